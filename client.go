@@ -2,7 +2,9 @@ package sdk
 
 import (
 	"context"
-	"strings"
+	"log/slog"
+	"net/http"
+	"time"
 
 	gql "github.com/Khan/genqlient/graphql"
 
@@ -10,6 +12,9 @@ import (
 	"github.com/raito-io/sdk-go/services"
 )
 
+// RaitoClient gives access to the clients of the different Raito Cloud resources.
+// A RaitoClient and the clients it returns are safe for concurrent use by multiple goroutines. They share the same connection pool and access token,
+// so a single RaitoClient should be created and reused.
 type RaitoClient struct {
 	accessProviderClient services.AccessProviderClient
 	dataObjectClient     services.DataObjectClient
@@ -18,21 +23,199 @@ type RaitoClient struct {
 	identityStoreClient  services.IdentityStoreClient
 	roleClient           services.RoleClient
 	userClient           services.UserClient
+	rawClient            services.RawClient
 }
 
 type ClientOptions struct {
-	UrlOverride string
+	UrlOverride      string
+	RetryMaxAttempts int
+	RetryBackoff     time.Duration
+	RetryMaxBackoff  time.Duration
+	RetryClassifier  RetryClassifier
+	MaxRateLimitWait time.Duration
+	OperationTimeout time.Duration
+	HttpClient       *http.Client
+	UserAgent        string
+	MaxResponseBytes int64
+
+	ClientCredentials *ClientCredentials
+
+	Logger          *slog.Logger
+	MetricsObserver MetricsObserver
+	Middlewares     []func(client gql.Client) gql.Client
+	Interceptors    []GraphQLInterceptor
 }
 
+// MetricsObserver is notified after each GraphQL round trip, e.g. to expose Prometheus metrics.
+type MetricsObserver = services.MetricsObserver
+
+// RetryClassifier decides whether a request is retried after a failed attempt, and how long to wait before the next attempt.
+// services.ExponentialRetryClassifier can be used as fallback in a custom RetryClassifier.
+type RetryClassifier = services.RetryClassifier
+
+// ClientCredentials holds the configuration of the OAuth2 client credentials flow.
+type ClientCredentials = internal.ClientCredentials
+
 // WithUrlOverride can be used to override the URL used to communicate with the Raito API.
+//
+// Deprecated: use WithBaseUrl, which sets the same option.
 func WithUrlOverride(urlOverride string) func(options *ClientOptions) {
+	return WithBaseUrl(urlOverride)
+}
+
+// WithBaseUrl can be used to specify the base URL of the Raito deployment to communicate with, e.g. a regional or staging deployment.
+// The GraphQL endpoint is derived from the base URL by appending the API path. Use NewValidatedClient to validate the URL when constructing the client.
+func WithBaseUrl(baseUrl string) func(options *ClientOptions) {
+	return func(options *ClientOptions) {
+		options.UrlOverride = baseUrl
+	}
+}
+
+// WithRetry can be used to retry queries failing with a transient error, such as a network error or an HTTP 502, 503 or 504 response.
+// A query is executed at most maxAttempts times. Mutations are never retried.
+// The delay between attempts grows exponentially from backoff with full jitter, capped at 30 seconds unless specified otherwise with WithBackoff.
+func WithRetry(maxAttempts int, backoff time.Duration) func(options *ClientOptions) {
+	return func(options *ClientOptions) {
+		options.RetryMaxAttempts = maxAttempts
+		options.RetryBackoff = backoff
+	}
+}
+
+// WithBackoff can be used in combination with WithRetry to tune the delay between attempts.
+// The delay before a retry is chosen randomly between 0 and base * 2^(attempt-1), capped at maxDelay.
+// The random jitter prevents many clients that failed at the same time from retrying at the same time.
+func WithBackoff(base, maxDelay time.Duration) func(options *ClientOptions) {
+	return func(options *ClientOptions) {
+		options.RetryBackoff = base
+		options.RetryMaxBackoff = maxDelay
+	}
+}
+
+// WithRetryClassifier can be used to override the default retry policy, deciding which failed requests are retried and how long to wait before the next attempt.
+// The classifier is called after each failed attempt with the error and the number of the attempt, starting at 1.
+// If WithRetry is also specified, a request is executed at most maxAttempts times. Mutations are never retried.
+func WithRetryClassifier(classifier RetryClassifier) func(options *ClientOptions) {
+	return func(options *ClientOptions) {
+		options.RetryClassifier = classifier
+	}
+}
+
+// WithRateLimitWait can be used to wait and retry requests that are rate limited by the Raito API.
+// The Retry-After header of the response is respected, waiting at most maxWait in total for a single request.
+// If the request is still rate limited after maxWait or after 10 retries, a types.ErrRateLimited is returned.
+// By default, rate limited requests are not retried.
+func WithRateLimitWait(maxWait time.Duration) func(options *ClientOptions) {
+	return func(options *ClientOptions) {
+		options.MaxRateLimitWait = maxWait
+	}
+}
+
+// WithOperationTimeout can be used to limit the duration of each GraphQL operation, including retries, without passing a context with a deadline to each method.
+// If the context passed to a method already has an earlier deadline, that deadline is used instead. A later deadline of the context is shortened to the timeout.
+// For list methods, the timeout applies to each page separately, not to the whole list.
+func WithOperationTimeout(timeout time.Duration) func(options *ClientOptions) {
 	return func(options *ClientOptions) {
-		options.UrlOverride = urlOverride
+		options.OperationTimeout = timeout
+	}
+}
+
+// WithHttpClient can be used to specify the http.Client used to communicate with Raito Cloud, e.g. to configure a proxy, custom CA certificates or timeouts.
+// Authentication headers are still added to each request sent with the given client.
+func WithHttpClient(httpClient *http.Client) func(options *ClientOptions) {
+	return func(options *ClientOptions) {
+		options.HttpClient = httpClient
+	}
+}
+
+// WithUserAgent can be used to identify the application in the User-Agent header of each request.
+// The given value is appended to the User-Agent of the SDK, e.g. "Raito SDK my-app/1.0".
+func WithUserAgent(userAgent string) func(options *ClientOptions) {
+	return func(options *ClientOptions) {
+		options.UserAgent = userAgent
+	}
+}
+
+// WithMaxResponseBytes can be used to limit the size of the response body of each request, protecting against queries returning huge payloads.
+// If a response exceeds the limit, a types.ErrResponseTooLarge is returned. By default, the size of responses is not limited.
+func WithMaxResponseBytes(maxBytes int64) func(options *ClientOptions) {
+	return func(options *ClientOptions) {
+		options.MaxResponseBytes = maxBytes
+	}
+}
+
+// WithClientCredentials can be used to authenticate with the OAuth2 client credentials flow, e.g. for service accounts.
+// An access token is requested at tokenUrl and is automatically renewed before it expires.
+// The user and secret passed to NewClient are ignored when this option is used.
+func WithClientCredentials(clientId, clientSecret, tokenUrl string) func(options *ClientOptions) {
+	return func(options *ClientOptions) {
+		options.ClientCredentials = &ClientCredentials{
+			ClientId:     clientId,
+			ClientSecret: clientSecret,
+			TokenUrl:     tokenUrl,
+		}
+	}
+}
+
+// WithLogger can be used to log each executed GraphQL operation at debug level, including its duration and error class.
+// Operation variables are never logged, as they may contain sensitive data.
+func WithLogger(logger *slog.Logger) func(options *ClientOptions) {
+	return func(options *ClientOptions) {
+		options.Logger = logger
+	}
+}
+
+// WithMetricsObserver can be used to observe the name, duration and error of each GraphQL round trip, e.g. to expose Prometheus metrics.
+// Retried requests are observed once per attempt.
+func WithMetricsObserver(observer MetricsObserver) func(options *ClientOptions) {
+	return func(options *ClientOptions) {
+		options.MetricsObserver = observer
+	}
+}
+
+// WithMiddleware can be used to wrap the GraphQL client used to communicate with the Raito API, e.g. to add tracing or metrics.
+// Middlewares are applied in the given order, so the last middleware is the outermost one.
+func WithMiddleware(middlewares ...func(client gql.Client) gql.Client) func(options *ClientOptions) {
+	return func(options *ClientOptions) {
+		options.Middlewares = append(options.Middlewares, middlewares...)
+	}
+}
+
+// GraphQLInterceptor is called for each GraphQL request and must call next to execute it.
+// It can inspect or modify the context, the request and the response, e.g. to add tracing or to strip fields.
+type GraphQLInterceptor = services.GraphQLInterceptor
+
+// WithGraphQLInterceptor can be used to inspect or modify each GraphQL request sent to the Raito API.
+// Interceptors are applied in the given order, so the last interceptor is the outermost one and is called first.
+// Interceptors are called once per attempt, after the retry and operation timeout are applied, and before the authentication headers are added.
+func WithGraphQLInterceptor(interceptors ...GraphQLInterceptor) func(options *ClientOptions) {
+	return func(options *ClientOptions) {
+		options.Interceptors = append(options.Interceptors, interceptors...)
 	}
 }
 
 // NewClient creates a new RaitoClient with the given credentials.
+// The domain and base URL are not validated until the first request. Use NewValidatedClient to validate them upfront.
 func NewClient(ctx context.Context, domain, user, secret string, ops ...func(options *ClientOptions)) *RaitoClient {
+	return newClient(domain, user, secret, clientOptions(ops))
+}
+
+// NewValidatedClient creates a new RaitoClient with the given credentials, like NewClient.
+// A types.ErrInvalidInput is returned if the domain or the base URL is invalid, instead of failing on the first request.
+func NewValidatedClient(ctx context.Context, domain, user, secret string, ops ...func(options *ClientOptions)) (*RaitoClient, error) {
+	options := clientOptions(ops)
+
+	if err := internal.ValidateBaseUrl(options.UrlOverride); err != nil {
+		return nil, err
+	}
+
+	if err := internal.ValidateDomain(domain); err != nil {
+		return nil, err
+	}
+
+	return newClient(domain, user, secret, options), nil
+}
+
+func clientOptions(ops []func(options *ClientOptions)) ClientOptions {
 	options := ClientOptions{
 		UrlOverride: internal.DefaultApiEndpoint,
 	}
@@ -41,62 +224,136 @@ func NewClient(ctx context.Context, domain, user, secret string, ops ...func(opt
 		op(&options)
 	}
 
-	url := options.UrlOverride
-	if !strings.HasSuffix(url, "/") {
-		url += "/"
-	}
-
-	url += internal.GqlApiPath
+	return options
+}
 
-	client := gql.NewClient(url, &internal.AuthedDoer{
+func newClient(domain, user, secret string, options ClientOptions) *RaitoClient {
+	client := gql.NewClient(internal.GqlEndpoint(options.UrlOverride), &internal.AuthedDoer{
 		Domain: domain,
 		User:   user,
 		Secret: secret,
 		Url:    options.UrlOverride,
+
+		HttpClient:       options.HttpClient,
+		MaxRateLimitWait: options.MaxRateLimitWait,
+		UserAgent:        options.UserAgent,
+		MaxResponseBytes: options.MaxResponseBytes,
+
+		ClientCredentials: options.ClientCredentials,
 	})
 
+	serviceOps := []func(options *services.ClientOptions){
+		services.WithRetry(options.RetryMaxAttempts, options.RetryBackoff),
+		services.WithBackoff(options.RetryBackoff, options.RetryMaxBackoff),
+		services.WithOperationTimeout(options.OperationTimeout),
+	}
+
+	if options.RetryClassifier != nil {
+		serviceOps = append(serviceOps, services.WithRetryClassifier(options.RetryClassifier))
+	}
+
+	if options.Logger != nil {
+		serviceOps = append(serviceOps, services.WithLogger(options.Logger))
+	}
+
+	if options.MetricsObserver != nil {
+		serviceOps = append(serviceOps, services.WithMetricsObserver(options.MetricsObserver))
+	}
+
+	if len(options.Middlewares) > 0 {
+		serviceOps = append(serviceOps, services.WithMiddleware(options.Middlewares...))
+	}
+
+	if len(options.Interceptors) > 0 {
+		serviceOps = append(serviceOps, services.WithGraphQLInterceptor(options.Interceptors...))
+	}
+
 	return &RaitoClient{
-		accessProviderClient: services.NewAccessProviderClient(client),
-		dataObjectClient:     services.NewDataObjectClient(client),
-		dataSourceClient:     services.NewDataSourceClient(client),
-		grantCategoryClient:  services.NewGrantCategoryClient(client),
-		identityStoreClient:  services.NewIdentityStoreClient(client),
-		roleClient:           services.NewRoleClient(client),
-		userClient:           services.NewUserClient(client),
+		accessProviderClient: services.NewAccessProviderClient(client, serviceOps...),
+		dataObjectClient:     services.NewDataObjectClient(client, serviceOps...),
+		dataSourceClient:     services.NewDataSourceClient(client, serviceOps...),
+		grantCategoryClient:  services.NewGrantCategoryClient(client, serviceOps...),
+		identityStoreClient:  services.NewIdentityStoreClient(client, serviceOps...),
+		roleClient:           services.NewRoleClient(client, serviceOps...),
+		userClient:           services.NewUserClient(client, serviceOps...),
+		rawClient:            services.NewRawClient(client, serviceOps...),
 	}
 }
 
-// AccessProvider returns the AccessProviderClient
+// AccessProvider returns the AccessProviderClient, which implements services.AccessProviderService
 func (c *RaitoClient) AccessProvider() *services.AccessProviderClient {
 	return &c.accessProviderClient
 }
 
-// DataObject returns the DataObjectClient
+// DataObject returns the DataObjectClient, which implements services.DataObjectService
 func (c *RaitoClient) DataObject() *services.DataObjectClient {
 	return &c.dataObjectClient
 }
 
-// DataSource returns the DataSourceClient
+// DataSource returns the DataSourceClient, which implements services.DataSourceService
 func (c *RaitoClient) DataSource() *services.DataSourceClient {
 	return &c.dataSourceClient
 }
 
-// GrantCategory returns the GrantCategoryClient
+// GrantCategory returns the GrantCategoryClient, which implements services.GrantCategoryService
 func (c *RaitoClient) GrantCategory() *services.GrantCategoryClient {
 	return &c.grantCategoryClient
 }
 
-// IdentityStore returns the IdentityStoreClient
+// IdentityStore returns the IdentityStoreClient, which implements services.IdentityStoreService
 func (c *RaitoClient) IdentityStore() *services.IdentityStoreClient {
 	return &c.identityStoreClient
 }
 
-// Role returns the RoleClient
+// Role returns the RoleClient, which implements services.RoleService
 func (c *RaitoClient) Role() *services.RoleClient {
 	return &c.roleClient
 }
 
-// User returns the UserClient
+// User returns the UserClient, which implements services.UserService
 func (c *RaitoClient) User() *services.UserClient {
 	return &c.userClient
 }
+
+// RawClient returns the RawClient, which can be used to execute custom GraphQL operations with the same authentication and options as the other clients.
+// The RawClient implements services.RawService.
+func (c *RaitoClient) RawClient() *services.RawClient {
+	return &c.rawClient
+}
+
+const pingQuery = `query Ping { currentUser { id } }`
+
+// Ping verifies the connection with Raito Cloud and the credentials of the client by executing a trivial authenticated GraphQL query.
+// Nil is returned on success. If no valid token can be obtained or the token is rejected, the error matches types.ErrUnauthenticated.
+// Other failures, such as network errors, result in a types.ErrClient.
+// This can be used to fail fast at startup, before running long operations.
+func (c *RaitoClient) Ping(ctx context.Context) error {
+	var result struct {
+		CurrentUser struct {
+			Id string `json:"id"`
+		} `json:"currentUser"`
+	}
+
+	return c.rawClient.Query(ctx, pingQuery, nil, &result)
+}
+
+// WithCorrelationId returns a copy of ctx carrying the given correlation id.
+// The correlation id is sent as X-Request-ID header with each request made with the returned context, so the requests can be correlated with Raito support.
+// If no correlation id is set, a random one is generated for each operation. The correlation id of a failed operation can be retrieved with types.CorrelationIdFromError.
+func WithCorrelationId(ctx context.Context, id string) context.Context {
+	return internal.WithCorrelationId(ctx, id)
+}
+
+// WithRequestHeaders returns a copy of ctx carrying the given headers, which are sent with each request made with the returned context.
+// This can be used to change a header for a single operation, without creating a new client.
+// Per-call headers take precedence over the headers set by the client, such as User-Agent and Raito-Domain.
+// The Authorization and X-Request-ID headers can't be overwritten; use WithCorrelationId to set the correlation id.
+// Calling WithRequestHeaders on a context that already carries headers merges both, with the new headers taking precedence.
+func WithRequestHeaders(ctx context.Context, headers map[string]string) context.Context {
+	return internal.WithRequestHeaders(ctx, headers)
+}
+
+// CorrelationId returns the correlation id carried by ctx, if any.
+func CorrelationId(ctx context.Context) (string, bool) {
+	return internal.CorrelationId(ctx)
+}
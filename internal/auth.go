@@ -8,17 +8,21 @@ import (
 	"net/http"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	idp "github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+
+	"github.com/raito-io/sdk-go/types"
 )
 
 type userTokens struct {
 	userName     string
 	idToken      string
 	refreshToken string
+	tokenType    string
 	expiration   *time.Time
 }
 
@@ -33,31 +37,164 @@ type AuthedDoer struct {
 	Secret string
 	Url    string
 
+	// HttpClient is used for all HTTP requests. If nil, a default http.Client is used.
+	HttpClient *http.Client
+
+	// MaxRateLimitWait is the maximum total time to wait for rate limited requests before retrying them.
+	// If 0, rate limited requests are not retried and an ErrRateLimited is returned.
+	// A request is retried at most MaxRateLimitRetries times.
+	MaxRateLimitWait time.Duration
+
+	// MaxResponseBytes is the maximum size of a response body. Reading more returns an ErrResponseTooLarge.
+	// If 0, the size of responses is not limited.
+	MaxResponseBytes int64
+
+	// UserAgent is appended to the default User-Agent header of the SDK.
+	UserAgent string
+
+	// ClientCredentials enables the OAuth2 client credentials flow. If set, User and Secret are ignored.
+	ClientCredentials *ClientCredentials
+
 	clientAppId string
 
+	mutex sync.Mutex
 	token *userTokens
 }
 
 func (d *AuthedDoer) Do(req *http.Request) (*http.Response, error) {
-	req.Header.Set("User-Agent", "Raito SDK")
+	req.Header.Set("User-Agent", d.userAgent())
 	req.Header.Set("Raito-Domain", d.Domain)
 
+	if id, ok := CorrelationId(req.Context()); ok {
+		req.Header.Set(CorrelationIdHeader, id)
+	}
+
+	setRequestHeaders(req.Context(), req.Header)
+
 	err := d.addTokenToHeader(req.Context(), &req.Header)
 	if err != nil {
-		return nil, fmt.Errorf("get token: %w", err)
+		return nil, types.NewErrUnauthenticated(fmt.Errorf("get token: %w", err))
 	}
 
-	client := &http.Client{}
+	client := d.httpClient()
 
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("error while doing HTTP POST to %q: %s", req.URL.String(), err.Error())
+	var waited time.Duration
+
+	rateLimitRetries := 0
+	refreshed := false
+
+	for {
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("error while doing HTTP POST to %q: %w", req.URL.String(), err)
+		}
+
+		if resp.StatusCode == http.StatusUnauthorized {
+			resp.Body.Close()
+
+			if refreshed || req.GetBody == nil {
+				return nil, types.NewErrUnauthenticated(fmt.Errorf("unexpected status code %d received when calling URL %q", resp.StatusCode, req.URL.String()))
+			}
+
+			refreshed = true
+
+			req, err = rewindRequest(req)
+			if err != nil {
+				return nil, err
+			}
+
+			err = d.refreshTokenInHeader(req.Context(), &req.Header)
+			if err != nil {
+				return nil, types.NewErrUnauthenticated(err)
+			}
+
+			continue
+		}
+
+		if resp.StatusCode != http.StatusTooManyRequests {
+			return d.limitResponse(resp)
+		}
+
+		retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+
+		resp.Body.Close()
+
+		if d.MaxRateLimitWait <= 0 || waited+retryAfter > d.MaxRateLimitWait || rateLimitRetries >= MaxRateLimitRetries || req.GetBody == nil {
+			return nil, types.NewErrRateLimited(retryAfter)
+		}
+
+		err = sleepWithContext(req.Context(), retryAfter)
+		if err != nil {
+			return nil, err
+		}
+
+		waited += retryAfter
+		rateLimitRetries++
+
+		req, err = rewindRequest(req)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// limitResponse enforces MaxResponseBytes on the body of resp.
+func (d *AuthedDoer) limitResponse(resp *http.Response) (*http.Response, error) {
+	if d.MaxResponseBytes <= 0 {
+		return resp, nil
 	}
 
+	if resp.ContentLength > d.MaxResponseBytes {
+		resp.Body.Close()
+
+		return nil, types.NewErrResponseTooLarge(d.MaxResponseBytes)
+	}
+
+	resp.Body = &maxBytesBody{ReadCloser: resp.Body, remaining: d.MaxResponseBytes, limit: d.MaxResponseBytes}
+
 	return resp, nil
 }
 
+// maxBytesBody returns an ErrResponseTooLarge once more than limit bytes are read from the wrapped body.
+type maxBytesBody struct {
+	io.ReadCloser
+	remaining int64
+	limit     int64
+	err       error
+}
+
+func (b *maxBytesBody) Read(p []byte) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	// Read one byte more than allowed to detect if the limit is exceeded
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+
+	n, err := b.ReadCloser.Read(p)
+	if int64(n) <= b.remaining {
+		b.remaining -= int64(n)
+
+		return n, err
+	}
+
+	n = int(b.remaining)
+	b.remaining = 0
+	b.err = types.NewErrResponseTooLarge(b.limit)
+
+	return n, b.err
+}
+
 func (d *AuthedDoer) addTokenToHeader(ctx context.Context, h *http.Header) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
 	if d.token == nil {
 		d.token = &userTokens{userName: d.User}
 	}
@@ -67,18 +204,56 @@ func (d *AuthedDoer) addTokenToHeader(ctx context.Context, h *http.Header) error
 		return fmt.Errorf("update token: %w", err)
 	}
 
-	h.Add("Authorization", "token "+d.token.idToken)
+	h.Add("Authorization", d.authorizationHeader())
 
 	return nil
 }
 
+// refreshTokenInHeader invalidates the token used in h, if it was not already replaced by another request, and adds a new token to h.
+func (d *AuthedDoer) refreshTokenInHeader(ctx context.Context, h *http.Header) error {
+	d.mutex.Lock()
+
+	if d.token != nil && h.Get("Authorization") == d.authorizationHeader() {
+		d.token.idToken = ""
+		d.token.expiration = nil
+	}
+
+	d.mutex.Unlock()
+
+	h.Del("Authorization")
+
+	return d.addTokenToHeader(ctx, h)
+}
+
+func (d *AuthedDoer) authorizationHeader() string {
+	tokenType := d.token.tokenType
+	if tokenType == "" {
+		tokenType = "token"
+	}
+
+	return tokenType + " " + d.token.idToken
+}
+
 func (d *AuthedDoer) updateToken(ctx context.Context) error {
+	if d.ClientCredentials != nil {
+		if checkAccessTokenValidity(d.token) {
+			return nil
+		}
+
+		err := d.fetchClientCredentialsToken(ctx)
+		if err != nil {
+			return fmt.Errorf("fetch client credentials token: %w", err)
+		}
+
+		return nil
+	}
+
 	if checkTokenValidity(d.token) {
 		return nil
 	}
 
 	if d.clientAppId == "" {
-		clientAppId, err := fetchClientAppId(d.Url, d.Domain)
+		clientAppId, err := fetchClientAppId(d.httpClient(), d.Url, d.Domain)
 		if err != nil {
 			return fmt.Errorf("fetch client app id: %w", err)
 		}
@@ -102,13 +277,13 @@ func (d *AuthedDoer) updateToken(ctx context.Context) error {
 }
 
 func (d *AuthedDoer) fetchNewToken(ctx context.Context) error {
-	cfg, err := loadConfig(ctx)
+	cfg, err := loadConfig(ctx, d.HttpClient)
 	if err != nil {
 		return err
 	}
 
 	idpClient := idp.NewFromConfig(cfg)
-	output, err := idpClient.InitiateAuth(context.TODO(), &idp.InitiateAuthInput{
+	output, err := idpClient.InitiateAuth(ctx, &idp.InitiateAuthInput{
 		AuthFlow:       "USER_PASSWORD_AUTH",
 		ClientId:       &d.clientAppId,
 		AuthParameters: map[string]string{"USERNAME": d.User, "PASSWORD": d.Secret},
@@ -127,7 +302,7 @@ func (d *AuthedDoer) fetchNewToken(ctx context.Context) error {
 }
 
 func (d *AuthedDoer) refreshToken(ctx context.Context) error {
-	cfg, err := loadConfig(ctx)
+	cfg, err := loadConfig(ctx, d.HttpClient)
 	if err != nil {
 		return err
 	}
@@ -151,13 +326,33 @@ func (d *AuthedDoer) refreshToken(ctx context.Context) error {
 	return nil
 }
 
-func loadConfig(ctx context.Context) (aws.Config, error) {
+func (d *AuthedDoer) userAgent() string {
+	if d.UserAgent == "" {
+		return UserAgent
+	}
+
+	return UserAgent + " " + d.UserAgent
+}
+
+func (d *AuthedDoer) httpClient() *http.Client {
+	if d.HttpClient != nil {
+		return d.HttpClient
+	}
+
+	return &http.Client{}
+}
+
+func loadConfig(ctx context.Context, httpClient *http.Client) (aws.Config, error) {
 	// TODO configurable region
 	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion("eu-central-1"))
 	if err != nil {
 		return aws.Config{}, fmt.Errorf("error while configuring AWS SDK: %w", err)
 	}
 
+	if httpClient != nil {
+		cfg.HTTPClient = httpClient
+	}
+
 	return cfg, nil
 }
 
@@ -181,7 +376,7 @@ func handleAuthOutput(output *idp.InitiateAuthOutput, tokens *userTokens) error
 	}
 }
 
-func fetchClientAppId(urlBase, domain string) (string, error) {
+func fetchClientAppId(client *http.Client, urlBase, domain string) (string, error) {
 	if domain == "" {
 		return "", fmt.Errorf("no domain specified")
 	}
@@ -202,8 +397,6 @@ func fetchClientAppId(urlBase, domain string) (string, error) {
 		return "", fmt.Errorf("error while creating HTTP GET request to %q: %s", url, err.Error())
 	}
 
-	client := &http.Client{}
-
 	resp, err := client.Do(req)
 	if err != nil {
 		return "", fmt.Errorf("error while doing HTTP GET to %q: %s", url, err.Error())
@@ -0,0 +1,321 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/raito-io/sdk-go/types"
+)
+
+type countingTransport struct {
+	requests []*http.Request
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests = append(t.requests, req)
+
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestAuthedDoer_HttpClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	transport := &countingTransport{}
+
+	doer := newTestAuthedDoer(0)
+	doer.HttpClient = &http.Client{Transport: transport}
+
+	resp, err := doer.Do(newTestRequest(t, server.URL))
+
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Len(t, transport.requests, 1)
+	assert.Equal(t, "token id-token", transport.requests[0].Header.Get("Authorization"))
+	assert.Equal(t, "test", transport.requests[0].Header.Get("Raito-Domain"))
+	assert.Equal(t, "Raito SDK", transport.requests[0].Header.Get("User-Agent"))
+}
+
+func TestAuthedDoer_UserAgent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	transport := &countingTransport{}
+
+	doer := newTestAuthedDoer(0)
+	doer.HttpClient = &http.Client{Transport: transport}
+	doer.UserAgent = "my-app/1.0"
+
+	resp, err := doer.Do(newTestRequest(t, server.URL))
+
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, "Raito SDK my-app/1.0", transport.requests[0].Header.Get("User-Agent"))
+}
+
+func TestAuthedDoer_MaxResponseBytes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("chunked") != "" {
+			w.(http.Flusher).Flush()
+		}
+
+		_, _ = w.Write([]byte(`{"data": {"id": "123"}}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		url      string
+		maxBytes int64
+		wantErr  bool
+	}{
+		{name: "unlimited", url: server.URL, maxBytes: 0},
+		{name: "within limit", url: server.URL + "?chunked=1", maxBytes: 23},
+		{name: "content length exceeded", url: server.URL, maxBytes: 10, wantErr: true},
+		{name: "body exceeded", url: server.URL + "?chunked=1", maxBytes: 22, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doer := newTestAuthedDoer(0)
+			doer.MaxResponseBytes = tt.maxBytes
+
+			resp, err := doer.Do(newTestRequest(t, tt.url))
+			if err == nil {
+				defer resp.Body.Close()
+
+				_, err = io.ReadAll(resp.Body)
+			}
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, &types.ErrResponseTooLarge{})
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthedDoer_RequestHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	transport := &countingTransport{}
+
+	doer := newTestAuthedDoer(0)
+	doer.HttpClient = &http.Client{Transport: transport}
+
+	ctx := WithRequestHeaders(context.Background(), map[string]string{"raito-domain": "other", "X-Custom": "a"})
+	ctx = WithRequestHeaders(ctx, map[string]string{"X-Custom": "b", "Authorization": "token other"})
+
+	req := newTestRequest(t, server.URL)
+
+	resp, err := doer.Do(req.WithContext(ctx))
+
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, "other", transport.requests[0].Header.Get("Raito-Domain"))
+	assert.Equal(t, "b", transport.requests[0].Header.Get("X-Custom"))
+	assert.Equal(t, []string{"token id-token"}, transport.requests[0].Header.Values("Authorization"))
+}
+
+func TestAuthedDoer_ClientCredentials(t *testing.T) {
+	var tokenRequests atomic.Int32
+
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests.Add(1)
+
+		clientId, clientSecret, _ := r.BasicAuth()
+		if clientId != "client-id" || clientSecret != "client-secret" || r.FormValue("grant_type") != "client_credentials" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"access-token","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer tokenServer.Close()
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer apiServer.Close()
+
+	doer := &AuthedDoer{
+		Domain: "test",
+		ClientCredentials: &ClientCredentials{
+			ClientId:     "client-id",
+			ClientSecret: "client-secret",
+			TokenUrl:     tokenServer.URL,
+		},
+	}
+
+	var wg sync.WaitGroup
+
+	for range 10 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			resp, err := doer.Do(newTestRequest(t, apiServer.URL))
+			if assert.NoError(t, err) {
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+				resp.Body.Close()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, int32(1), tokenRequests.Load())
+}
+
+func TestAuthedDoer_RefreshOnUnauthorized(t *testing.T) {
+	t.Run("refresh and retry", testAuthedDoerRefreshAndRetry)
+	t.Run("refresh failure", testAuthedDoerRefreshFailure)
+	t.Run("still unauthorized", testAuthedDoerStillUnauthorized)
+	t.Run("invalid credentials", testAuthedDoerInvalidCredentials)
+}
+
+func newTestTokenServer(tokenStatusCodes ...int) (*httptest.Server, *atomic.Int32) {
+	var tokenRequests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i := int(tokenRequests.Add(1))
+
+		if i <= len(tokenStatusCodes) && tokenStatusCodes[i-1] != http.StatusOK {
+			w.WriteHeader(tokenStatusCodes[i-1])
+
+			return
+		}
+
+		_, _ = fmt.Fprintf(w, `{"access_token":"access-token-%d","token_type":"Bearer","expires_in":3600}`, i)
+	}))
+
+	return server, &tokenRequests
+}
+
+func newTestClientCredentialsDoer(tokenUrl string) *AuthedDoer {
+	return &AuthedDoer{
+		Domain: "test",
+		ClientCredentials: &ClientCredentials{
+			ClientId:     "client-id",
+			ClientSecret: "client-secret",
+			TokenUrl:     tokenUrl,
+		},
+	}
+}
+
+func testAuthedDoerRefreshAndRetry(t *testing.T) {
+	tokenServer, tokenRequests := newTestTokenServer()
+	defer tokenServer.Close()
+
+	var bodies []string
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+
+		if r.Header.Get("Authorization") != "Bearer access-token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer apiServer.Close()
+
+	doer := newTestClientCredentialsDoer(tokenServer.URL)
+
+	resp, err := doer.Do(newTestRequest(t, apiServer.URL))
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	assert.Equal(t, int32(2), tokenRequests.Load())
+	assert.Equal(t, []string{`{"query":""}`, `{"query":""}`}, bodies)
+}
+
+func testAuthedDoerRefreshFailure(t *testing.T) {
+	tokenServer, _ := newTestTokenServer(http.StatusOK, http.StatusBadRequest)
+	defer tokenServer.Close()
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer apiServer.Close()
+
+	doer := newTestClientCredentialsDoer(tokenServer.URL)
+
+	_, err := doer.Do(newTestRequest(t, apiServer.URL))
+
+	assert.ErrorIs(t, err, &types.ErrUnauthenticated{})
+}
+
+func testAuthedDoerInvalidCredentials(t *testing.T) {
+	tokenServer, _ := newTestTokenServer(http.StatusUnauthorized)
+	defer tokenServer.Close()
+
+	var apiRequests atomic.Int32
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiRequests.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer apiServer.Close()
+
+	doer := newTestClientCredentialsDoer(tokenServer.URL)
+
+	_, err := doer.Do(newTestRequest(t, apiServer.URL))
+
+	assert.ErrorIs(t, err, &types.ErrUnauthenticated{})
+	assert.Equal(t, int32(0), apiRequests.Load())
+}
+
+func testAuthedDoerStillUnauthorized(t *testing.T) {
+	tokenServer, tokenRequests := newTestTokenServer()
+	defer tokenServer.Close()
+
+	var apiRequests atomic.Int32
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiRequests.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer apiServer.Close()
+
+	doer := newTestClientCredentialsDoer(tokenServer.URL)
+
+	_, err := doer.Do(newTestRequest(t, apiServer.URL))
+
+	assert.ErrorIs(t, err, &types.ErrUnauthenticated{})
+	assert.Equal(t, int32(2), apiRequests.Load())
+	assert.Equal(t, int32(2), tokenRequests.Load())
+}
@@ -0,0 +1,64 @@
+package internal
+
+import (
+	"context"
+	"sync"
+
+	"github.com/raito-io/sdk-go/types"
+)
+
+// BatchExecutor calls fn for every input, running at most concurrency calls at the same time.
+// The outputs and errors are returned in the same order as the inputs.
+// A failing call does not stop the execution of the other inputs.
+// Once ctx is done, no new calls are started. The calls in flight receive the cancelled ctx, and the error of each input that was not attempted is a types.ErrNotAttempted.
+func BatchExecutor[I any, O any](ctx context.Context, inputs []I, concurrency int, fn func(ctx context.Context, input I) (O, error)) ([]O, []error) {
+	outputs := make([]O, len(inputs))
+	errs := make([]error, len(inputs))
+
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	semaphore := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+
+	for i := range inputs {
+		if !acquire(ctx, semaphore) {
+			for j := i; j < len(inputs); j++ {
+				errs[j] = types.NewErrNotAttempted(ctx.Err())
+			}
+
+			break
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			outputs[i], errs[i] = fn(ctx, inputs[i])
+		}()
+	}
+
+	wg.Wait()
+
+	return outputs, errs
+}
+
+// acquire takes a slot of the semaphore, returning false without taking a slot if ctx is done first.
+func acquire(ctx context.Context, semaphore chan struct{}) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	select {
+	case semaphore <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,97 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/raito-io/sdk-go/types"
+)
+
+func TestBatchExecutor(t *testing.T) {
+	t.Run("TestBatchExecutor_Success", testBatchExecutorSuccess)
+	t.Run("TestBatchExecutor_PartialFailure", testBatchExecutorPartialFailure)
+	t.Run("TestBatchExecutor_Concurrency", testBatchExecutorConcurrency)
+	t.Run("TestBatchExecutor_Cancel", testBatchExecutorCancel)
+}
+
+func testBatchExecutorSuccess(t *testing.T) {
+	outputs, errs := BatchExecutor(context.Background(), []int{1, 2, 3}, 2, func(ctx context.Context, input int) (int, error) {
+		return input * 2, nil
+	})
+
+	assert.Equal(t, []int{2, 4, 6}, outputs)
+	assert.Equal(t, []error{nil, nil, nil}, errs)
+}
+
+func testBatchExecutorPartialFailure(t *testing.T) {
+	expectedErr := errors.New("item error")
+
+	outputs, errs := BatchExecutor(context.Background(), []int{1, 2, 3}, 2, func(ctx context.Context, input int) (int, error) {
+		if input == 2 {
+			return 0, expectedErr
+		}
+
+		return input * 2, nil
+	})
+
+	assert.Equal(t, []int{2, 0, 6}, outputs)
+	assert.Equal(t, []error{nil, expectedErr, nil}, errs)
+}
+
+func testBatchExecutorConcurrency(t *testing.T) {
+	var running, maxRunning int32
+
+	_, errs := BatchExecutor(context.Background(), make([]int, 20), 3, func(ctx context.Context, input int) (int, error) {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+
+		for {
+			currentMax := atomic.LoadInt32(&maxRunning)
+			if current <= currentMax || atomic.CompareAndSwapInt32(&maxRunning, currentMax, current) {
+				break
+			}
+		}
+
+		time.Sleep(time.Millisecond)
+
+		return input, nil
+	})
+
+	assert.Len(t, errs, 20)
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxRunning), int32(3))
+}
+
+func testBatchExecutorCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var calls []int
+
+	outputs, errs := BatchExecutor(ctx, []int{1, 2, 3, 4}, 1, func(ctx context.Context, input int) (int, error) {
+		calls = append(calls, input)
+
+		if input == 2 {
+			cancel()
+
+			return 0, ctx.Err()
+		}
+
+		return input * 2, nil
+	})
+
+	assert.Equal(t, []int{1, 2}, calls)
+	assert.Equal(t, []int{2, 0, 0, 0}, outputs)
+	assert.NoError(t, errs[0])
+	assert.ErrorIs(t, errs[1], context.Canceled)
+	assert.NotErrorIs(t, errs[1], &types.ErrNotAttempted{})
+
+	for _, err := range errs[2:] {
+		assert.ErrorIs(t, err, &types.ErrNotAttempted{})
+		assert.ErrorIs(t, err, context.Canceled)
+	}
+}
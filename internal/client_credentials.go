@@ -0,0 +1,87 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ClientCredentials holds the configuration of the OAuth2 client credentials flow.
+type ClientCredentials struct {
+	ClientId     string
+	ClientSecret string
+	TokenUrl     string
+}
+
+type clientCredentialsResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+func (d *AuthedDoer) fetchClientCredentialsToken(ctx context.Context) error {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.ClientCredentials.TokenUrl, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("error while creating HTTP POST request to %q: %w", d.ClientCredentials.TokenUrl, err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(d.ClientCredentials.ClientId), url.QueryEscape(d.ClientCredentials.ClientSecret))
+
+	resp, err := d.httpClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("error while doing HTTP POST to %q: %w", d.ClientCredentials.TokenUrl, err)
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error while reading body for call to %q: %w", d.ClientCredentials.TokenUrl, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d received when calling URL %q: %s", resp.StatusCode, d.ClientCredentials.TokenUrl, string(body))
+	}
+
+	tokenResponse := clientCredentialsResponse{}
+
+	err = json.Unmarshal(body, &tokenResponse)
+	if err != nil {
+		return fmt.Errorf("error while parsing token response from %q: %w", d.ClientCredentials.TokenUrl, err)
+	}
+
+	if tokenResponse.AccessToken == "" {
+		return fmt.Errorf("no access token found in token response from %q", d.ClientCredentials.TokenUrl)
+	}
+
+	d.token.idToken = tokenResponse.AccessToken
+	d.token.tokenType = tokenResponse.TokenType
+
+	if d.token.tokenType == "" {
+		d.token.tokenType = "Bearer"
+	}
+
+	e := time.Now().Add(time.Second * time.Duration(tokenResponse.ExpiresIn))
+	d.token.expiration = &e
+
+	return nil
+}
+
+func checkAccessTokenValidity(token *userTokens) bool {
+	if token.idToken == "" || token.expiration == nil {
+		return false
+	}
+
+	now := time.Now().Add(time.Second * 10)
+
+	return now.Before(*token.expiration)
+}
@@ -1,6 +1,16 @@
 package internal
 
+import "time"
+
 const DefaultApiEndpoint = "https://api.raito.cloud/"
 const GqlApiPath = "query"
+const UserAgent = "Raito SDK"
 
 const MaxPageSize = 25
+const MaxServerPageSize = 1000
+
+const DefaultBatchConcurrency = 10
+
+const DefaultMaxRetryBackoff = 30 * time.Second
+
+const DefaultWhatLeafMaxDepth = 10
@@ -0,0 +1,63 @@
+package internal
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/Khan/genqlient/graphql"
+
+	"github.com/raito-io/sdk-go/types"
+)
+
+const CorrelationIdHeader = "X-Request-ID"
+
+type correlationIdKey struct{}
+
+// WithCorrelationId returns a copy of ctx carrying the given correlation id, which is sent as X-Request-ID header.
+func WithCorrelationId(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIdKey{}, id)
+}
+
+// CorrelationId returns the correlation id carried by ctx, if any.
+func CorrelationId(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(correlationIdKey{}).(string)
+
+	return id, ok && id != ""
+}
+
+// CorrelationClient is a graphql.Client that ensures each request has a correlation id.
+// If the context of a request does not carry a correlation id, a random one is generated.
+// Errors are wrapped in a types.ErrCorrelated, so the correlation id of a failed request can be logged.
+type CorrelationClient struct {
+	client graphql.Client
+}
+
+// NewCorrelationClient wraps client in a CorrelationClient.
+func NewCorrelationClient(client graphql.Client) *CorrelationClient {
+	return &CorrelationClient{
+		client: client,
+	}
+}
+
+func (c *CorrelationClient) MakeRequest(ctx context.Context, req *graphql.Request, resp *graphql.Response) error {
+	id, ok := CorrelationId(ctx)
+	if !ok {
+		id = newCorrelationId()
+		ctx = WithCorrelationId(ctx, id)
+	}
+
+	err := c.client.MakeRequest(ctx, req, resp)
+	if err != nil {
+		return types.NewErrCorrelated(id, err)
+	}
+
+	return nil
+}
+
+func newCorrelationId() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,74 @@
+package internal
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/raito-io/sdk-go/types"
+)
+
+type correlationRecordingClient struct {
+	ids []string
+	err error
+}
+
+func (c *correlationRecordingClient) MakeRequest(ctx context.Context, _ *graphql.Request, _ *graphql.Response) error {
+	id, _ := CorrelationId(ctx)
+	c.ids = append(c.ids, id)
+
+	return c.err
+}
+
+func TestCorrelationClient(t *testing.T) {
+	t.Run("Given id", func(t *testing.T) {
+		fake := &correlationRecordingClient{err: serviceUnavailableErr}
+		client := NewCorrelationClient(fake)
+
+		err := client.MakeRequest(WithCorrelationId(context.Background(), "my-id"), queryRequest, &graphql.Response{})
+
+		id, ok := types.CorrelationIdFromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, "my-id", id)
+		assert.ErrorIs(t, err, serviceUnavailableErr)
+		assert.Equal(t, []string{"my-id"}, fake.ids)
+	})
+
+	t.Run("Generated id", func(t *testing.T) {
+		fake := &correlationRecordingClient{}
+		client := NewCorrelationClient(fake)
+
+		assert.NoError(t, client.MakeRequest(context.Background(), queryRequest, &graphql.Response{}))
+		assert.NoError(t, client.MakeRequest(context.Background(), queryRequest, &graphql.Response{}))
+
+		assert.Len(t, fake.ids, 2)
+		assert.Len(t, fake.ids[0], 32)
+		assert.NotEqual(t, fake.ids[0], fake.ids[1])
+	})
+}
+
+func TestAuthedDoer_CorrelationId(t *testing.T) {
+	var header string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get(CorrelationIdHeader)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	doer := newTestAuthedDoer(0)
+
+	req := newTestRequest(t, server.URL)
+	req = req.WithContext(WithCorrelationId(req.Context(), "my-id"))
+
+	resp, err := doer.Do(req)
+
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, "my-id", header)
+}
@@ -0,0 +1,53 @@
+package internal
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/raito-io/sdk-go/types"
+)
+
+// GqlEndpoint returns the GraphQL endpoint of the Raito API with the given base URL.
+func GqlEndpoint(baseUrl string) string {
+	if !strings.HasSuffix(baseUrl, "/") {
+		baseUrl += "/"
+	}
+
+	return baseUrl + GqlApiPath
+}
+
+// ValidateBaseUrl returns an error if baseUrl is not an absolute http or https URL.
+func ValidateBaseUrl(baseUrl string) error {
+	parsedUrl, err := url.Parse(baseUrl)
+	if err != nil {
+		return types.NewErrInvalidInput(fmt.Sprintf("invalid base URL %q: %s", baseUrl, err.Error()))
+	}
+
+	if parsedUrl.Scheme != "http" && parsedUrl.Scheme != "https" {
+		return types.NewErrInvalidInput(fmt.Sprintf("invalid base URL %q: scheme should be http or https", baseUrl))
+	}
+
+	if parsedUrl.Host == "" {
+		return types.NewErrInvalidInput(fmt.Sprintf("invalid base URL %q: no host specified", baseUrl))
+	}
+
+	if parsedUrl.RawQuery != "" || parsedUrl.Fragment != "" {
+		return types.NewErrInvalidInput(fmt.Sprintf("invalid base URL %q: query and fragment are not supported", baseUrl))
+	}
+
+	return nil
+}
+
+// ValidateDomain returns an error if domain is not a valid Raito domain name.
+func ValidateDomain(domain string) error {
+	if domain == "" {
+		return types.NewErrInvalidInput("no domain specified")
+	}
+
+	if !isValidDomain(strings.ToLower(domain)) {
+		return types.NewErrInvalidInput(fmt.Sprintf("invalid domain name %q. A domain should start with a letter and can only contain alphanumeric characters and the dash character. It also should not end with a dash character", domain))
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/raito-io/sdk-go/types"
+)
+
+func TestGqlEndpoint(t *testing.T) {
+	assert.Equal(t, "https://api.raito.cloud/query", GqlEndpoint("https://api.raito.cloud"))
+	assert.Equal(t, "https://api.raito.cloud/query", GqlEndpoint("https://api.raito.cloud/"))
+}
+
+func TestValidateBaseUrl(t *testing.T) {
+	for _, baseUrl := range []string{"https://api.raito.cloud/", "http://localhost:8080", "https://staging.example.com/api"} {
+		assert.NoError(t, ValidateBaseUrl(baseUrl), baseUrl)
+	}
+
+	for _, baseUrl := range []string{"", "api.raito.cloud", "ftp://api.raito.cloud", "https://", "https://api.raito.cloud/?a=b", "://invalid"} {
+		assert.ErrorIs(t, ValidateBaseUrl(baseUrl), &types.ErrInvalidInput{}, baseUrl)
+	}
+}
+
+func TestValidateDomain(t *testing.T) {
+	assert.NoError(t, ValidateDomain("my-domain"))
+	assert.NoError(t, ValidateDomain("MyDomain1"))
+
+	assert.ErrorIs(t, ValidateDomain(""), &types.ErrInvalidInput{})
+	assert.ErrorIs(t, ValidateDomain("1domain"), &types.ErrInvalidInput{})
+	assert.ErrorIs(t, ValidateDomain("domain-"), &types.ErrInvalidInput{})
+	assert.ErrorIs(t, ValidateDomain("my.domain"), &types.ErrInvalidInput{})
+}
@@ -0,0 +1,43 @@
+package internal
+
+import (
+	"context"
+	"maps"
+	"net/http"
+)
+
+type requestHeadersKey struct{}
+
+// WithRequestHeaders returns a copy of ctx carrying the given headers, which are added to each HTTP request made with the returned context.
+// Headers of an earlier call on a parent context are kept, unless they are overwritten.
+func WithRequestHeaders(ctx context.Context, headers map[string]string) context.Context {
+	merged := maps.Clone(RequestHeaders(ctx))
+	if merged == nil {
+		merged = make(map[string]string, len(headers))
+	}
+
+	for key, value := range headers {
+		merged[http.CanonicalHeaderKey(key)] = value
+	}
+
+	return context.WithValue(ctx, requestHeadersKey{}, merged)
+}
+
+// RequestHeaders returns the headers carried by ctx, if any.
+func RequestHeaders(ctx context.Context) map[string]string {
+	headers, _ := ctx.Value(requestHeadersKey{}).(map[string]string)
+
+	return headers
+}
+
+// setRequestHeaders sets the headers carried by ctx on h.
+// The Authorization and X-Request-ID headers are managed by the SDK and are never overwritten.
+func setRequestHeaders(ctx context.Context, h http.Header) {
+	for key, value := range RequestHeaders(ctx) {
+		if key == "Authorization" || key == CorrelationIdHeader {
+			continue
+		}
+
+		h.Set(key, value)
+	}
+}
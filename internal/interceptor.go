@@ -0,0 +1,33 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/Khan/genqlient/graphql"
+)
+
+// GraphQLInvoker executes a GraphQL request, e.g. by passing it to the next graphql.Client in the chain.
+type GraphQLInvoker func(ctx context.Context, req *graphql.Request, resp *graphql.Response) error
+
+// GraphQLInterceptor is called for each GraphQL request instead of the wrapped graphql.Client.
+// It can inspect or modify the request, the context and the response, and must call next to execute the request.
+// Returning without calling next skips the request, in which case the interceptor is responsible for filling resp.
+type GraphQLInterceptor func(ctx context.Context, req *graphql.Request, resp *graphql.Response, next GraphQLInvoker) error
+
+// InterceptorClient is a graphql.Client that passes each request through a GraphQLInterceptor.
+type InterceptorClient struct {
+	client      graphql.Client
+	interceptor GraphQLInterceptor
+}
+
+// NewInterceptorClient wraps client in an InterceptorClient calling interceptor.
+func NewInterceptorClient(client graphql.Client, interceptor GraphQLInterceptor) *InterceptorClient {
+	return &InterceptorClient{
+		client:      client,
+		interceptor: interceptor,
+	}
+}
+
+func (c *InterceptorClient) MakeRequest(ctx context.Context, req *graphql.Request, resp *graphql.Response) error {
+	return c.interceptor(ctx, req, resp, c.client.MakeRequest)
+}
@@ -0,0 +1,54 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingClient struct {
+	requests []*graphql.Request
+}
+
+func (c *recordingClient) MakeRequest(_ context.Context, req *graphql.Request, _ *graphql.Response) error {
+	c.requests = append(c.requests, req)
+
+	return nil
+}
+
+func TestInterceptorClient(t *testing.T) {
+	t.Run("Modify request", func(t *testing.T) {
+		fake := &recordingClient{}
+
+		client := NewInterceptorClient(fake, func(ctx context.Context, req *graphql.Request, resp *graphql.Response, next GraphQLInvoker) error {
+			modified := *req
+			modified.OpName = "Intercepted" + req.OpName
+
+			return next(ctx, &modified, resp)
+		})
+
+		err := client.MakeRequest(context.Background(), queryRequest, &graphql.Response{})
+
+		assert.NoError(t, err)
+		assert.Len(t, fake.requests, 1)
+		assert.Equal(t, "InterceptedGetAccessProvider", fake.requests[0].OpName)
+		assert.Equal(t, "GetAccessProvider", queryRequest.OpName)
+	})
+
+	t.Run("Skip request", func(t *testing.T) {
+		fake := &recordingClient{}
+		interceptErr := errors.New("intercepted")
+
+		client := NewInterceptorClient(fake, func(ctx context.Context, req *graphql.Request, resp *graphql.Response, next GraphQLInvoker) error {
+			return interceptErr
+		})
+
+		err := client.MakeRequest(context.Background(), queryRequest, &graphql.Response{})
+
+		assert.ErrorIs(t, err, interceptErr)
+		assert.Empty(t, fake.requests)
+	})
+}
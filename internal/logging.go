@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+
+	"github.com/raito-io/sdk-go/types"
+)
+
+// LoggingClient is a graphql.Client that logs each executed operation at debug level.
+// Only the operation name, duration and error class are logged. Variables are never logged, as they may contain sensitive data.
+type LoggingClient struct {
+	client graphql.Client
+	logger *slog.Logger
+}
+
+// NewLoggingClient wraps client in a LoggingClient that logs to logger.
+func NewLoggingClient(client graphql.Client, logger *slog.Logger) *LoggingClient {
+	return &LoggingClient{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (c *LoggingClient) MakeRequest(ctx context.Context, req *graphql.Request, resp *graphql.Response) error {
+	if !c.logger.Enabled(ctx, slog.LevelDebug) {
+		return c.client.MakeRequest(ctx, req, resp)
+	}
+
+	start := time.Now()
+
+	err := c.client.MakeRequest(ctx, req, resp)
+
+	attrs := []slog.Attr{
+		slog.String("operation", req.OpName),
+		slog.Duration("duration", time.Since(start)),
+	}
+
+	if id, ok := CorrelationId(ctx); ok {
+		attrs = append(attrs, slog.String("request_id", id))
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error_class", ErrorClass(err)))
+	}
+
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "graphql operation executed", attrs...)
+
+	return err
+}
+
+// ErrorClass returns a short, low cardinality classification of err, suitable for logging and metrics.
+// An empty string is returned if err is nil.
+func ErrorClass(err error) string {
+	var httpErr *graphql.HTTPError
+
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return "context"
+	case errors.Is(err, &types.ErrRateLimited{}):
+		return "rate_limited"
+	case errors.Is(err, &types.ErrUnauthenticated{}):
+		return "unauthenticated"
+	case errors.Is(err, &types.ErrValidation{}):
+		return "validation"
+	case IsTransientError(err):
+		return "transient"
+	case errors.As(err, &httpErr):
+		return "http"
+	default:
+		return "graphql"
+	}
+}
@@ -0,0 +1,59 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/raito-io/sdk-go/types"
+)
+
+func TestLoggingClient(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	fake := &failingClient{failures: 1, err: serviceUnavailableErr}
+	client := NewLoggingClient(fake, logger)
+
+	req := &graphql.Request{OpName: "CreateAccessProvider", Query: mutationRequest.Query, Variables: map[string]string{"who": "secret@raito.io"}}
+
+	err := client.MakeRequest(context.Background(), req, &graphql.Response{})
+	assert.Equal(t, serviceUnavailableErr, err)
+
+	err = client.MakeRequest(context.Background(), req, &graphql.Response{})
+	assert.NoError(t, err)
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	assert.Len(t, lines, 2)
+	assert.Contains(t, string(lines[0]), "operation=CreateAccessProvider")
+	assert.Contains(t, string(lines[0]), "error_class=transient")
+	assert.NotContains(t, string(lines[1]), "error_class")
+	assert.NotContains(t, buf.String(), "secret@raito.io")
+}
+
+func TestLoggingClient_DebugDisabled(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	client := NewLoggingClient(&failingClient{}, logger)
+
+	err := client.MakeRequest(context.Background(), queryRequest, &graphql.Response{})
+
+	assert.NoError(t, err)
+	assert.Empty(t, buf.String())
+}
+
+func TestErrorClass(t *testing.T) {
+	assert.Equal(t, "", ErrorClass(nil))
+	assert.Equal(t, "context", ErrorClass(context.DeadlineExceeded))
+	assert.Equal(t, "rate_limited", ErrorClass(types.NewErrRateLimited(time.Second)))
+	assert.Equal(t, "unauthenticated", ErrorClass(types.NewErrUnauthenticated(assert.AnError)))
+	assert.Equal(t, "transient", ErrorClass(serviceUnavailableErr))
+	assert.Equal(t, "http", ErrorClass(&graphql.HTTPError{StatusCode: 400}))
+	assert.Equal(t, "graphql", ErrorClass(assert.AnError))
+}
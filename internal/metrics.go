@@ -0,0 +1,38 @@
+package internal
+
+import (
+	"context"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+)
+
+// MetricsObserver is notified after each GraphQL round trip.
+// name is the name of the GraphQL operation, e.g. "CreateAccessProvider", and err is the error of the round trip, if any.
+type MetricsObserver interface {
+	ObserveOperation(name string, dur time.Duration, err error)
+}
+
+// MetricsClient is a graphql.Client that reports each round trip to a MetricsObserver.
+type MetricsClient struct {
+	client   graphql.Client
+	observer MetricsObserver
+}
+
+// NewMetricsClient wraps client in a MetricsClient that reports to observer.
+func NewMetricsClient(client graphql.Client, observer MetricsObserver) *MetricsClient {
+	return &MetricsClient{
+		client:   client,
+		observer: observer,
+	}
+}
+
+func (c *MetricsClient) MakeRequest(ctx context.Context, req *graphql.Request, resp *graphql.Response) error {
+	start := time.Now()
+
+	err := c.client.MakeRequest(ctx, req, resp)
+
+	c.observer.ObserveOperation(req.OpName, time.Since(start), err)
+
+	return err
+}
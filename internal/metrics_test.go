@@ -0,0 +1,40 @@
+package internal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+type observation struct {
+	name string
+	err  error
+}
+
+type recordingObserver struct {
+	observations []observation
+}
+
+func (o *recordingObserver) ObserveOperation(name string, _ time.Duration, err error) {
+	o.observations = append(o.observations, observation{name: name, err: err})
+}
+
+func TestMetricsClient(t *testing.T) {
+	observer := &recordingObserver{}
+	fake := &failingClient{failures: 2, err: serviceUnavailableErr}
+
+	// Each round trip of a retried request is observed
+	client := NewRetryClient(NewMetricsClient(fake, observer), 3, time.Millisecond, false)
+
+	err := client.MakeRequest(context.Background(), queryRequest, &graphql.Response{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []observation{
+		{name: "GetAccessProvider", err: serviceUnavailableErr},
+		{name: "GetAccessProvider", err: serviceUnavailableErr},
+		{name: "GetAccessProvider"},
+	}, observer.observations)
+}
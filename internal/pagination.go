@@ -2,33 +2,165 @@ package internal
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync"
 
 	"github.com/raito-io/sdk-go/types"
 )
 
-func PaginationExecutor[T any, E any](ctx context.Context, loadPageFn func(ctx context.Context, cursor *string) (*types.PageInfo, []E, error), edgeFn func(edge *E) (*string, *T, error)) <-chan types.ListItem[T] {
-	outputChannel := make(chan types.ListItem[T])
+type PaginationOptions struct {
+	prefetch        int
+	bufferSize      int
+	startCursor     *string
+	progressFn      func(pageInfo types.PageInfo)
+	strict          bool
+	initialPageSize int
+	maxPageSize     int
+}
+
+// WithPaginationPrefetch sets the number of pages that are loaded ahead of the consumer.
+// As the cursor of the next page is only known once the current page is loaded, pages are still loaded one after another.
+// However, with a prefetch depth larger than 0, loading the next pages is not blocked by the consumer processing the current page.
+// The order of the items is preserved.
+func WithPaginationPrefetch(depth int) func(options *PaginationOptions) {
+	return func(options *PaginationOptions) {
+		options.prefetch = depth
+	}
+}
+
+// WithPaginationBufferSize sets the number of items that can be buffered in the output channel before the consumer receives them.
+// A larger buffer allows the producer to continue loading pages while the consumer is busy, trading memory for smoother throughput.
+// By default, the output channel is unbuffered.
+func WithPaginationBufferSize(size int) func(options *PaginationOptions) {
+	return func(options *PaginationOptions) {
+		options.bufferSize = size
+	}
+}
+
+// WithPaginationStartCursor sets the cursor after which the first page is loaded.
+// This can be used to resume listing from the cursor of a previously received ListItem.
+func WithPaginationStartCursor(cursor *string) func(options *PaginationOptions) {
+	return func(options *PaginationOptions) {
+		options.startCursor = cursor
+	}
+}
+
+// WithPaginationProgress sets a function that is called with the PageInfo of each loaded page, before the items of that page are sent.
+// The function is called from the pagination goroutine and should not block. With prefetching, it is called when the page is loaded, which can be before the items of previous pages are received.
+func WithPaginationProgress(progressFn func(pageInfo types.PageInfo)) func(options *PaginationOptions) {
+	return func(options *PaginationOptions) {
+		options.progressFn = progressFn
+	}
+}
+
+// WithPaginationStrictDecode sets whether an edge with a node of an unexpected type results in an error.
+// If strict is false, which is the default, such edges are skipped and pagination continues after them.
+func WithPaginationStrictDecode(strict bool) func(options *PaginationOptions) {
+	return func(options *PaginationOptions) {
+		options.strict = strict
+	}
+}
+
+// WithPaginationAdaptivePageSize sets the page size to initial for the first page and doubles it for each next page, up to maxPageSize.
+// Small first pages reduce the latency until the first items are received, while large later pages reduce the number of round trips.
+// The page size of the page being loaded is passed to loadPageFn through the context and can be retrieved with PageSize.
+// The page size is not adapted if maxPageSize is not larger than initial.
+func WithPaginationAdaptivePageSize(initial, maxPageSize int) func(options *PaginationOptions) {
+	return func(options *PaginationOptions) {
+		options.initialPageSize = initial
+		options.maxPageSize = maxPageSize
+	}
+}
+
+type pageSizeKey struct{}
+
+// PageSize returns the page size to use in loadPageFn for the page being loaded.
+// This is the adaptive page size if WithPaginationAdaptivePageSize is set, and pageSize otherwise.
+func PageSize(ctx context.Context, pageSize int) int {
+	if adaptivePageSize, ok := ctx.Value(pageSizeKey{}).(int); ok {
+		return adaptivePageSize
+	}
+
+	return pageSize
+}
+
+// pageSizes returns a function returning the context in which the next page is loaded, carrying its adaptive page size if set.
+func pageSizes(options *PaginationOptions) func(ctx context.Context) context.Context {
+	pageSize := options.initialPageSize
+
+	return func(ctx context.Context) context.Context {
+		if pageSize <= 0 || options.maxPageSize <= options.initialPageSize {
+			return ctx
+		}
+
+		ctx = context.WithValue(ctx, pageSizeKey{}, pageSize)
+		pageSize = min(pageSize*2, options.maxPageSize)
+
+		return ctx
+	}
+}
+
+// DecodeEdgeNode returns node as N. A types.ErrUnexpectedEdge is returned if node is not of type N.
+func DecodeEdgeNode[N any, I any](node I) (N, error) {
+	n, ok := any(node).(N)
+	if !ok {
+		return n, types.NewErrUnexpectedEdge(fmt.Sprintf("%T", node))
+	}
+
+	return n, nil
+}
+
+// skipEdgeError returns true if edgeErr is caused by an unexpected edge that should be skipped.
+func skipEdgeError(options *PaginationOptions, edgeErr error) bool {
+	return !options.strict && errors.Is(edgeErr, &types.ErrUnexpectedEdge{})
+}
+
+// PaginationExecutor loads all pages using loadPageFn and sends every item returned by edgeFn on the output channel.
+// If loadPageFn or edgeFn returns an error, a ListItem carrying that error is sent as the last element before the channel is closed.
+// The channel is closed without error when the context is cancelled.
+// Each ListItem carries the cursor of its edge, so listing can be resumed with WithPaginationStartCursor.
+// Pages can be loaded ahead of the consumer with WithPaginationPrefetch, and with a growing page size with WithPaginationAdaptivePageSize.
+// Edges for which edgeFn returns a types.ErrUnexpectedEdge are skipped, unless WithPaginationStrictDecode is set.
+func PaginationExecutor[T any, E any](ctx context.Context, loadPageFn func(ctx context.Context, cursor *string) (*types.PageInfo, []E, error), edgeFn func(edge *E) (*string, *T, error), ops ...func(options *PaginationOptions)) <-chan types.ListItem[T] {
+	options := PaginationOptions{}
+	for _, op := range ops {
+		op(&options)
+	}
+
+	if options.prefetch > 0 {
+		return prefetchPaginationExecutor(ctx, loadPageFn, edgeFn, &options)
+	}
+
+	outputChannel := make(chan types.ListItem[T], max(options.bufferSize, 0))
 
 	go func() {
 		defer close(outputChannel)
 
 		hasNext := true
-		var lastCursor *string
+		lastCursor := options.startCursor
+		nextPageCtx := pageSizes(&options)
 
 		for hasNext {
 			select {
 			case <-ctx.Done():
 				return
 			default:
-				pageInfo, edges, err := loadPageFn(ctx, lastCursor)
+				pageInfo, edges, err := loadPageFn(nextPageCtx(ctx), lastCursor)
 				if err != nil {
 					putOnChannel(ctx, types.NewListItemError[T](err), outputChannel)
 
 					return
 				}
 
+				reportProgress(options.progressFn, pageInfo)
+
 				for i := range edges {
 					cursor, item, edgeErr := edgeFn(&edges[i])
+					if edgeErr != nil && skipEdgeError(&options, edgeErr) {
+						item, edgeErr = nil, nil
+					}
+
 					if edgeErr != nil {
 						putOnChannel(ctx, types.NewListItemError[T](edgeErr), outputChannel)
 
@@ -43,7 +175,7 @@ func PaginationExecutor[T any, E any](ctx context.Context, loadPageFn func(ctx c
 						continue
 					}
 
-					ctxDone := putOnChannel(ctx, types.NewListItemItem(item), outputChannel)
+					ctxDone := putOnChannel(ctx, types.NewListItemItemWithCursor(item, cursor), outputChannel)
 					if ctxDone {
 						return
 					}
@@ -57,6 +189,95 @@ func PaginationExecutor[T any, E any](ctx context.Context, loadPageFn func(ctx c
 	return outputChannel
 }
 
+type page[T any] struct {
+	items []types.ListItem[T]
+	err   error
+}
+
+func prefetchPaginationExecutor[T any, E any](ctx context.Context, loadPageFn func(ctx context.Context, cursor *string) (*types.PageInfo, []E, error), edgeFn func(edge *E) (*string, *T, error), options *PaginationOptions) <-chan types.ListItem[T] {
+	// The loader is always one page ahead while a page is being emitted, hence the buffer of depth - 1
+	pageChannel := make(chan page[T], options.prefetch-1)
+	outputChannel := make(chan types.ListItem[T], max(options.bufferSize, 0))
+
+	go func() {
+		defer close(pageChannel)
+
+		hasNext := true
+		lastCursor := options.startCursor
+		nextPageCtx := pageSizes(options)
+
+		for hasNext {
+			if ctx.Err() != nil {
+				return
+			}
+
+			pageInfo, edges, err := loadPageFn(nextPageCtx(ctx), lastCursor)
+			if err != nil {
+				putOnChannel(ctx, page[T]{err: err}, pageChannel)
+
+				return
+			}
+
+			reportProgress(options.progressFn, pageInfo)
+
+			currentPage := page[T]{items: make([]types.ListItem[T], 0, len(edges))}
+
+			for i := range edges {
+				cursor, item, edgeErr := edgeFn(&edges[i])
+				if edgeErr != nil && skipEdgeError(options, edgeErr) {
+					item, edgeErr = nil, nil
+				}
+
+				if edgeErr != nil {
+					currentPage.err = edgeErr
+
+					break
+				}
+
+				if cursor != nil {
+					lastCursor = cursor
+				}
+
+				if item != nil {
+					currentPage.items = append(currentPage.items, types.NewListItemItemWithCursor(item, cursor))
+				}
+			}
+
+			if putOnChannel(ctx, currentPage, pageChannel) || currentPage.err != nil {
+				return
+			}
+
+			hasNext = pageInfo != nil && pageInfo.HasNextPage != nil && *pageInfo.HasNextPage
+		}
+	}()
+
+	go func() {
+		defer close(outputChannel)
+
+		for currentPage := range pageChannel {
+			for _, item := range currentPage.items {
+				if putOnChannel(ctx, item, outputChannel) {
+					return
+				}
+			}
+
+			if currentPage.err != nil {
+				putOnChannel(ctx, types.NewListItemError[T](currentPage.err), outputChannel)
+
+				return
+			}
+		}
+	}()
+
+	return outputChannel
+}
+
+func reportProgress(progressFn func(pageInfo types.PageInfo), pageInfo *types.PageInfo) {
+	if progressFn != nil && pageInfo != nil {
+		progressFn(*pageInfo)
+	}
+}
+
 func putOnChannel[T any](ctx context.Context, item T, outputChannel chan<- T) bool {
 	select {
 	case <-ctx.Done():
@@ -65,3 +286,119 @@ func putOnChannel[T any](ctx context.Context, item T, outputChannel chan<- T) bo
 		return false
 	}
 }
+
+// ValidatePageSize returns an error if pageSize is not within the range accepted by the Raito API.
+func ValidatePageSize(pageSize int) error {
+	if pageSize < 1 || pageSize > MaxServerPageSize {
+		return types.NewErrInvalidInput(fmt.Sprintf("page size %d is out of range [1, %d]", pageSize, MaxServerPageSize))
+	}
+
+	return nil
+}
+
+// ErrorChannel returns a closed channel containing a single ListItem with the given error.
+func ErrorChannel[T any](err error) <-chan types.ListItem[T] {
+	outputChannel := make(chan types.ListItem[T], 1)
+	outputChannel <- types.NewListItemError[T](err)
+
+	close(outputChannel)
+
+	return outputChannel
+}
+
+// MapListItems sends the result of mapFn for each item received from inputChannel on the returned channel, preserving the cursor of the item.
+// Items for which mapFn returns false are skipped. An error received from inputChannel is sent as the last element.
+// inputChannel should be created with the same context, so it is released when the context is cancelled.
+func MapListItems[T any, U any](ctx context.Context, inputChannel <-chan types.ListItem[T], mapFn func(item *T) (*U, bool)) <-chan types.ListItem[U] {
+	outputChannel := make(chan types.ListItem[U])
+
+	go func() {
+		defer close(outputChannel)
+
+		for listItem := range inputChannel {
+			if listItem.HasError() {
+				putOnChannel(ctx, types.NewListItemError[U](listItem.GetError()), outputChannel)
+
+				return
+			}
+
+			item, ok := mapFn(listItem.GetItem())
+			if !ok {
+				continue
+			}
+
+			if putOnChannel(ctx, types.NewListItemItemWithCursor(item, listItem.GetCursor()), outputChannel) {
+				return
+			}
+		}
+	}()
+
+	return outputChannel
+}
+
+// CancelableList calls listFn with a cancelable child context of ctx and returns the resulting channel together with a cancel function.
+// Calling cancel stops the listing and drains the channel, so the underlying pagination goroutine has terminated once cancel returns.
+// Items that were not received before cancel is called are discarded. Cancel can be called multiple times.
+func CancelableList[T any](ctx context.Context, listFn func(ctx context.Context) <-chan types.ListItem[T]) (<-chan types.ListItem[T], func()) {
+	ctx, cancelCtx := context.WithCancel(ctx)
+
+	outputChannel := listFn(ctx)
+
+	var once sync.Once
+
+	cancel := func() {
+		once.Do(func() {
+			cancelCtx()
+
+			for range outputChannel {
+				// Drain the channel until the pagination goroutine closes it
+			}
+		})
+	}
+
+	return outputChannel, cancel
+}
+
+// CollectAll drains the channel returned by listFn into a slice.
+// Collection stops at the first ListItem carrying an error. The items collected so far are returned together with that error.
+// The context passed to listFn is cancelled before returning, so the underlying pagination goroutine is always released.
+func CollectAll[T any](ctx context.Context, listFn func(ctx context.Context) <-chan types.ListItem[T]) ([]T, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var result []T
+
+	for listItem := range listFn(ctx) {
+		if listItem.HasError() {
+			return result, listItem.GetError()
+		}
+
+		result = append(result, listItem.MustGetItem())
+	}
+
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// ForEach calls fn for each item of the channel returned by listFn, in order.
+// Iteration stops at the first ListItem carrying an error or the first error returned by fn, which is returned as is.
+// Before returning, the context passed to listFn is cancelled and the channel is drained, so the underlying pagination goroutine has terminated.
+func ForEach[T any](ctx context.Context, listFn func(ctx context.Context) <-chan types.ListItem[T], fn func(item T) error) error {
+	outputChannel, cancel := CancelableList(ctx, listFn)
+	defer cancel()
+
+	for listItem := range outputChannel {
+		if listItem.HasError() {
+			return listItem.GetError()
+		}
+
+		if err := fn(listItem.MustGetItem()); err != nil {
+			return err
+		}
+	}
+
+	return ctx.Err()
+}
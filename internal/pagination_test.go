@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"runtime"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -16,7 +18,16 @@ func TestPaginationExecutor(t *testing.T) {
 	t.Run("TestPaginationExecutor_Success", testPaginationExecutorSuccess)
 	t.Run("TestPaginationExecutor_LoadPageError", testPaginationExecutorLoadPageError)
 	t.Run("TestPaginationExecutor_EdgeFnError", testPaginationExecutorEdgeFnError)
+	t.Run("TestPaginationExecutor_SecondPageError", testPaginationExecutorSecondPageError)
 	t.Run("TestPaginationExecutor_ExecutorCancel", testPaginationExecutorCancel)
+	t.Run("TestPaginationExecutor_Prefetch", testPaginationExecutorPrefetch)
+	t.Run("TestPaginationExecutor_PrefetchEdgeFnError", testPaginationExecutorPrefetchEdgeFnError)
+	t.Run("TestPaginationExecutor_StartCursor", testPaginationExecutorStartCursor)
+	t.Run("TestPaginationExecutor_Progress", testPaginationExecutorProgress)
+	t.Run("TestPaginationExecutor_AdaptivePageSize", testPaginationExecutorAdaptivePageSize)
+	t.Run("TestPaginationExecutor_BufferSize", testPaginationExecutorBufferSize)
+	t.Run("TestPaginationExecutor_UnexpectedEdge", testPaginationExecutorUnexpectedEdge)
+	t.Run("TestPaginationExecutor_CancelNoLeak", testPaginationExecutorCancelNoLeak)
 }
 
 func testPaginationExecutorSuccess(t *testing.T) {
@@ -65,6 +76,109 @@ func testPaginationExecutorSuccess(t *testing.T) {
 	assert.Equal(t, []string{"item 0", "item 1", "item 2", "item 3", "item 4", "item 5", "item 6", "item 7"}, items)
 }
 
+func testPaginationExecutorStartCursor(t *testing.T) {
+	// Pages of 3 items, with the cursor of an item being its index
+	mockLoadPageFn := func(ctx context.Context, cursor *string) (*types.PageInfo, []int, error) {
+		start := 0
+
+		if cursor != nil {
+			cursorId, _ := strconv.Atoi(*cursor)
+			start = cursorId + 1
+		}
+
+		end := min(start+3, 8)
+		edges := make([]int, 0, end-start)
+
+		for i := start; i < end; i++ {
+			edges = append(edges, i)
+		}
+
+		return &types.PageInfo{HasNextPage: boolPtr(end < 8)}, edges, nil
+	}
+	mockEdgeFn := func(edge *int) (*string, *string, error) {
+		cursor := fmt.Sprintf("%d", *edge)
+		item := fmt.Sprintf("item %d", *edge)
+
+		return &cursor, &item, nil
+	}
+
+	for _, prefetch := range []int{0, 2} {
+		t.Run(fmt.Sprintf("prefetch %d", prefetch), func(t *testing.T) {
+			outputChannel := PaginationExecutor(context.Background(), mockLoadPageFn, mockEdgeFn, WithPaginationPrefetch(prefetch), WithPaginationStartCursor(stringPtr("3")))
+
+			var items []string
+			var cursors []string
+
+			for listItem := range outputChannel {
+				assert.NoError(t, listItem.GetError())
+
+				items = append(items, listItem.MustGetItem())
+				cursors = append(cursors, *listItem.GetCursor())
+			}
+
+			assert.Equal(t, []string{"item 4", "item 5", "item 6", "item 7"}, items)
+			assert.Equal(t, []string{"4", "5", "6", "7"}, cursors)
+		})
+	}
+}
+
+func testPaginationExecutorProgress(t *testing.T) {
+	for _, prefetch := range []int{0, 2} {
+		t.Run(fmt.Sprintf("prefetch %d", prefetch), func(t *testing.T) {
+			var hasNextPages []bool
+
+			progressFn := func(pageInfo types.PageInfo) {
+				hasNextPages = append(hasNextPages, *pageInfo.HasNextPage)
+			}
+
+			outputChannel := PaginationExecutor(context.Background(), mockPagedLoadPageFn(3, 2, 0), mockPagedEdgeFn, WithPaginationPrefetch(prefetch), WithPaginationProgress(progressFn))
+
+			count := 0
+
+			for listItem := range outputChannel {
+				assert.NoError(t, listItem.GetError())
+
+				count++
+			}
+
+			assert.Equal(t, 6, count)
+			assert.Equal(t, []bool{true, true, false}, hasNextPages)
+		})
+	}
+}
+
+func testPaginationExecutorAdaptivePageSize(t *testing.T) {
+	for _, prefetch := range []int{0, 2} {
+		t.Run(fmt.Sprintf("prefetch %d", prefetch), func(t *testing.T) {
+			var pageSizes []int
+
+			loadPageFn := mockAdaptiveLoadPageFn(100, MaxPageSize, 0)
+			recordingLoadPageFn := func(ctx context.Context, cursor *string) (*types.PageInfo, []int, error) {
+				pageSizes = append(pageSizes, PageSize(ctx, MaxPageSize))
+
+				return loadPageFn(ctx, cursor)
+			}
+
+			outputChannel := PaginationExecutor(context.Background(), recordingLoadPageFn, mockPagedEdgeFn, WithPaginationPrefetch(prefetch), WithPaginationAdaptivePageSize(5, 40))
+
+			count := 0
+
+			for listItem := range outputChannel {
+				assert.NoError(t, listItem.GetError())
+
+				count++
+			}
+
+			assert.Equal(t, 100, count)
+			assert.Equal(t, []int{5, 10, 20, 40, 40}, pageSizes)
+		})
+	}
+
+	t.Run("Not set", func(t *testing.T) {
+		assert.Equal(t, MaxPageSize, PageSize(context.Background(), MaxPageSize))
+	})
+}
+
 func testPaginationExecutorLoadPageError(t *testing.T) {
 	ctx := context.Background()
 	expectedErr := errors.New("loadPage error")
@@ -115,9 +229,56 @@ func testPaginationExecutorEdgeFnError(t *testing.T) {
 	}
 }
 
+func testPaginationExecutorSecondPageError(t *testing.T) {
+	ctx := context.Background()
+	expectedErr := types.NewErrPermissionDenied("listAccessProviders", "not allowed")
+
+	mockLoadPageFn := func(ctx context.Context, cursor *string) (*types.PageInfo, []int, error) {
+		if cursor != nil {
+			return nil, nil, expectedErr
+		}
+
+		pageInfo := &types.PageInfo{HasNextPage: boolPtr(true)}
+		edges := []int{0, 1, 2}
+
+		return pageInfo, edges, nil
+	}
+	mockEdgeFn := func(edge *int) (*string, *string, error) {
+		cursor := fmt.Sprintf("%d", *edge)
+		item := fmt.Sprintf("item %d", *edge)
+
+		return &cursor, &item, nil
+	}
+
+	outputChannel := PaginationExecutor(ctx, mockLoadPageFn, mockEdgeFn)
+
+	var items []string
+	var receivedErr error
+
+	for listItem := range outputChannel {
+		if receivedErr != nil {
+			t.Error("Received item after error")
+			return
+		}
+
+		if listItem.HasError() {
+			receivedErr = listItem.GetError()
+			continue
+		}
+
+		items = append(items, listItem.MustGetItem())
+	}
+
+	assert.Equal(t, []string{"item 0", "item 1", "item 2"}, items)
+
+	var permissionDeniedErr *types.ErrPermissionDenied
+	assert.ErrorAs(t, receivedErr, &permissionDeniedErr)
+}
+
 func testPaginationExecutorCancel(t *testing.T) {
 	ctx := context.Background()
 	cancelCtx, cancelFn := context.WithCancel(ctx)
+	defer cancelFn()
 
 	mockLoadPageFn := func(ctx context.Context, cursor *string) (*types.PageInfo, []int, error) {
 		pageNr := 0
@@ -167,7 +328,465 @@ func testPaginationExecutorCancel(t *testing.T) {
 
 }
 
+func testPaginationExecutorPrefetch(t *testing.T) {
+	for _, depth := range []int{1, 2, 5} {
+		outputChannel := PaginationExecutor(context.Background(), mockPagedLoadPageFn(4, 3, 0), mockPagedEdgeFn, WithPaginationPrefetch(depth))
+
+		var items []string
+		for listItem := range outputChannel {
+			if listItem.HasError() {
+				t.Errorf("Error encountered: %v", listItem.GetError())
+				return
+			}
+			items = append(items, listItem.MustGetItem())
+		}
+
+		assert.Len(t, items, 12)
+
+		for i := range items {
+			assert.Equal(t, fmt.Sprintf("item %d", i), items[i])
+		}
+	}
+}
+
+func testPaginationExecutorPrefetchEdgeFnError(t *testing.T) {
+	expectedErr := errors.New("edgeFn error")
+
+	mockEdgeFn := func(edge *int) (*string, *string, error) {
+		if *edge == 4 {
+			return nil, nil, expectedErr
+		}
+
+		return mockPagedEdgeFn(edge)
+	}
+
+	outputChannel := PaginationExecutor(context.Background(), mockPagedLoadPageFn(3, 3, 0), mockEdgeFn, WithPaginationPrefetch(1))
+
+	var items []string
+	var receivedErr error
+
+	for listItem := range outputChannel {
+		if listItem.HasError() {
+			receivedErr = listItem.GetError()
+			continue
+		}
+
+		items = append(items, listItem.MustGetItem())
+	}
+
+	assert.Equal(t, []string{"item 0", "item 1", "item 2", "item 3"}, items)
+	assert.Equal(t, expectedErr, receivedErr)
+}
+
+func testPaginationExecutorBufferSize(t *testing.T) {
+	for _, prefetch := range []int{0, 2} {
+		t.Run(fmt.Sprintf("prefetch %d", prefetch), func(t *testing.T) {
+			outputChannel := PaginationExecutor(context.Background(), mockPagedLoadPageFn(5, 7, 0), mockPagedEdgeFn, WithPaginationPrefetch(prefetch), WithPaginationBufferSize(10))
+
+			assert.Equal(t, 10, cap(outputChannel))
+
+			var expected []string
+			for i := range 35 {
+				expected = append(expected, fmt.Sprintf("item %d", i))
+			}
+
+			var items []string
+
+			for listItem := range outputChannel {
+				assert.NoError(t, listItem.GetError())
+
+				// Simulate a slow consumer, so the buffer is filled
+				time.Sleep(100 * time.Microsecond)
+
+				items = append(items, listItem.MustGetItem())
+			}
+
+			assert.Equal(t, expected, items)
+		})
+	}
+}
+
+func testPaginationExecutorUnexpectedEdge(t *testing.T) {
+	loadPageFn := func(ctx context.Context, cursor *string) (*types.PageInfo, []any, error) {
+		return &types.PageInfo{HasNextPage: boolPtr(false)}, []any{"a", 1, "b"}, nil
+	}
+
+	edgeFn := func(edge *any) (*string, *string, error) {
+		item, err := DecodeEdgeNode[string](*edge)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return &item, &item, nil
+	}
+
+	for _, prefetch := range []int{0, 2} {
+		t.Run(fmt.Sprintf("lenient prefetch %d", prefetch), func(t *testing.T) {
+			var items []string
+
+			for listItem := range PaginationExecutor(context.Background(), loadPageFn, edgeFn, WithPaginationPrefetch(prefetch)) {
+				assert.NoError(t, listItem.GetError())
+
+				items = append(items, listItem.MustGetItem())
+			}
+
+			assert.Equal(t, []string{"a", "b"}, items)
+		})
+
+		t.Run(fmt.Sprintf("strict prefetch %d", prefetch), func(t *testing.T) {
+			var items []string
+			var err error
+
+			for listItem := range PaginationExecutor(context.Background(), loadPageFn, edgeFn, WithPaginationPrefetch(prefetch), WithPaginationStrictDecode(true)) {
+				if listItem.HasError() {
+					err = listItem.GetError()
+
+					continue
+				}
+
+				items = append(items, listItem.MustGetItem())
+			}
+
+			assert.Equal(t, []string{"a"}, items)
+			assert.ErrorIs(t, err, &types.ErrUnexpectedEdge{})
+		})
+	}
+}
+
+func testPaginationExecutorCancelNoLeak(t *testing.T) {
+	tests := []struct {
+		name string
+		ops  []func(options *PaginationOptions)
+	}{
+		{name: "default"},
+		{name: "prefetch", ops: []func(options *PaginationOptions){WithPaginationPrefetch(3)}},
+		{name: "buffer", ops: []func(options *PaginationOptions){WithPaginationBufferSize(5)}},
+		{name: "prefetch and buffer", ops: []func(options *PaginationOptions){WithPaginationPrefetch(3), WithPaginationBufferSize(5)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseline := runtime.NumGoroutine()
+
+			ctx, cancel := context.WithCancel(context.Background())
+
+			outputChannel := PaginationExecutor(ctx, mockPagedLoadPageFn(100, 10, 0), mockPagedEdgeFn, tt.ops...)
+			mappedChannel := MapListItems(ctx, outputChannel, func(item *string) (*string, bool) { return item, true })
+
+			// Leave the remaining items unread
+			<-mappedChannel
+			<-mappedChannel
+
+			cancel()
+
+			assertNoGoroutineLeak(t, baseline)
+		})
+	}
+}
+
+// assertNoGoroutineLeak waits until the number of goroutines drops to baseline, failing the test if it does not within a second.
+func assertNoGoroutineLeak(t *testing.T, baseline int) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+
+	for runtime.NumGoroutine() > baseline {
+		if time.Now().After(deadline) {
+			t.Errorf("%d goroutines leaked", runtime.NumGoroutine()-baseline)
+
+			return
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func BenchmarkPaginationExecutor(b *testing.B) {
+	for _, depth := range []int{0, 1, 2} {
+		b.Run(fmt.Sprintf("prefetch=%d", depth), func(b *testing.B) {
+			for range b.N {
+				outputChannel := PaginationExecutor(context.Background(), mockPagedLoadPageFn(10, 10, 5*time.Millisecond), mockPagedEdgeFn, WithPaginationPrefetch(depth))
+
+				for range outputChannel {
+					// Simulate processing time of the consumer
+					time.Sleep(500 * time.Microsecond)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkPaginationExecutor_AdaptivePageSize(b *testing.B) {
+	benchmarks := []struct {
+		name string
+		ops  []func(options *PaginationOptions)
+	}{
+		{name: "fixed=25"},
+		{name: "adaptive=25..1000", ops: []func(options *PaginationOptions){WithPaginationAdaptivePageSize(MaxPageSize, MaxServerPageSize)}},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			for range b.N {
+				outputChannel := PaginationExecutor(context.Background(), mockAdaptiveLoadPageFn(10_000, MaxPageSize, time.Millisecond), mockPagedEdgeFn, bm.ops...)
+
+				for range outputChannel {
+					// Drain the channel
+				}
+			}
+		})
+	}
+}
+
+// mockPagedLoadPageFn returns a loadPageFn serving nrOfPages pages of pageSize items, waiting latency before returning each page.
+func mockPagedLoadPageFn(nrOfPages, pageSize int, latency time.Duration) func(ctx context.Context, cursor *string) (*types.PageInfo, []int, error) {
+	return func(ctx context.Context, cursor *string) (*types.PageInfo, []int, error) {
+		time.Sleep(latency)
+
+		offset := 0
+
+		if cursor != nil {
+			cursorId, _ := strconv.Atoi(*cursor)
+			offset = cursorId + 1
+		}
+
+		edges := make([]int, 0, pageSize)
+		for i := offset; i < offset+pageSize; i++ {
+			edges = append(edges, i)
+		}
+
+		return &types.PageInfo{HasNextPage: boolPtr(offset+pageSize < nrOfPages*pageSize)}, edges, nil
+	}
+}
+
+// mockAdaptiveLoadPageFn returns a loadPageFn serving nrOfItems items in pages of the size returned by PageSize, waiting latency before returning each page.
+func mockAdaptiveLoadPageFn(nrOfItems, pageSize int, latency time.Duration) func(ctx context.Context, cursor *string) (*types.PageInfo, []int, error) {
+	return func(ctx context.Context, cursor *string) (*types.PageInfo, []int, error) {
+		time.Sleep(latency)
+
+		offset := 0
+
+		if cursor != nil {
+			cursorId, _ := strconv.Atoi(*cursor)
+			offset = cursorId + 1
+		}
+
+		end := min(offset+PageSize(ctx, pageSize), nrOfItems)
+
+		edges := make([]int, 0, end-offset)
+		for i := offset; i < end; i++ {
+			edges = append(edges, i)
+		}
+
+		return &types.PageInfo{HasNextPage: boolPtr(end < nrOfItems)}, edges, nil
+	}
+}
+
+func mockPagedEdgeFn(edge *int) (*string, *string, error) {
+	cursor := fmt.Sprintf("%d", *edge)
+	item := fmt.Sprintf("item %d", *edge)
+
+	return &cursor, &item, nil
+}
+
 // Utility function to get a pointer to bool
 func boolPtr(b bool) *bool {
 	return &b
 }
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestValidatePageSize(t *testing.T) {
+	assert.NoError(t, ValidatePageSize(1))
+	assert.NoError(t, ValidatePageSize(MaxPageSize))
+	assert.NoError(t, ValidatePageSize(MaxServerPageSize))
+
+	var invalidInputErr *types.ErrInvalidInput
+	assert.ErrorAs(t, ValidatePageSize(0), &invalidInputErr)
+	assert.ErrorAs(t, ValidatePageSize(MaxServerPageSize+1), &invalidInputErr)
+}
+
+func TestErrorChannel(t *testing.T) {
+	expectedErr := errors.New("some error")
+
+	var items []types.ListItem[string]
+	for listItem := range ErrorChannel[string](expectedErr) {
+		items = append(items, listItem)
+	}
+
+	assert.Len(t, items, 1)
+	assert.Equal(t, expectedErr, items[0].GetError())
+}
+
+func TestMapListItems(t *testing.T) {
+	expectedErr := errors.New("list error")
+
+	inputChannel := make(chan types.ListItem[int], 4)
+	inputChannel <- types.NewListItemItemWithCursor(intPtr(1), stringPtr("1"))
+	inputChannel <- types.NewListItemItemWithCursor(intPtr(2), stringPtr("2"))
+	inputChannel <- types.NewListItemItemWithCursor(intPtr(3), stringPtr("3"))
+	inputChannel <- types.NewListItemError[int](expectedErr)
+	close(inputChannel)
+
+	// Only odd numbers are kept
+	mapFn := func(item *int) (*string, bool) {
+		if *item%2 == 0 {
+			return nil, false
+		}
+
+		result := fmt.Sprintf("item %d", *item)
+
+		return &result, true
+	}
+
+	var items []string
+	var cursors []string
+	var receivedErr error
+
+	for listItem := range MapListItems(context.Background(), inputChannel, mapFn) {
+		if listItem.HasError() {
+			receivedErr = listItem.GetError()
+
+			continue
+		}
+
+		items = append(items, listItem.MustGetItem())
+		cursors = append(cursors, *listItem.GetCursor())
+	}
+
+	assert.Equal(t, []string{"item 1", "item 3"}, items)
+	assert.Equal(t, []string{"1", "3"}, cursors)
+	assert.Equal(t, expectedErr, receivedErr)
+}
+
+func TestCancelableList(t *testing.T) {
+	outputChannel, cancel := CancelableList(context.Background(), func(ctx context.Context) <-chan types.ListItem[string] {
+		return PaginationExecutor(ctx, mockPagedLoadPageFn(100, 10, 0), mockPagedEdgeFn)
+	})
+
+	first := <-outputChannel
+	assert.Equal(t, "item 0", first.MustGetItem())
+
+	cancel()
+
+	// The channel is closed once cancel returns
+	_, ok := <-outputChannel
+	assert.False(t, ok)
+
+	// Cancel can be called again
+	cancel()
+}
+
+func TestCollectAll(t *testing.T) {
+	t.Run("TestCollectAll_Success", testCollectAllSuccess)
+	t.Run("TestCollectAll_Error", testCollectAllError)
+	t.Run("TestCollectAll_Cancel", testCollectAllCancel)
+}
+
+func testCollectAllSuccess(t *testing.T) {
+	listFn := func(ctx context.Context) <-chan types.ListItem[string] {
+		return PaginationExecutor(ctx, func(ctx context.Context, cursor *string) (*types.PageInfo, []int, error) {
+			return &types.PageInfo{HasNextPage: boolPtr(false)}, []int{0, 1, 2}, nil
+		}, func(edge *int) (*string, *string, error) {
+			item := fmt.Sprintf("item %d", *edge)
+
+			return nil, &item, nil
+		})
+	}
+
+	items, err := CollectAll(context.Background(), listFn)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"item 0", "item 1", "item 2"}, items)
+}
+
+func testCollectAllError(t *testing.T) {
+	expectedErr := errors.New("loadPage error")
+
+	listFn := func(ctx context.Context) <-chan types.ListItem[string] {
+		return PaginationExecutor(ctx, func(ctx context.Context, cursor *string) (*types.PageInfo, []int, error) {
+			if cursor != nil {
+				return nil, nil, expectedErr
+			}
+
+			return &types.PageInfo{HasNextPage: boolPtr(true)}, []int{0, 1}, nil
+		}, func(edge *int) (*string, *string, error) {
+			cursor := fmt.Sprintf("%d", *edge)
+			item := fmt.Sprintf("item %d", *edge)
+
+			return &cursor, &item, nil
+		})
+	}
+
+	items, err := CollectAll(context.Background(), listFn)
+
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, []string{"item 0", "item 1"}, items)
+}
+
+func testCollectAllCancel(t *testing.T) {
+	ctx, cancelFn := context.WithCancel(context.Background())
+	cancelFn()
+
+	listFn := func(ctx context.Context) <-chan types.ListItem[string] {
+		return PaginationExecutor(ctx, func(ctx context.Context, cursor *string) (*types.PageInfo, []int, error) {
+			return &types.PageInfo{HasNextPage: boolPtr(true)}, []int{0}, nil
+		}, func(edge *int) (*string, *string, error) {
+			item := fmt.Sprintf("item %d", *edge)
+
+			return nil, &item, nil
+		})
+	}
+
+	_, err := CollectAll(ctx, listFn)
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestForEach(t *testing.T) {
+	t.Run("TestForEach_Success", testForEachSuccess)
+	t.Run("TestForEach_CallbackError", testForEachCallbackError)
+}
+
+func testForEachSuccess(t *testing.T) {
+	var items []string
+
+	err := ForEach(context.Background(), func(ctx context.Context) <-chan types.ListItem[string] {
+		return PaginationExecutor(ctx, mockPagedLoadPageFn(3, 2, 0), mockPagedEdgeFn)
+	}, func(item string) error {
+		items = append(items, item)
+
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, items, 6)
+}
+
+func testForEachCallbackError(t *testing.T) {
+	expectedErr := errors.New("callback error")
+	baseline := runtime.NumGoroutine()
+	calls := 0
+
+	err := ForEach(context.Background(), func(ctx context.Context) <-chan types.ListItem[string] {
+		return PaginationExecutor(ctx, mockPagedLoadPageFn(100, 10, 0), mockPagedEdgeFn, WithPaginationPrefetch(3))
+	}, func(item string) error {
+		calls++
+		if calls == 2 {
+			return expectedErr
+		}
+
+		return nil
+	})
+
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, 2, calls)
+
+	assertNoGoroutineLeak(t, baseline)
+}
@@ -0,0 +1,68 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultRetryAfter is used if a rate limited response does not contain a valid Retry-After header.
+const DefaultRetryAfter = time.Second
+
+// MaxRateLimitRetries is the maximum number of times a rate limited request is retried,
+// so a server responding with Retry-After: 0 cannot keep a request retrying forever.
+const MaxRateLimitRetries = 10
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a number of seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return DefaultRetryAfter
+		}
+
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if retryAfter := date.Sub(now); retryAfter > 0 {
+			return retryAfter
+		}
+
+		return 0
+	}
+
+	return DefaultRetryAfter
+}
+
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// rewindRequest returns a copy of req with a fresh body, so it can be sent again.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	newReq := req.Clone(req.Context())
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("rewind request body: %w", err)
+		}
+
+		newReq.Body = body
+	}
+
+	return newReq, nil
+}
@@ -0,0 +1,134 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/raito-io/sdk-go/types"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 5*time.Second, parseRetryAfter("5", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("0", now))
+	assert.Equal(t, 30*time.Second, parseRetryAfter(now.Add(30*time.Second).Format(http.TimeFormat), now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter(now.Add(-30*time.Second).Format(http.TimeFormat), now))
+	assert.Equal(t, DefaultRetryAfter, parseRetryAfter("", now))
+	assert.Equal(t, DefaultRetryAfter, parseRetryAfter("-1", now))
+	assert.Equal(t, DefaultRetryAfter, parseRetryAfter("invalid", now))
+}
+
+func TestAuthedDoer_RateLimited(t *testing.T) {
+	t.Run("TestAuthedDoer_RateLimited_NoWait", testAuthedDoerRateLimitedNoWait)
+	t.Run("TestAuthedDoer_RateLimited_Wait", testAuthedDoerRateLimitedWait)
+	t.Run("TestAuthedDoer_RateLimited_WaitExceeded", testAuthedDoerRateLimitedWaitExceeded)
+	t.Run("TestAuthedDoer_RateLimited_NoWait_RetryAfterZero", testAuthedDoerRateLimitedNoWaitRetryAfterZero)
+	t.Run("TestAuthedDoer_RateLimited_PersistentRetryAfterZero", testAuthedDoerRateLimitedPersistentRetryAfterZero)
+}
+
+func testAuthedDoerRateLimitedNoWait(t *testing.T) {
+	server, calls := rateLimitedServer(1, "2")
+	defer server.Close()
+
+	_, err := newTestAuthedDoer(0).Do(newTestRequest(t, server.URL))
+
+	var rateLimitedErr *types.ErrRateLimited
+	assert.ErrorAs(t, err, &rateLimitedErr)
+	assert.Equal(t, 2*time.Second, rateLimitedErr.RetryAfter)
+	assert.Equal(t, 1, *calls)
+}
+
+func testAuthedDoerRateLimitedWait(t *testing.T) {
+	server, calls := rateLimitedServer(2, "0")
+	defer server.Close()
+
+	resp, err := newTestAuthedDoer(time.Second).Do(newTestRequest(t, server.URL))
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 3, *calls)
+
+	resp.Body.Close()
+}
+
+func testAuthedDoerRateLimitedWaitExceeded(t *testing.T) {
+	server, calls := rateLimitedServer(1, "10")
+	defer server.Close()
+
+	_, err := newTestAuthedDoer(time.Second).Do(newTestRequest(t, server.URL))
+
+	var rateLimitedErr *types.ErrRateLimited
+	assert.ErrorAs(t, err, &rateLimitedErr)
+	assert.Equal(t, 1, *calls)
+}
+
+func testAuthedDoerRateLimitedNoWaitRetryAfterZero(t *testing.T) {
+	server, calls := rateLimitedServer(1, "0")
+	defer server.Close()
+
+	_, err := newTestAuthedDoer(0).Do(newTestRequest(t, server.URL))
+
+	var rateLimitedErr *types.ErrRateLimited
+	assert.ErrorAs(t, err, &rateLimitedErr)
+	assert.Equal(t, 1, *calls)
+}
+
+func testAuthedDoerRateLimitedPersistentRetryAfterZero(t *testing.T) {
+	server, calls := rateLimitedServer(math.MaxInt, "0")
+	defer server.Close()
+
+	_, err := newTestAuthedDoer(time.Second).Do(newTestRequest(t, server.URL))
+
+	var rateLimitedErr *types.ErrRateLimited
+	assert.ErrorAs(t, err, &rateLimitedErr)
+	assert.Equal(t, MaxRateLimitRetries+1, *calls)
+}
+
+// rateLimitedServer returns a server responding with 429 for the first nrOfRateLimits requests.
+func rateLimitedServer(nrOfRateLimits int, retryAfter string) (*httptest.Server, *int) {
+	calls := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		if calls <= nrOfRateLimits {
+			w.Header().Set("Retry-After", retryAfter)
+			w.WriteHeader(http.StatusTooManyRequests)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	return server, &calls
+}
+
+func newTestAuthedDoer(maxRateLimitWait time.Duration) *AuthedDoer {
+	expiration := time.Now().Add(time.Hour)
+
+	return &AuthedDoer{
+		Domain:           "test",
+		MaxRateLimitWait: maxRateLimitWait,
+		token: &userTokens{
+			idToken:      "id-token",
+			refreshToken: "refresh-token",
+			expiration:   &expiration,
+		},
+	}
+}
+
+func newTestRequest(t *testing.T, url string) *http.Request {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, bytes.NewReader([]byte(`{"query":""}`)))
+	assert.NoError(t, err)
+
+	return req
+}
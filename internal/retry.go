@@ -0,0 +1,150 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+)
+
+// RetryClassifier decides whether a request is retried after a failed attempt, and how long to wait before the next attempt.
+// attempt is the number of the failed attempt, starting at 1.
+type RetryClassifier func(err error, attempt int) (retry bool, delay time.Duration)
+
+// DefaultRetryClassifier returns a RetryClassifier that retries transient errors, as reported by IsTransientError, waiting a fixed backoff between attempts.
+func DefaultRetryClassifier(backoff time.Duration) RetryClassifier {
+	return func(err error, _ int) (bool, time.Duration) {
+		return IsTransientError(err), backoff
+	}
+}
+
+// ExponentialRetryClassifier returns a RetryClassifier that retries transient errors, as reported by IsTransientError, waiting ExponentialBackoff between attempts.
+func ExponentialRetryClassifier(base, maxDelay time.Duration) RetryClassifier {
+	return func(err error, attempt int) (bool, time.Duration) {
+		return IsTransientError(err), ExponentialBackoff(base, maxDelay, attempt)
+	}
+}
+
+// ExponentialBackoff returns the delay before retrying the given failed attempt, starting at 1.
+// The delay is chosen randomly between 0 and base * 2^(attempt-1), capped at maxDelay.
+// This "full jitter" prevents clients that failed at the same time from retrying at the same time.
+// If maxDelay is not larger than 0, the delay is not capped.
+func ExponentialBackoff(base, maxDelay time.Duration, attempt int) time.Duration {
+	if base <= 0 {
+		return 0
+	}
+
+	ceiling := base
+
+	for i := 1; i < attempt && (maxDelay <= 0 || ceiling < maxDelay); i++ {
+		if ceiling > math.MaxInt64/2 {
+			break
+		}
+
+		ceiling *= 2
+	}
+
+	if maxDelay > 0 && ceiling > maxDelay {
+		ceiling = maxDelay
+	}
+
+	return time.Duration(rand.Int64N(int64(ceiling) + 1))
+}
+
+// RetryClient is a graphql.Client that retries failed requests as decided by a RetryClassifier.
+// By default, only queries are retried as mutations are not guaranteed to be idempotent.
+type RetryClient struct {
+	client         graphql.Client
+	maxAttempts    int
+	classifier     RetryClassifier
+	retryMutations bool
+}
+
+// NewRetryClient wraps client in a RetryClient that retries requests failing with a transient error.
+// A request is executed at most maxAttempts times, waiting backoff between attempts.
+// Mutations are only retried if retryMutations is true.
+func NewRetryClient(client graphql.Client, maxAttempts int, backoff time.Duration, retryMutations bool) *RetryClient {
+	return NewRetryClientWithClassifier(client, maxAttempts, DefaultRetryClassifier(backoff), retryMutations)
+}
+
+// NewRetryClientWithClassifier wraps client in a RetryClient that retries requests as decided by classifier.
+// If maxAttempts is larger than 0, a request is executed at most maxAttempts times, regardless of the classifier.
+// Mutations are only retried if retryMutations is true.
+func NewRetryClientWithClassifier(client graphql.Client, maxAttempts int, classifier RetryClassifier, retryMutations bool) *RetryClient {
+	return &RetryClient{
+		client:         client,
+		maxAttempts:    maxAttempts,
+		classifier:     classifier,
+		retryMutations: retryMutations,
+	}
+}
+
+func (c *RetryClient) MakeRequest(ctx context.Context, req *graphql.Request, resp *graphql.Response) error {
+	if !c.retryMutations && isMutation(req) {
+		return c.client.MakeRequest(ctx, req, resp)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := c.client.MakeRequest(ctx, req, resp)
+		if err == nil || (c.maxAttempts > 0 && attempt >= c.maxAttempts) || ctx.Err() != nil {
+			return err
+		}
+
+		retry, delay := c.classifier(err, attempt)
+		if !retry {
+			return err
+		}
+
+		// Do not wait for a retry that cannot be executed before the deadline of the context
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return err
+		}
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// IsTransientError returns true if err is a network error or an HTTP 502, 503 or 504 response.
+// This includes a connection that is closed or reset while the response is read, which surfaces as an (unexpected) EOF.
+func IsTransientError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var httpErr *graphql.HTTPError
+	if errors.As(err, &httpErr) {
+		switch httpErr.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		default:
+			return false
+		}
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED)
+}
+
+func isMutation(req *graphql.Request) bool {
+	return strings.HasPrefix(strings.TrimSpace(req.Query), "mutation")
+}
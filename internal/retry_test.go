@@ -0,0 +1,196 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+type failingClient struct {
+	failures int
+	err      error
+	calls    int
+}
+
+func (c *failingClient) MakeRequest(_ context.Context, _ *graphql.Request, _ *graphql.Response) error {
+	c.calls++
+
+	if c.calls <= c.failures {
+		return c.err
+	}
+
+	return nil
+}
+
+func TestRetryClient(t *testing.T) {
+	t.Run("TestRetryClient_SucceedsAfterTransientErrors", testRetryClientSucceedsAfterTransientErrors)
+	t.Run("TestRetryClient_MaxAttempts", testRetryClientMaxAttempts)
+	t.Run("TestRetryClient_NonTransientError", testRetryClientNonTransientError)
+	t.Run("TestRetryClient_MutationNotRetried", testRetryClientMutationNotRetried)
+	t.Run("TestRetryClient_MutationRetried", testRetryClientMutationRetried)
+	t.Run("TestRetryClient_Deadline", testRetryClientDeadline)
+	t.Run("TestRetryClient_Classifier", testRetryClientClassifier)
+}
+
+var queryRequest = &graphql.Request{OpName: "GetAccessProvider", Query: "\nquery GetAccessProvider ($id: ID!) {}"}
+var mutationRequest = &graphql.Request{OpName: "CreateAccessProvider", Query: "\nmutation CreateAccessProvider ($ap: AccessProviderInput!) {}"}
+var serviceUnavailableErr = &graphql.HTTPError{StatusCode: http.StatusServiceUnavailable}
+
+func testRetryClientSucceedsAfterTransientErrors(t *testing.T) {
+	fake := &failingClient{failures: 2, err: serviceUnavailableErr}
+	client := NewRetryClient(fake, 3, time.Millisecond, false)
+
+	err := client.MakeRequest(context.Background(), queryRequest, &graphql.Response{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, fake.calls)
+}
+
+func testRetryClientMaxAttempts(t *testing.T) {
+	fake := &failingClient{failures: 5, err: serviceUnavailableErr}
+	client := NewRetryClient(fake, 3, time.Millisecond, false)
+
+	err := client.MakeRequest(context.Background(), queryRequest, &graphql.Response{})
+
+	assert.Equal(t, serviceUnavailableErr, err)
+	assert.Equal(t, 3, fake.calls)
+}
+
+func testRetryClientNonTransientError(t *testing.T) {
+	fake := &failingClient{failures: 2, err: &graphql.HTTPError{StatusCode: http.StatusBadRequest}}
+	client := NewRetryClient(fake, 3, time.Millisecond, false)
+
+	err := client.MakeRequest(context.Background(), queryRequest, &graphql.Response{})
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, fake.calls)
+}
+
+func testRetryClientMutationNotRetried(t *testing.T) {
+	fake := &failingClient{failures: 2, err: serviceUnavailableErr}
+	client := NewRetryClient(fake, 3, time.Millisecond, false)
+
+	err := client.MakeRequest(context.Background(), mutationRequest, &graphql.Response{})
+
+	assert.Equal(t, serviceUnavailableErr, err)
+	assert.Equal(t, 1, fake.calls)
+}
+
+func testRetryClientMutationRetried(t *testing.T) {
+	fake := &failingClient{failures: 2, err: serviceUnavailableErr}
+	client := NewRetryClient(fake, 3, time.Millisecond, true)
+
+	err := client.MakeRequest(context.Background(), mutationRequest, &graphql.Response{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, fake.calls)
+}
+
+func testRetryClientDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	fake := &failingClient{failures: 2, err: serviceUnavailableErr}
+	client := NewRetryClient(fake, 3, time.Second, false)
+
+	err := client.MakeRequest(ctx, queryRequest, &graphql.Response{})
+
+	assert.Equal(t, serviceUnavailableErr, err)
+	assert.Equal(t, 1, fake.calls)
+}
+
+func testRetryClientClassifier(t *testing.T) {
+	internalServerErr := &graphql.HTTPError{StatusCode: http.StatusInternalServerError}
+
+	var attempts []int
+
+	classifier := func(err error, attempt int) (bool, time.Duration) {
+		attempts = append(attempts, attempt)
+
+		return errors.Is(err, internalServerErr), time.Millisecond
+	}
+
+	t.Run("Retried", func(t *testing.T) {
+		attempts = nil
+
+		fake := &failingClient{failures: 3, err: internalServerErr}
+		client := NewRetryClientWithClassifier(fake, 0, classifier, false)
+
+		err := client.MakeRequest(context.Background(), queryRequest, &graphql.Response{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 4, fake.calls)
+		assert.Equal(t, []int{1, 2, 3}, attempts)
+	})
+
+	t.Run("Not retried", func(t *testing.T) {
+		attempts = nil
+
+		fake := &failingClient{failures: 3, err: serviceUnavailableErr}
+		client := NewRetryClientWithClassifier(fake, 0, classifier, false)
+
+		err := client.MakeRequest(context.Background(), queryRequest, &graphql.Response{})
+
+		assert.Equal(t, serviceUnavailableErr, err)
+		assert.Equal(t, 1, fake.calls)
+	})
+
+	t.Run("Max attempts", func(t *testing.T) {
+		attempts = nil
+
+		fake := &failingClient{failures: 5, err: internalServerErr}
+		client := NewRetryClientWithClassifier(fake, 2, classifier, false)
+
+		err := client.MakeRequest(context.Background(), queryRequest, &graphql.Response{})
+
+		assert.Equal(t, internalServerErr, err)
+		assert.Equal(t, 2, fake.calls)
+		assert.Equal(t, []int{1}, attempts)
+	})
+}
+
+func TestIsTransientError(t *testing.T) {
+	assert.True(t, IsTransientError(&graphql.HTTPError{StatusCode: http.StatusBadGateway}))
+	assert.True(t, IsTransientError(&graphql.HTTPError{StatusCode: http.StatusServiceUnavailable}))
+	assert.True(t, IsTransientError(&graphql.HTTPError{StatusCode: http.StatusGatewayTimeout}))
+	assert.False(t, IsTransientError(&graphql.HTTPError{StatusCode: http.StatusInternalServerError}))
+	assert.True(t, IsTransientError(fmt.Errorf("decoding response: %w", io.EOF)))
+	assert.True(t, IsTransientError(fmt.Errorf("reading body: %w", syscall.ECONNRESET)))
+	assert.False(t, IsTransientError(errors.New("some error")))
+	assert.False(t, IsTransientError(context.DeadlineExceeded))
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	base := 10 * time.Millisecond
+	maxDelay := 100 * time.Millisecond
+
+	for attempt := 1; attempt <= 10; attempt++ {
+		ceiling := min(base<<(attempt-1), maxDelay)
+
+		var largest time.Duration
+
+		for range 200 {
+			delay := ExponentialBackoff(base, maxDelay, attempt)
+
+			assert.GreaterOrEqual(t, delay, time.Duration(0))
+			assert.LessOrEqual(t, delay, ceiling)
+
+			largest = max(largest, delay)
+		}
+
+		// With 200 samples, the largest delay is close to the ceiling, showing the delays grow with the attempts
+		assert.Greater(t, largest, ceiling/2)
+	}
+
+	assert.Equal(t, time.Duration(0), ExponentialBackoff(0, maxDelay, 3))
+	assert.LessOrEqual(t, ExponentialBackoff(time.Hour, 0, 100), time.Duration(math.MaxInt64))
+}
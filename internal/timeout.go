@@ -0,0 +1,30 @@
+package internal
+
+import (
+	"context"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+)
+
+// TimeoutClient is a graphql.Client that limits the duration of each request.
+// If the context of a request already has an earlier deadline, that deadline is kept.
+type TimeoutClient struct {
+	client  graphql.Client
+	timeout time.Duration
+}
+
+// NewTimeoutClient wraps client in a TimeoutClient that cancels requests after timeout.
+func NewTimeoutClient(client graphql.Client, timeout time.Duration) *TimeoutClient {
+	return &TimeoutClient{
+		client:  client,
+		timeout: timeout,
+	}
+}
+
+func (c *TimeoutClient) MakeRequest(ctx context.Context, req *graphql.Request, resp *graphql.Response) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	return c.client.MakeRequest(ctx, req, resp)
+}
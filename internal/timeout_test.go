@@ -0,0 +1,49 @@
+package internal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+type deadlineClient struct {
+	deadline time.Time
+	ok       bool
+}
+
+func (c *deadlineClient) MakeRequest(ctx context.Context, _ *graphql.Request, _ *graphql.Response) error {
+	c.deadline, c.ok = ctx.Deadline()
+
+	return nil
+}
+
+func TestTimeoutClient(t *testing.T) {
+	t.Run("Timeout applied", func(t *testing.T) {
+		fake := &deadlineClient{}
+		client := NewTimeoutClient(fake, time.Minute)
+
+		err := client.MakeRequest(context.Background(), queryRequest, &graphql.Response{})
+
+		assert.NoError(t, err)
+		assert.True(t, fake.ok)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), fake.deadline, time.Second)
+	})
+
+	t.Run("Earlier caller deadline kept", func(t *testing.T) {
+		fake := &deadlineClient{}
+		client := NewTimeoutClient(fake, time.Minute)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		expectedDeadline, _ := ctx.Deadline()
+
+		err := client.MakeRequest(ctx, queryRequest, &graphql.Response{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedDeadline, fake.deadline)
+	})
+}
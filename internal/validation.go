@@ -0,0 +1,94 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Khan/genqlient/graphql"
+
+	"github.com/raito-io/sdk-go/types"
+)
+
+// ValidationClient is a graphql.Client that converts errors reported in the errors array of a GraphQL response to a types.ErrValidation.
+// Transport-level errors, such as network errors or unexpected HTTP responses, are returned unchanged.
+// If the response contains both data and errors, an error is returned, even if the wrapped client did not report the errors.
+type ValidationClient struct {
+	client graphql.Client
+}
+
+// NewValidationClient wraps client in a ValidationClient.
+func NewValidationClient(client graphql.Client) *ValidationClient {
+	return &ValidationClient{
+		client: client,
+	}
+}
+
+func (c *ValidationClient) MakeRequest(ctx context.Context, req *graphql.Request, resp *graphql.Response) error {
+	err := c.client.MakeRequest(ctx, req, resp)
+	if err == nil {
+		if resp == nil || len(resp.Errors) == 0 {
+			return nil
+		}
+
+		// The wrapped client returned partial data without reporting the errors of the response.
+		// Surface the errors, so callers never receive a partially built result.
+		err = resp.Errors
+	}
+
+	var httpErr *graphql.HTTPError
+	if errors.As(err, &httpErr) {
+		// The server rejects requests that fail GraphQL validation with a 400 or 422 response
+		if httpErr.StatusCode != http.StatusBadRequest && httpErr.StatusCode != http.StatusUnprocessableEntity {
+			return err
+		}
+
+		if validationErr := validationError(&httpErr.Response); validationErr != nil {
+			return validationErr
+		}
+
+		return err
+	}
+
+	if validationErr := validationError(resp); validationErr != nil {
+		return validationErr
+	}
+
+	return err
+}
+
+// validationError returns a types.ErrValidation for the errors of resp, or nil if resp contains no errors or any of the errors is an internal server error.
+func validationError(resp *graphql.Response) error {
+	if resp == nil || len(resp.Errors) == 0 {
+		return nil
+	}
+
+	errs := make([]types.GraphqlError, 0, len(resp.Errors))
+
+	for _, gqlErr := range resp.Errors {
+		if gqlErr == nil {
+			continue
+		}
+
+		if code, ok := gqlErr.Extensions["code"].(string); ok && code == "INTERNAL_SERVER_ERROR" {
+			return nil
+		}
+
+		path := make([]string, 0, len(gqlErr.Path))
+		for _, element := range gqlErr.Path {
+			path = append(path, fmt.Sprint(element))
+		}
+
+		errs = append(errs, types.GraphqlError{
+			Message: gqlErr.Message,
+			Path:    path,
+		})
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return types.NewErrValidation(errs)
+}
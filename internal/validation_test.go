@@ -0,0 +1,95 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/types"
+)
+
+func TestValidationClient(t *testing.T) {
+	newServer := func(status int, body string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(body))
+		}))
+	}
+
+	t.Run("GraphQL errors", func(t *testing.T) {
+		server := newServer(http.StatusOK, `{"data":null,"errors":[{"message":"invalid value for enum","path":["createAccessProvider","action"]},{"message":"unknown field"}]}`)
+		defer server.Close()
+
+		client := NewValidationClient(graphql.NewClient(server.URL, server.Client()))
+
+		err := client.MakeRequest(context.Background(), queryRequest, &graphql.Response{})
+
+		var validationErr *types.ErrValidation
+		require.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, []types.GraphqlError{
+			{Message: "invalid value for enum", Path: []string{"createAccessProvider", "action"}},
+			{Message: "unknown field", Path: []string{}},
+		}, validationErr.Errors)
+		assert.Equal(t, "validation error: invalid value for enum (at createAccessProvider.action); unknown field", err.Error())
+	})
+
+	t.Run("Rejected request", func(t *testing.T) {
+		server := newServer(http.StatusUnprocessableEntity, `{"errors":[{"message":"Cannot query field \"foo\""}]}`)
+		defer server.Close()
+
+		client := NewValidationClient(graphql.NewClient(server.URL, server.Client()))
+
+		err := client.MakeRequest(context.Background(), queryRequest, &graphql.Response{})
+
+		assert.ErrorIs(t, err, &types.ErrValidation{})
+	})
+
+	t.Run("Internal server error", func(t *testing.T) {
+		server := newServer(http.StatusOK, `{"data":null,"errors":[{"message":"oops","extensions":{"code":"INTERNAL_SERVER_ERROR"}}]}`)
+		defer server.Close()
+
+		client := NewValidationClient(graphql.NewClient(server.URL, server.Client()))
+
+		err := client.MakeRequest(context.Background(), queryRequest, &graphql.Response{})
+
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, &types.ErrValidation{})
+	})
+
+	t.Run("Transport error", func(t *testing.T) {
+		server := newServer(http.StatusBadGateway, `bad gateway`)
+		defer server.Close()
+
+		client := NewValidationClient(graphql.NewClient(server.URL, server.Client()))
+
+		err := client.MakeRequest(context.Background(), queryRequest, &graphql.Response{})
+
+		var httpErr *graphql.HTTPError
+		assert.True(t, errors.As(err, &httpErr))
+		assert.NotErrorIs(t, err, &types.ErrValidation{})
+	})
+
+	t.Run("Unreported errors", func(t *testing.T) {
+		client := NewValidationClient(partialResponseClient{})
+
+		resp := &graphql.Response{}
+		err := client.MakeRequest(context.Background(), queryRequest, resp)
+
+		assert.ErrorIs(t, err, &types.ErrValidation{})
+	})
+}
+
+// partialResponseClient sets errors on the response without reporting them.
+type partialResponseClient struct{}
+
+func (partialResponseClient) MakeRequest(_ context.Context, _ *graphql.Request, resp *graphql.Response) error {
+	return json.Unmarshal([]byte(`{"data":{},"errors":[{"message":"could not resolve whoList","path":["createAccessProvider","whoList"]}]}`), resp)
+}
@@ -0,0 +1,118 @@
+// Package otel adds OpenTelemetry tracing to the Raito SDK.
+// It is a separate module, so users of the SDK that do not use OpenTelemetry do not depend on it.
+package otel
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/Khan/genqlient/graphql"
+	otelapi "go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	sdk "github.com/raito-io/sdk-go"
+)
+
+const instrumentationName = "github.com/raito-io/sdk-go/otel"
+
+type TracingOptions struct {
+	tracerProvider trace.TracerProvider
+}
+
+// WithTracerProvider can be used to specify the TracerProvider used to create spans.
+// By default, the global TracerProvider is used.
+func WithTracerProvider(tracerProvider trace.TracerProvider) func(options *TracingOptions) {
+	return func(options *TracingOptions) {
+		options.tracerProvider = tracerProvider
+	}
+}
+
+// WithTracing can be passed to sdk.NewClient to wrap each GraphQL operation in a span.
+// The span is started from the context passed to the SDK method.
+func WithTracing(ops ...func(options *TracingOptions)) func(options *sdk.ClientOptions) {
+	return sdk.WithMiddleware(func(client graphql.Client) graphql.Client {
+		return NewTracingClient(client, ops...)
+	})
+}
+
+// TracingClient is a graphql.Client that wraps each GraphQL operation in a span.
+type TracingClient struct {
+	client graphql.Client
+	tracer trace.Tracer
+}
+
+// NewTracingClient wraps client in a TracingClient.
+func NewTracingClient(client graphql.Client, ops ...func(options *TracingOptions)) *TracingClient {
+	options := TracingOptions{
+		tracerProvider: otelapi.GetTracerProvider(),
+	}
+
+	for _, op := range ops {
+		op(&options)
+	}
+
+	return &TracingClient{
+		client: client,
+		tracer: options.tracerProvider.Tracer(instrumentationName),
+	}
+}
+
+func (c *TracingClient) MakeRequest(ctx context.Context, req *graphql.Request, resp *graphql.Response) error {
+	attrs := []attribute.KeyValue{
+		attribute.String("graphql.operation.name", req.OpName),
+		attribute.String("graphql.operation.type", operationType(req)),
+	}
+
+	if id, ok := resourceId(req); ok {
+		attrs = append(attrs, attribute.String("raito.resource.id", id))
+	}
+
+	ctx, span := c.tracer.Start(ctx, req.OpName, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	defer span.End()
+
+	err := c.client.MakeRequest(ctx, req, resp)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "")
+
+	return nil
+}
+
+func operationType(req *graphql.Request) string {
+	if strings.HasPrefix(strings.TrimSpace(req.Query), "mutation") {
+		return "mutation"
+	}
+
+	return "query"
+}
+
+// resourceId returns the id variable of the request, if any.
+func resourceId(req *graphql.Request) (string, bool) {
+	if req.Variables == nil {
+		return "", false
+	}
+
+	variables, err := json.Marshal(req.Variables)
+	if err != nil {
+		return "", false
+	}
+
+	var parsed struct {
+		Id *string `json:"id"`
+	}
+
+	err = json.Unmarshal(variables, &parsed)
+	if err != nil || parsed.Id == nil {
+		return "", false
+	}
+
+	return *parsed.Id, true
+}
@@ -0,0 +1,77 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type stubGraphqlClient struct {
+	err error
+}
+
+func (c *stubGraphqlClient) MakeRequest(_ context.Context, _ *graphql.Request, _ *graphql.Response) error {
+	return c.err
+}
+
+func newRecordingClient(client graphql.Client) (*TracingClient, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	return NewTracingClient(client, WithTracerProvider(provider)), recorder
+}
+
+func TestTracingClient_MakeRequest(t *testing.T) {
+	client, recorder := newRecordingClient(&stubGraphqlClient{})
+
+	err := client.MakeRequest(context.Background(), &graphql.Request{
+		OpName:    "GetAccessProvider",
+		Query:     "query GetAccessProvider($id: ID!) { accessProvider(id: $id) { id } }",
+		Variables: map[string]interface{}{"id": "ap-1"},
+	}, &graphql.Response{})
+
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+
+	span := spans[0]
+	assert.Equal(t, "GetAccessProvider", span.Name())
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+	assert.Equal(t, codes.Ok, span.Status().Code)
+	assert.Contains(t, span.Attributes(), attribute.String("raito.resource.id", "ap-1"))
+	assert.Contains(t, span.Attributes(), attribute.String("graphql.operation.type", "query"))
+}
+
+func TestTracingClient_MakeRequest_Error(t *testing.T) {
+	client, recorder := newRecordingClient(&stubGraphqlClient{err: errors.New("boom")})
+
+	err := client.MakeRequest(context.Background(), &graphql.Request{
+		OpName: "DeleteAccessProvider",
+		Query:  "mutation DeleteAccessProvider { deleteAccessProvider { __typename } }",
+	}, &graphql.Response{})
+
+	require.EqualError(t, err, "boom")
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+
+	span := spans[0]
+	assert.Equal(t, "DeleteAccessProvider", span.Name())
+	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.Equal(t, "boom", span.Status().Description)
+	assert.Contains(t, span.Attributes(), attribute.String("graphql.operation.type", "mutation"))
+
+	for _, attr := range span.Attributes() {
+		assert.NotEqual(t, attribute.Key("raito.resource.id"), attr.Key)
+	}
+}
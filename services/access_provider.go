@@ -4,6 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/Khan/genqlient/graphql"
 	"github.com/aws/smithy-go/ptr"
@@ -11,25 +15,97 @@ import (
 	"github.com/raito-io/sdk-go/internal"
 	"github.com/raito-io/sdk-go/internal/schema"
 	"github.com/raito-io/sdk-go/types"
+	"github.com/raito-io/sdk-go/types/filter"
+	"github.com/raito-io/sdk-go/types/models"
 )
 
+// AccessProviderClient can be used to manage AccessProviders in Raito Cloud.
+// An AccessProviderClient is safe for concurrent use by multiple goroutines, and should be reused rather than created per request.
+// The options passed to its methods only apply to that call.
 type AccessProviderClient struct {
 	client graphql.Client
 }
 
-func NewAccessProviderClient(client graphql.Client) AccessProviderClient {
+func NewAccessProviderClient(client graphql.Client, ops ...func(options *ClientOptions)) AccessProviderClient {
 	return AccessProviderClient{
-		client: client,
+		client: newGraphqlClient(client, ops...),
+	}
+}
+
+// AccessProviderService is implemented by AccessProviderClient.
+// It can be used to replace the AccessProviderClient in tests, e.g. with servicestest.AccessProviderService.
+type AccessProviderService interface {
+	CreateAccessProvider(ctx context.Context, ap types.AccessProviderInput, ops ...func(options *CreateAccessProviderOptions)) (*types.AccessProvider, error)
+	CreateAccessProviders(ctx context.Context, aps []types.AccessProviderInput, ops ...func(options *AccessProviderBatchOptions)) ([]types.AccessProviderResult, error)
+	GetAccessProviders(ctx context.Context, ids []string, ops ...func(options *AccessProviderBatchOptions)) (map[string]types.AccessProviderResult, error)
+	DeleteAccessProviders(ctx context.Context, ids []string, ops ...func(options *AccessProviderBatchOptions)) ([]types.AccessProviderDeleteResult, error)
+	UpdateAccessProvider(ctx context.Context, id string, ap types.AccessProviderInput, ops ...func(options *UpdateAccessProviderOptions)) (*types.AccessProvider, error)
+	PatchAccessProvider(ctx context.Context, id string, patch types.AccessProviderPatch, ops ...func(options *UpdateAccessProviderOptions)) (*types.AccessProvider, error)
+	SetAccessProviderWhoList(ctx context.Context, id string, items []types.WhoItemInput, ops ...func(options *UpdateAccessProviderOptions)) error
+	DeleteAccessProvider(ctx context.Context, id string, ops ...func(options *UpdateAccessProviderOptions)) error
+	ActivateAccessProvider(ctx context.Context, id string) (*types.AccessProvider, error)
+	DeactivateAccessProvider(ctx context.Context, id string) (*types.AccessProvider, error)
+	GetAccessProvider(ctx context.Context, id string) (*types.AccessProvider, error)
+	GetAccessProviderWithWhoWhat(ctx context.Context, id string) (*types.AccessProviderWithWhoWhat, error)
+	CreateMaskingPolicy(ctx context.Context, ap types.AccessProviderInput) (*types.MaskingPolicy, error)
+	GetMaskingPolicy(ctx context.Context, id string) (*types.MaskingPolicy, error)
+	CreateRowFilter(ctx context.Context, ap types.AccessProviderInput) (*types.RowFilter, error)
+	GetRowFilter(ctx context.Context, id string) (*types.RowFilter, error)
+	GetAccessProviderSummary(ctx context.Context, id string) (*types.AccessProviderSummary, error)
+	AccessProviderExists(ctx context.Context, id string) (bool, error)
+	GetAccessProviderSyncStatus(ctx context.Context, id string) (*types.AccessProviderSyncStatus, error)
+	ListAccessProviders(ctx context.Context, ops ...func(*AccessProviderListOptions)) <-chan types.ListItem[types.AccessProvider]
+	SearchAccessProviders(ctx context.Context, query string, ops ...func(*AccessProviderListOptions)) <-chan types.ListItem[types.AccessProvider]
+	ListAccessProvidersCancelable(ctx context.Context, ops ...func(*AccessProviderListOptions)) (<-chan types.ListItem[types.AccessProvider], func())
+	ListAccessProvidersAll(ctx context.Context, ops ...func(*AccessProviderListOptions)) ([]types.AccessProvider, error)
+	ForEachAccessProvider(ctx context.Context, fn func(types.AccessProvider) error, ops ...func(*AccessProviderListOptions)) error
+	CountAccessProviders(ctx context.Context, filter *types.AccessProviderFilterInput) (int, error)
+	GetAccessProviderWhoList(ctx context.Context, id string, ops ...func(*AccessProviderWhoListOptions)) <-chan types.ListItem[types.AccessProviderWhoListItem]
+	CountAccessProviderWhoItems(ctx context.Context, id string) (int, error)
+	GetAccessProviderWhoListGrouped(ctx context.Context, id string, ops ...func(*AccessProviderWhoListOptions)) (*types.WhoListGroups, error)
+	GetAccessProviderWhoAccessProviderRefs(ctx context.Context, id string) <-chan types.ListItem[types.AccessProviderWhoAccessProviderRef]
+	GetAccessProviderWhatDataObjectList(ctx context.Context, id string, ops ...func(*AccessProviderWhatListOptions)) <-chan types.ListItem[types.AccessProviderWhatListItem]
+	GetAccessProviderWhatColumnList(ctx context.Context, id string, ops ...func(*AccessProviderWhatListOptions)) <-chan types.ListItem[types.AccessProviderWhatColumnItem]
+	GetAccessProviderWhatLeafDataObjects(ctx context.Context, id string, ops ...func(*AccessProviderWhatLeafOptions)) <-chan types.ListItem[types.AccessProviderWhatLeafItem]
+	GetAccessProviderWhoWhatList(ctx context.Context, id string) <-chan types.ListItem[types.AccessProviderWhoWhatItem]
+	AccessProviderHasWhatDataObject(ctx context.Context, apId string, dataObjectId string) (bool, error)
+	GetAccessProviderWhatAccessProviderList(ctx context.Context, id string, ops ...func(*AccessProviderWhatAccessProviderListOptions)) <-chan types.ListItem[types.AccessWhatAccessProviderItem]
+	GetAccessProviderAbacWhatScope(ctx context.Context, id string, ops ...func(*AccessProviderAbacWhatScopeListOptions)) <-chan types.ListItem[types.DataObject]
+	ExportAccessProviders(ctx context.Context, w io.Writer, ops ...func(*AccessProviderListOptions)) error
+	ImportAccessProviders(ctx context.Context, r io.Reader, ops ...func(options *AccessProviderImportOptions)) ([]types.AccessProviderImportResult, error)
+}
+
+var _ AccessProviderService = (*AccessProviderClient)(nil)
+
+type CreateAccessProviderOptions struct {
+	clientSideValidation bool
+}
+
+// WithAccessProviderCreateClientSideValidation can be used to validate the AccessProviderInput with types.ValidateAccessProviderInput before sending it to Raito Cloud.
+func WithAccessProviderCreateClientSideValidation() func(options *CreateAccessProviderOptions) {
+	return func(options *CreateAccessProviderOptions) {
+		options.clientSideValidation = true
 	}
 }
 
 // CreateAccessProvider creates a new AccessProvider in Raito Cloud.
 // The valid AccessProvider is returned if the creation is successful.
 // Otherwise, an error is returned
-func (a *AccessProviderClient) CreateAccessProvider(ctx context.Context, ap types.AccessProviderInput) (*types.AccessProvider, error) {
+func (a *AccessProviderClient) CreateAccessProvider(ctx context.Context, ap types.AccessProviderInput, ops ...func(options *CreateAccessProviderOptions)) (*types.AccessProvider, error) {
+	options := CreateAccessProviderOptions{}
+	for _, op := range ops {
+		op(&options)
+	}
+
+	if options.clientSideValidation {
+		if err := types.ValidateAccessProviderInput(&ap); err != nil {
+			return nil, err
+		}
+	}
+
 	result, err := schema.CreateAccessProvider(ctx, a.client, ap)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	switch response := result.CreateAccessProvider.(type) {
@@ -42,12 +118,157 @@ func (a *AccessProviderClient) CreateAccessProvider(ctx context.Context, ap type
 	case *schema.CreateAccessProviderCreateAccessProviderInvalidInputError:
 		return nil, types.NewErrInvalidInput(response.Message)
 	default:
-		return nil, fmt.Errorf("unexpected response type: %T", result.CreateAccessProvider)
+		return nil, unexpectedResponse("CreateAccessProvider", result.CreateAccessProvider)
+	}
+}
+
+// AccessProviderBatchOptions options for batch operations on AccessProviders.
+type AccessProviderBatchOptions struct {
+	concurrency int
+}
+
+// WithAccessProviderBatchConcurrency can be used to specify the maximum number of concurrent requests of a batch operation.
+func WithAccessProviderBatchConcurrency(concurrency int) func(options *AccessProviderBatchOptions) {
+	return func(options *AccessProviderBatchOptions) {
+		options.concurrency = concurrency
+	}
+}
+
+// CreateAccessProviders creates multiple AccessProviders in Raito Cloud.
+// A result is returned for each input, in the same order as the inputs. Each result contains the created AccessProvider or the error for that input.
+// Failing inputs do not stop the creation of the other AccessProviders.
+// The maximum number of concurrent requests can be specified with WithAccessProviderBatchConcurrency.
+// Once ctx is done, no new requests are started and the Err of each input that was not attempted is a types.ErrNotAttempted.
+// An error is only returned for transport-level problems or if the batch was aborted.
+func (a *AccessProviderClient) CreateAccessProviders(ctx context.Context, aps []types.AccessProviderInput, ops ...func(options *AccessProviderBatchOptions)) ([]types.AccessProviderResult, error) {
+	options := AccessProviderBatchOptions{concurrency: internal.DefaultBatchConcurrency}
+	for _, op := range ops {
+		op(&options)
+	}
+
+	if options.concurrency < 1 {
+		return nil, types.NewErrInvalidInput(fmt.Sprintf("batch concurrency should be at least 1, got %d", options.concurrency))
+	}
+
+	createdAps, errs := internal.BatchExecutor(ctx, aps, options.concurrency, func(ctx context.Context, ap types.AccessProviderInput) (*types.AccessProvider, error) {
+		return a.CreateAccessProvider(ctx, ap)
+	})
+
+	return toAccessProviderResults(createdAps, errs)
+}
+
+// GetAccessProviders fetches multiple AccessProviders in Raito Cloud concurrently.
+// A result is returned for each distinct id, keyed by id. Each result contains the AccessProvider or the error for that id, e.g. a types.ErrNotFound.
+// The Index of a result refers to the position of the id in ids, ignoring duplicates.
+// Failing ids do not stop fetching the other AccessProviders.
+// The maximum number of concurrent requests can be specified with WithAccessProviderBatchConcurrency.
+// Once ctx is done, no new requests are started and the Err of each input that was not attempted is a types.ErrNotAttempted.
+// An error is only returned for transport-level problems or if the batch was aborted.
+func (a *AccessProviderClient) GetAccessProviders(ctx context.Context, ids []string, ops ...func(options *AccessProviderBatchOptions)) (map[string]types.AccessProviderResult, error) {
+	options := AccessProviderBatchOptions{concurrency: internal.DefaultBatchConcurrency}
+	for _, op := range ops {
+		op(&options)
+	}
+
+	if options.concurrency < 1 {
+		return nil, types.NewErrInvalidInput(fmt.Sprintf("batch concurrency should be at least 1, got %d", options.concurrency))
+	}
+
+	distinctIds := make([]string, 0, len(ids))
+	seen := make(map[string]struct{}, len(ids))
+
+	for _, id := range ids {
+		if _, found := seen[id]; !found {
+			seen[id] = struct{}{}
+			distinctIds = append(distinctIds, id)
+		}
+	}
+
+	aps, errs := internal.BatchExecutor(ctx, distinctIds, options.concurrency, a.GetAccessProvider)
+
+	results := make(map[string]types.AccessProviderResult, len(distinctIds))
+
+	for i, id := range distinctIds {
+		results[id] = types.AccessProviderResult{
+			Index:          i,
+			AccessProvider: aps[i],
+			Err:            errs[i],
+		}
+	}
+
+	return results, batchError(errs)
+}
+
+// DeleteAccessProviders deletes multiple AccessProviders in Raito Cloud.
+// A result is returned for each id, in the same order as the ids. Each result contains the error for that id, if any.
+// Failing ids, for example ids that are not found, do not stop the deletion of the other AccessProviders.
+// The maximum number of concurrent requests can be specified with WithAccessProviderBatchConcurrency.
+// Once ctx is done, no new requests are started and the Err of each input that was not attempted is a types.ErrNotAttempted.
+// An error is only returned for transport-level problems or if the batch was aborted.
+func (a *AccessProviderClient) DeleteAccessProviders(ctx context.Context, ids []string, ops ...func(options *AccessProviderBatchOptions)) ([]types.AccessProviderDeleteResult, error) {
+	options := AccessProviderBatchOptions{concurrency: internal.DefaultBatchConcurrency}
+	for _, op := range ops {
+		op(&options)
 	}
+
+	if options.concurrency < 1 {
+		return nil, types.NewErrInvalidInput(fmt.Sprintf("batch concurrency should be at least 1, got %d", options.concurrency))
+	}
+
+	_, errs := internal.BatchExecutor(ctx, ids, options.concurrency, func(ctx context.Context, id string) (struct{}, error) {
+		return struct{}{}, a.DeleteAccessProvider(ctx, id)
+	})
+
+	results := make([]types.AccessProviderDeleteResult, len(ids))
+
+	for i := range ids {
+		results[i] = types.AccessProviderDeleteResult{
+			Index: i,
+			Id:    ids[i],
+			Err:   errs[i],
+		}
+	}
+
+	return results, batchError(errs)
+}
+
+func toAccessProviderResults(aps []*types.AccessProvider, errs []error) ([]types.AccessProviderResult, error) {
+	results := make([]types.AccessProviderResult, len(aps))
+
+	for i := range aps {
+		results[i] = types.AccessProviderResult{
+			Index:          i,
+			AccessProvider: aps[i],
+			Err:            errs[i],
+		}
+	}
+
+	return results, batchError(errs)
+}
+
+// batchError returns the first transport-level error in errs, if any.
+// Otherwise, the first types.ErrNotAttempted is returned, if the batch was aborted because the context was done.
+func batchError(errs []error) error {
+	for _, err := range errs {
+		var clientErr *types.ErrClient
+		if errors.As(err, &clientErr) {
+			return err
+		}
+	}
+
+	for _, err := range errs {
+		if errors.Is(err, &types.ErrNotAttempted{}) {
+			return err
+		}
+	}
+
+	return nil
 }
 
 type UpdateAccessProviderOptions struct {
-	overrideLocks bool
+	overrideLocks        bool
+	clientSideValidation bool
+	refuseLocked         bool
 }
 
 func WithAccessProviderOverrideLocks() func(options *UpdateAccessProviderOptions) {
@@ -56,6 +277,23 @@ func WithAccessProviderOverrideLocks() func(options *UpdateAccessProviderOptions
 	}
 }
 
+// WithAccessProviderUpdateClientSideValidation can be used to validate the AccessProviderInput with types.ValidateAccessProviderInput before sending it to Raito Cloud.
+// This option is ignored by PatchAccessProvider and DeleteAccessProvider.
+func WithAccessProviderUpdateClientSideValidation() func(options *UpdateAccessProviderOptions) {
+	return func(options *UpdateAccessProviderOptions) {
+		options.clientSideValidation = true
+	}
+}
+
+// WithAccessProviderUpdateRefuseLocked can be used to refuse updating an AccessProvider that has locks, e.g. because it is managed externally.
+// The AccessProvider is fetched before the update, which requires an additional request. A types.ErrLocked is returned if it is locked.
+// This option is ignored by PatchAccessProvider and DeleteAccessProvider, and has no effect in combination with WithAccessProviderOverrideLocks.
+func WithAccessProviderUpdateRefuseLocked() func(options *UpdateAccessProviderOptions) {
+	return func(options *UpdateAccessProviderOptions) {
+		options.refuseLocked = true
+	}
+}
+
 // UpdateAccessProvider updates an existing AccessProvider in Raito Cloud.
 // The updated AccessProvider is returned if the update is successful.
 // Otherwise, an error is returned.
@@ -65,11 +303,87 @@ func (a *AccessProviderClient) UpdateAccessProvider(ctx context.Context, id stri
 		op(&options)
 	}
 
+	if options.clientSideValidation {
+		if err := types.ValidateAccessProviderInput(&ap); err != nil {
+			return nil, err
+		}
+	}
+
+	if options.refuseLocked && !options.overrideLocks {
+		current, err := a.GetAccessProvider(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if types.IsAccessProviderLocked(current) {
+			locks := make([]types.AccessProviderLock, 0, len(current.Locks))
+			for i := range current.Locks {
+				locks = append(locks, current.Locks[i].LockKey)
+			}
+
+			return nil, types.NewErrLocked(id, locks)
+		}
+	}
+
 	result, err := schema.UpdateAccessProvider(ctx, a.client, id, ap, &options.overrideLocks)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
+	return handleUpdateAccessProviderResponse(id, result)
+}
+
+type patchAccessProviderInput struct {
+	Id            string                    `json:"id"`
+	Ap            types.AccessProviderPatch `json:"ap"`
+	OverrideLocks *bool                     `json:"overrideLocks,omitempty"`
+}
+
+// PatchAccessProvider updates only the fields of an existing AccessProvider in Raito Cloud that are set in the patch.
+// Fields that are nil in the patch are not sent and remain unchanged.
+// The updated AccessProvider is returned if the update is successful.
+// Otherwise, an error is returned.
+func (a *AccessProviderClient) PatchAccessProvider(ctx context.Context, id string, patch types.AccessProviderPatch, ops ...func(options *UpdateAccessProviderOptions)) (*types.AccessProvider, error) {
+	options := UpdateAccessProviderOptions{}
+	for _, op := range ops {
+		op(&options)
+	}
+
+	req := &graphql.Request{
+		OpName: "UpdateAccessProvider",
+		Query:  schema.UpdateAccessProvider_Operation,
+		Variables: &patchAccessProviderInput{
+			Id:            id,
+			Ap:            patch,
+			OverrideLocks: &options.overrideLocks,
+		},
+	}
+
+	var result schema.UpdateAccessProviderResponse
+
+	err := a.client.MakeRequest(ctx, req, &graphql.Response{Data: &result})
+	if err != nil {
+		return nil, clientError(err)
+	}
+
+	return handleUpdateAccessProviderResponse(id, &result)
+}
+
+// SetAccessProviderWhoList replaces the who items of an existing AccessProvider in Raito Cloud with the given items.
+// Only the who items are sent, so the Raito API adds and removes who items to match the given items in a single update, leaving the other fields unchanged.
+// An empty list removes all who items. The resulting who list can be retrieved with GetAccessProviderWhoList.
+func (a *AccessProviderClient) SetAccessProviderWhoList(ctx context.Context, id string, items []types.WhoItemInput, ops ...func(options *UpdateAccessProviderOptions)) error {
+	if items == nil {
+		// A nil slice is sent as null, which would leave the who items unchanged
+		items = []types.WhoItemInput{}
+	}
+
+	_, err := a.PatchAccessProvider(ctx, id, types.AccessProviderPatch{WhoItems: &items}, ops...)
+
+	return err
+}
+
+func handleUpdateAccessProviderResponse(id string, result *schema.UpdateAccessProviderResponse) (*types.AccessProvider, error) {
 	switch response := result.UpdateAccessProvider.(type) {
 	case *schema.UpdateAccessProviderUpdateAccessProvider:
 		return &response.AccessProvider, nil
@@ -80,9 +394,9 @@ func (a *AccessProviderClient) UpdateAccessProvider(ctx context.Context, id stri
 	case *schema.UpdateAccessProviderUpdateAccessProviderInvalidInputError:
 		return nil, types.NewErrInvalidInput(response.Message)
 	case *schema.UpdateAccessProviderUpdateAccessProviderNotFoundError:
-		return nil, types.NewErrNotFound(id, response.Typename, response.Message)
+		return nil, types.NewErrResourceNotFound("updateAccessProvider", "AccessProvider", id, response.Typename, response.Message)
 	default:
-		return nil, fmt.Errorf("unexpected response type: %T", result.UpdateAccessProvider)
+		return nil, unexpectedResponse("UpdateAccessProvider", result.UpdateAccessProvider)
 	}
 }
 
@@ -97,7 +411,7 @@ func (a *AccessProviderClient) DeleteAccessProvider(ctx context.Context, id stri
 
 	result, err := schema.DeleteAccessProvider(ctx, a.client, id, &options.overrideLocks)
 	if err != nil {
-		return types.NewErrClient(err)
+		return clientError(err)
 	}
 
 	switch response := result.DeleteAccessProvider.(type) {
@@ -106,132 +420,841 @@ func (a *AccessProviderClient) DeleteAccessProvider(ctx context.Context, id stri
 	case *schema.DeleteAccessProviderDeleteAccessProviderPermissionDeniedError:
 		return types.NewErrPermissionDenied("deleteAccessProvider", response.Message)
 	case *schema.DeleteAccessProviderDeleteAccessProviderNotFoundError:
-		return types.NewErrNotFound(id, response.Typename, response.Message)
+		return types.NewErrResourceNotFound("deleteAccessProvider", "AccessProvider", id, response.Typename, response.Message)
 	case *schema.DeleteAccessProviderDeleteAccessProviderInvalidInputError:
 		return types.NewErrInvalidInput(response.Message)
 	default:
-		return fmt.Errorf("unexpected response type: %T", result.DeleteAccessProvider)
+		return unexpectedResponse("DeleteAccessProvider", result.DeleteAccessProvider)
+	}
+}
+
+// ActivateAccessProvider activates an existing AccessProvider in Raito Cloud, without updating any other field.
+// The updated AccessProvider is returned if the activation is successful.
+// Otherwise, an error is returned, e.g. a types.ErrNotFound if the AccessProvider does not exist.
+func (a *AccessProviderClient) ActivateAccessProvider(ctx context.Context, id string) (*types.AccessProvider, error) {
+	result, err := schema.ActivateAccessProvider(ctx, a.client, id)
+	if err != nil {
+		return nil, clientError(err)
+	}
+
+	switch response := result.ActivateAccessProvider.(type) {
+	case *schema.ActivateAccessProviderActivateAccessProvider:
+		return &response.AccessProvider, nil
+	case *schema.ActivateAccessProviderActivateAccessProviderNotFoundError:
+		return nil, types.NewErrResourceNotFound("activateAccessProvider", "AccessProvider", id, response.Typename, response.Message)
+	case *schema.ActivateAccessProviderActivateAccessProviderPermissionDeniedError:
+		return nil, types.NewErrPermissionDenied("activateAccessProvider", response.Message)
+	default:
+		return nil, unexpectedResponse("ActivateAccessProvider", result.ActivateAccessProvider)
+	}
+}
+
+// DeactivateAccessProvider deactivates an existing AccessProvider in Raito Cloud, without updating any other field.
+// The updated AccessProvider is returned if the deactivation is successful.
+// Otherwise, an error is returned, e.g. a types.ErrNotFound if the AccessProvider does not exist.
+func (a *AccessProviderClient) DeactivateAccessProvider(ctx context.Context, id string) (*types.AccessProvider, error) {
+	result, err := schema.DeactivateAccessProvider(ctx, a.client, id)
+	if err != nil {
+		return nil, clientError(err)
+	}
+
+	switch response := result.DeactivateAccessProvider.(type) {
+	case *schema.DeactivateAccessProviderDeactivateAccessProvider:
+		return &response.AccessProvider, nil
+	case *schema.DeactivateAccessProviderDeactivateAccessProviderNotFoundError:
+		return nil, types.NewErrResourceNotFound("deactivateAccessProvider", "AccessProvider", id, response.Typename, response.Message)
+	case *schema.DeactivateAccessProviderDeactivateAccessProviderPermissionDeniedError:
+		return nil, types.NewErrPermissionDenied("deactivateAccessProvider", response.Message)
+	default:
+		return nil, unexpectedResponse("DeactivateAccessProvider", result.DeactivateAccessProvider)
+	}
+}
+
+// GetAccessProvider returns a specific AccessProvider
+func (a *AccessProviderClient) GetAccessProvider(ctx context.Context, id string) (*types.AccessProvider, error) {
+	result, err := schema.GetAccessProvider(ctx, a.client, id)
+	if err != nil {
+		return nil, clientError(err)
+	}
+
+	switch ap := result.AccessProvider.(type) {
+	case *schema.GetAccessProviderAccessProvider:
+		return &ap.AccessProvider, nil
+	case *schema.GetAccessProviderAccessProviderNotFoundError:
+		return nil, types.NewErrResourceNotFound("getAccessProvider", "AccessProvider", id, ap.Typename, ap.Message)
+	case *schema.GetAccessProviderAccessProviderPermissionDeniedError:
+		return nil, types.NewErrPermissionDenied("getAccessProvider", ap.Message)
+	default:
+		return nil, unexpectedResponse("GetAccessProvider", result.AccessProvider)
+	}
+}
+
+// GetAccessProviderWithWhoWhat returns the AccessProvider with the given id together with its who list and its what data object list.
+// This issues three concurrent requests (AccessProvider, who list, what list), so it takes the latency of a single request for AccessProviders with small lists.
+// If a list contains more than types.AccessProviderWithWhoWhatLimit items, a types.ErrTooManyItems is returned,
+// and GetAccessProviderWhoList and GetAccessProviderWhatDataObjectList should be used instead.
+func (a *AccessProviderClient) GetAccessProviderWithWhoWhat(ctx context.Context, id string) (*types.AccessProviderWithWhoWhat, error) {
+	var (
+		result                 types.AccessProviderWithWhoWhat
+		apErr, whoErr, whatErr error
+		wg                     sync.WaitGroup
+	)
+
+	wg.Add(3)
+
+	go func() {
+		defer wg.Done()
+
+		result.AccessProvider, apErr = a.GetAccessProvider(ctx, id)
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		result.WhoList, whoErr = collectLimited(ctx, "who list", func(ctx context.Context) <-chan types.ListItem[types.AccessProviderWhoListItem] {
+			return a.GetAccessProviderWhoList(ctx, id, WithAccessProviderWhoListPageSize(types.AccessProviderWithWhoWhatLimit))
+		})
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		result.WhatList, whatErr = collectLimited(ctx, "what list", func(ctx context.Context) <-chan types.ListItem[types.AccessProviderWhatListItem] {
+			return a.GetAccessProviderWhatDataObjectList(ctx, id, WithAccessProviderWhatListPageSize(types.AccessProviderWithWhoWhatLimit))
+		})
+	}()
+
+	wg.Wait()
+
+	for _, err := range []error{apErr, whoErr, whatErr} {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &result, nil
+}
+
+// collectLimited collects the items of the list returned by listFn, returning a types.ErrTooManyItems if it contains more than types.AccessProviderWithWhoWhatLimit items.
+func collectLimited[T any](ctx context.Context, list string, listFn func(ctx context.Context) <-chan types.ListItem[T]) ([]T, error) {
+	var items []T
+
+	err := internal.ForEach(ctx, listFn, func(item T) error {
+		if len(items) == types.AccessProviderWithWhoWhatLimit {
+			return types.NewErrTooManyItems(list, types.AccessProviderWithWhoWhatLimit)
+		}
+
+		items = append(items, item)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// CreateMaskingPolicy creates a new masking policy, i.e. an AccessProvider with the Mask action, in Raito Cloud.
+// The action of ap is set to Mask if it is not specified. The input is always validated with types.ValidateMaskingPolicyInput before it is sent,
+// so an input with another action or without data source is rejected.
+func (a *AccessProviderClient) CreateMaskingPolicy(ctx context.Context, ap types.AccessProviderInput) (*types.MaskingPolicy, error) {
+	if ap.Action == nil {
+		action := models.AccessProviderActionMask
+		ap.Action = &action
+	}
+
+	if err := types.ValidateMaskingPolicyInput(&ap); err != nil {
+		return nil, err
+	}
+
+	result, err := a.CreateAccessProvider(ctx, ap)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NewMaskingPolicy(result)
+}
+
+// GetMaskingPolicy returns a specific masking policy, including the mask type used in each data source.
+// An ErrInvalidInput is returned if the AccessProvider does not have the Mask action.
+func (a *AccessProviderClient) GetMaskingPolicy(ctx context.Context, id string) (*types.MaskingPolicy, error) {
+	ap, err := a.GetAccessProvider(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NewMaskingPolicy(ap)
+}
+
+// CreateRowFilter creates a new row filter, i.e. an AccessProvider with the Filtered action, in Raito Cloud.
+// The action of ap is set to Filtered if it is not specified. The input is always validated with types.ValidateRowFilterInput before it is sent,
+// so an input with another action or without policy rule is rejected.
+func (a *AccessProviderClient) CreateRowFilter(ctx context.Context, ap types.AccessProviderInput) (*types.RowFilter, error) {
+	if ap.Action == nil {
+		action := models.AccessProviderActionFiltered
+		ap.Action = &action
+	}
+
+	if err := types.ValidateRowFilterInput(&ap); err != nil {
+		return nil, err
+	}
+
+	result, err := a.CreateAccessProvider(ctx, ap)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NewRowFilter(result)
+}
+
+// GetRowFilter returns a specific row filter, including its policy rule.
+// An ErrInvalidInput is returned if the AccessProvider does not have the Filtered action.
+func (a *AccessProviderClient) GetRowFilter(ctx context.Context, id string) (*types.RowFilter, error) {
+	ap, err := a.GetAccessProvider(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NewRowFilter(ap)
+}
+
+const getAccessProviderSummaryOperation = `
+query GetAccessProviderSummary ($id: ID!) {
+	accessProvider(id: $id) {
+		__typename
+		... on AccessProvider {
+			id
+			name
+			state
+			action
+			createdAt
+			modifiedAt
+		}
+		... on PermissionDeniedError {
+			message
+		}
+		... on NotFoundError {
+			message
+		}
+		... on InvalidInputError {
+			message
+		}
+	}
+}
+`
+
+type getAccessProviderSummaryResponse struct {
+	AccessProvider struct {
+		Typename string `json:"__typename"`
+		Message  string `json:"message"`
+		types.AccessProviderSummary
+	} `json:"accessProvider"`
+}
+
+// GetAccessProviderSummary returns a summary of a specific AccessProvider.
+// Only the id, name, state, action and timestamps are requested, which is considerably cheaper than GetAccessProvider.
+func (a *AccessProviderClient) GetAccessProviderSummary(ctx context.Context, id string) (*types.AccessProviderSummary, error) {
+	req := &graphql.Request{
+		OpName: "GetAccessProviderSummary",
+		Query:  getAccessProviderSummaryOperation,
+		Variables: &struct {
+			Id string `json:"id"`
+		}{Id: id},
+	}
+
+	var result getAccessProviderSummaryResponse
+
+	err := a.client.MakeRequest(ctx, req, &graphql.Response{Data: &result})
+	if err != nil {
+		return nil, clientError(err)
+	}
+
+	ap := &result.AccessProvider
+
+	switch ap.Typename {
+	case "AccessProvider":
+		return &ap.AccessProviderSummary, nil
+	case "NotFoundError":
+		return nil, types.NewErrResourceNotFound("getAccessProvider", "AccessProvider", id, &ap.Typename, ap.Message)
+	case "PermissionDeniedError":
+		return nil, types.NewErrPermissionDenied("getAccessProvider", ap.Message)
+	case "InvalidInputError":
+		return nil, types.NewErrInvalidInput(ap.Message)
+	default:
+		return nil, types.NewErrUnexpectedResponse("GetAccessProviderSummary", ap.Typename)
+	}
+}
+
+const accessProviderExistsOperation = `
+query AccessProviderExists ($id: ID!) {
+	accessProvider(id: $id) {
+		__typename
+		... on AccessProvider {
+			id
+		}
+		... on PermissionDeniedError {
+			message
+		}
+		... on NotFoundError {
+			message
+		}
+		... on InvalidInputError {
+			message
+		}
+	}
+}
+`
+
+// AccessProviderExists returns whether an AccessProvider with the given id exists in Raito Cloud.
+// False is only returned if the AccessProvider is not found. Any other problem, including a permission denied error, is returned as error.
+// Only the id of the AccessProvider is requested.
+func (a *AccessProviderClient) AccessProviderExists(ctx context.Context, id string) (bool, error) {
+	req := &graphql.Request{
+		OpName: "AccessProviderExists",
+		Query:  accessProviderExistsOperation,
+		Variables: &struct {
+			Id string `json:"id"`
+		}{Id: id},
+	}
+
+	var result getAccessProviderSummaryResponse
+
+	err := a.client.MakeRequest(ctx, req, &graphql.Response{Data: &result})
+	if err != nil {
+		return false, clientError(err)
+	}
+
+	ap := &result.AccessProvider
+
+	switch ap.Typename {
+	case "AccessProvider":
+		return true, nil
+	case "NotFoundError":
+		return false, nil
+	case "PermissionDeniedError":
+		return false, types.NewErrPermissionDenied("getAccessProvider", ap.Message)
+	case "InvalidInputError":
+		return false, types.NewErrInvalidInput(ap.Message)
+	default:
+		return false, types.NewErrUnexpectedResponse("AccessProviderExists", ap.Typename)
+	}
+}
+
+const getAccessProviderSyncStatusOperation = `
+query GetAccessProviderSyncStatus ($id: ID!) {
+	accessProvider(id: $id) {
+		__typename
+		... on AccessProvider {
+			id
+			state
+			syncData {
+				dataSource {
+					id
+				}
+				syncStatus
+			}
+		}
+		... on PermissionDeniedError {
+			message
+		}
+		... on NotFoundError {
+			message
+		}
+		... on InvalidInputError {
+			message
+		}
+	}
+}
+`
+
+type getAccessProviderSyncStatusResponse struct {
+	AccessProvider struct {
+		Typename string                     `json:"__typename"`
+		Message  string                     `json:"message"`
+		Id       string                     `json:"id"`
+		State    models.AccessProviderState `json:"state"`
+		SyncData []struct {
+			DataSource struct {
+				Id string `json:"id"`
+			} `json:"dataSource"`
+			SyncStatus types.SyncStatus `json:"syncStatus"`
+		} `json:"syncData"`
+	} `json:"accessProvider"`
+}
+
+// GetAccessProviderSyncStatus returns the synchronization status of an AccessProvider for each of its data sources.
+// Raito Cloud synchronizes AccessProviders to the data sources asynchronously, so this can be polled to wait until a created or updated AccessProvider is in effect.
+// Only the id, state and sync status of the AccessProvider are requested.
+func (a *AccessProviderClient) GetAccessProviderSyncStatus(ctx context.Context, id string) (*types.AccessProviderSyncStatus, error) {
+	req := &graphql.Request{
+		OpName: "GetAccessProviderSyncStatus",
+		Query:  getAccessProviderSyncStatusOperation,
+		Variables: &struct {
+			Id string `json:"id"`
+		}{Id: id},
+	}
+
+	var result getAccessProviderSyncStatusResponse
+
+	err := a.client.MakeRequest(ctx, req, &graphql.Response{Data: &result})
+	if err != nil {
+		return nil, clientError(err)
+	}
+
+	ap := &result.AccessProvider
+
+	switch ap.Typename {
+	case "AccessProvider":
+		status := &types.AccessProviderSyncStatus{
+			Id:          ap.Id,
+			State:       ap.State,
+			DataSources: make(map[string]types.SyncStatus, len(ap.SyncData)),
+		}
+
+		for i := range ap.SyncData {
+			status.DataSources[ap.SyncData[i].DataSource.Id] = ap.SyncData[i].SyncStatus
+		}
+
+		return status, nil
+	case "NotFoundError":
+		return nil, types.NewErrResourceNotFound("getAccessProvider", "AccessProvider", id, &ap.Typename, ap.Message)
+	case "PermissionDeniedError":
+		return nil, types.NewErrPermissionDenied("getAccessProvider", ap.Message)
+	case "InvalidInputError":
+		return nil, types.NewErrInvalidInput(ap.Message)
+	default:
+		return nil, types.NewErrUnexpectedResponse("GetAccessProviderSyncStatus", ap.Typename)
+	}
+}
+
+type AccessProviderListOptions struct {
+	order          []types.AccessProviderOrderByInput
+	filters        []*types.AccessProviderFilterInput
+	search         *string
+	dataSource     *string
+	includeDeleted bool
+	modifiedSince  *time.Time
+	pageSize       int
+	adaptiveSize   int
+	adaptiveMax    int
+	prefetch       int
+	bufferSize     int
+	reverse        bool
+	startCursor    *string
+	progressFn     func(pageInfo types.PageInfo)
+	strict         bool
+}
+
+// WithAccessProviderListOrder can be used to specify the order of the returned AccessProviders.
+// Helpers like types.OrderByNameAsc can be used to construct the order.
+func WithAccessProviderListOrder(input ...types.AccessProviderOrderByInput) func(options *AccessProviderListOptions) {
+	return func(options *AccessProviderListOptions) {
+		options.order = append(options.order, input...)
+	}
+}
+
+// WithAccessProviderListFilter can be used to filter the returned AccessProviders.
+// If specified multiple times, only AccessProviders matching all filters are returned, as with filter.AccessProviderCondition.And:
+// the values of list fields like States are intersected, the ids in Exclude are combined and other fields must have the same value in all filters.
+// An ErrInvalidInput is returned if the filters can never match. As the Raito API does not support OR across filter fields, filters can't be combined with OR.
+func WithAccessProviderListFilter(input *types.AccessProviderFilterInput) func(options *AccessProviderListOptions) {
+	return func(options *AccessProviderListOptions) {
+		if input != nil {
+			options.filters = append(options.filters, input)
+		}
+	}
+}
+
+// WithAccessProviderListDataSource can be used to only return the AccessProviders of the DataSource with the given id.
+// It is combined with the filter specified with WithAccessProviderListFilter, regardless of the order of the options.
+func WithAccessProviderListDataSource(dataSourceId string) func(options *AccessProviderListOptions) {
+	return func(options *AccessProviderListOptions) {
+		options.dataSource = &dataSourceId
+	}
+}
+
+// WithAccessProviderListIncludeDeleted can be used to also return deleted AccessProviders, which are not returned by default.
+// If the filter specified with WithAccessProviderListFilter contains States, the deleted state is added to them.
+// The time at which a returned AccessProvider was deleted is available with types.AccessProviderDeletedAt.
+func WithAccessProviderListIncludeDeleted() func(options *AccessProviderListOptions) {
+	return func(options *AccessProviderListOptions) {
+		options.includeDeleted = true
+	}
+}
+
+// WithAccessProviderListModifiedSince can be used to only return the AccessProviders that were modified at or after the given time, e.g. for an incremental sync.
+// As the Raito API does not support filtering on the modification time, the AccessProviders are ordered by modification time, most recent first,
+// and listing stops at the first AccessProvider modified before the given time. It can't be combined with WithAccessProviderListOrder or WithAccessProviderListReverse.
+func WithAccessProviderListModifiedSince(since time.Time) func(options *AccessProviderListOptions) {
+	return func(options *AccessProviderListOptions) {
+		options.modifiedSince = &since
+	}
+}
+
+// WithAccessProviderListPageSize can be used to specify the number of AccessProviders fetched per request.
+// The page size should be between 1 and 1000.
+func WithAccessProviderListPageSize(pageSize int) func(options *AccessProviderListOptions) {
+	return func(options *AccessProviderListOptions) {
+		options.pageSize = pageSize
+	}
+}
+
+// WithAccessProviderListAdaptivePageSize can be used to fetch initial AccessProviders in the first request and to double the page size for each next request, up to maxPageSize.
+// This reduces the time until the first AccessProviders are received as well as the number of requests for large lists.
+// Both page sizes should be between 1 and 1000. It overrides WithAccessProviderListPageSize, regardless of the order of the options.
+func WithAccessProviderListAdaptivePageSize(initial, maxPageSize int) func(options *AccessProviderListOptions) {
+	return func(options *AccessProviderListOptions) {
+		options.adaptiveSize = initial
+		options.adaptiveMax = maxPageSize
+	}
+}
+
+// WithAccessProviderListPrefetch can be used to load up to depth pages ahead while the current page is being consumed.
+// Pages are still requested one after another, and the order of the returned AccessProviders is preserved.
+func WithAccessProviderListPrefetch(depth int) func(options *AccessProviderListOptions) {
+	return func(options *AccessProviderListOptions) {
+		options.prefetch = depth
+	}
+}
+
+// WithAccessProviderListBufferSize can be used to buffer up to size AccessProviders in the returned channel.
+// A larger buffer trades memory for smoother throughput when the consumer is slower than the API.
+func WithAccessProviderListBufferSize(size int) func(options *AccessProviderListOptions) {
+	return func(options *AccessProviderListOptions) {
+		options.bufferSize = size
+	}
+}
+
+// WithAccessProviderListReverse can be used to return the AccessProviders in reverse order.
+// Each order specified with WithAccessProviderListOrder is reversed. If no order is specified, the most recently created AccessProviders are returned first.
+// As the Raito API only supports forward pagination, the reversed order is requested from the server and the pages are still loaded one after another.
+func WithAccessProviderListReverse() func(options *AccessProviderListOptions) {
+	return func(options *AccessProviderListOptions) {
+		options.reverse = true
+	}
+}
+
+// WithAccessProviderListStartCursor can be used to resume listing after the AccessProvider with the given cursor.
+// The cursor of a received AccessProvider is available with ListItem.GetCursor. The same order and filter should be used as in the interrupted listing.
+func WithAccessProviderListStartCursor(cursor string) func(options *AccessProviderListOptions) {
+	return func(options *AccessProviderListOptions) {
+		options.startCursor = &cursor
+	}
+}
+
+// WithAccessProviderListProgress can be used to be notified of the PageInfo of each loaded page, e.g. to know when the last page is loaded.
+// The function is called from the pagination goroutine and should not block.
+func WithAccessProviderListProgress(progressFn func(pageInfo types.PageInfo)) func(options *AccessProviderListOptions) {
+	return func(options *AccessProviderListOptions) {
+		options.progressFn = progressFn
+	}
+}
+
+// WithAccessProviderListStrictDecode can be used to return a types.ErrUnexpectedEdge if the Raito API returns an item of an unknown type.
+// By default, such items are skipped, which allows the SDK to keep working with newer versions of the Raito API.
+func WithAccessProviderListStrictDecode() func(options *AccessProviderListOptions) {
+	return func(options *AccessProviderListOptions) {
+		options.strict = true
+	}
+}
+
+// ListAccessProviders returns a list of AccessProviders in Raito Cloud.
+// The order of the list can be specified with WithAccessProviderListOrder.
+// A filter can be specified with WithAccessProviderListFilter. The AccessProviders of a single DataSource can be listed with WithAccessProviderListDataSource.
+// Deleted AccessProviders are only returned if WithAccessProviderListIncludeDeleted is specified.
+// The page size can be specified with WithAccessProviderListPageSize.
+// Pages can be loaded ahead of the consumer with WithAccessProviderListPrefetch.
+// The order can be reversed with WithAccessProviderListReverse.
+// Each ListItem carries its cursor, so an interrupted listing can be resumed with WithAccessProviderListStartCursor.
+// A channel is returned that can be used to receive the list of AccessProviders.
+// To close the channel ensure to cancel the context.
+func (a *AccessProviderClient) ListAccessProviders(ctx context.Context, ops ...func(*AccessProviderListOptions)) <-chan types.ListItem[types.AccessProvider] {
+	options := AccessProviderListOptions{pageSize: internal.MaxPageSize}
+	for _, op := range ops {
+		op(&options)
+	}
+
+	if err := internal.ValidatePageSize(options.pageSize); err != nil {
+		return internal.ErrorChannel[types.AccessProvider](err)
+	}
+
+	if options.adaptiveSize != 0 || options.adaptiveMax != 0 {
+		for _, pageSize := range []int{options.adaptiveSize, options.adaptiveMax} {
+			if err := internal.ValidatePageSize(pageSize); err != nil {
+				return internal.ErrorChannel[types.AccessProvider](err)
+			}
+		}
+
+		options.pageSize = options.adaptiveSize
+	}
+
+	filter, err := accessProviderListFilter(&options)
+	if err != nil {
+		return internal.ErrorChannel[types.AccessProvider](err)
+	}
+
+	order := options.order
+	if options.reverse {
+		order = reverseAccessProviderOrder(order)
+	}
+
+	if options.modifiedSince != nil {
+		if len(options.order) > 0 || options.reverse {
+			return internal.ErrorChannel[types.AccessProvider](types.NewErrInvalidInput("modified since can't be combined with a custom order"))
+		}
+
+		order = []types.AccessProviderOrderByInput{types.OrderByModifiedDesc()}
+	}
+
+	loadPageFn := func(ctx context.Context, cursor *string) (*schema.PageInfo, []schema.AccessProviderPageEdgesEdge, error) {
+		output, err := schema.ListAccessProviders(ctx, a.client, cursor, ptr.Int(internal.PageSize(ctx, options.pageSize)), filter, order)
+		if err != nil {
+			return nil, nil, clientError(err)
+		}
+
+		switch page := output.AccessProviders.(type) {
+		case *schema.ListAccessProvidersAccessProvidersPagedResult:
+			pageInfo := page.PageInfo.PageInfo
+
+			// The next pages only contain AccessProviders that were modified even earlier
+			if options.modifiedSince != nil && modifiedBefore(page.Edges, *options.modifiedSince) {
+				pageInfo.HasNextPage = ptr.Bool(false)
+			}
+
+			return &pageInfo, page.Edges, nil
+		case *schema.ListAccessProvidersAccessProvidersPermissionDeniedError:
+			return nil, nil, types.NewErrPermissionDenied("listAccessProviders", page.Message)
+		default:
+			return nil, nil, unexpectedResponse("ListAccessProviders", page)
+		}
+	}
+
+	edgeFn := func(edge *schema.AccessProviderPageEdgesEdge) (*string, *schema.AccessProvider, error) {
+		cursor := edge.Cursor
+
+		if edge.Node == nil {
+			return cursor, nil, nil
+		}
+
+		listItem, err := internal.DecodeEdgeNode[*schema.AccessProviderPageEdgesEdgeNodeAccessProvider](*edge.Node)
+		if err != nil {
+			return cursor, nil, err
+		}
+
+		if options.modifiedSince != nil && listItem.ModifiedAt.Before(*options.modifiedSince) {
+			return cursor, nil, nil
+		}
+
+		return cursor, &listItem.AccessProvider, nil
+	}
+
+	return internal.PaginationExecutor(ctx, loadPageFn, edgeFn, internal.WithPaginationPrefetch(options.prefetch), internal.WithPaginationBufferSize(options.bufferSize), internal.WithPaginationStartCursor(options.startCursor), internal.WithPaginationProgress(options.progressFn), internal.WithPaginationStrictDecode(options.strict), internal.WithPaginationAdaptivePageSize(options.adaptiveSize, options.adaptiveMax))
+}
+
+// modifiedBefore returns true if the last AccessProvider of the page was modified before since.
+func modifiedBefore(edges []schema.AccessProviderPageEdgesEdge, since time.Time) bool {
+	for i := len(edges) - 1; i >= 0; i-- {
+		if edges[i].Node == nil {
+			continue
+		}
+
+		if ap, ok := (*edges[i].Node).(*schema.AccessProviderPageEdgesEdgeNodeAccessProvider); ok {
+			return ap.ModifiedAt.Before(since)
+		}
+	}
+
+	return false
+}
+
+// accessProviderListFilter combines the filters, the search query, the data source and the deleted state of the options, without modifying the filters provided by the caller.
+func accessProviderListFilter(options *AccessProviderListOptions) (*types.AccessProviderFilterInput, error) {
+	if len(options.filters) <= 1 && options.search == nil && options.dataSource == nil && !options.includeDeleted {
+		if len(options.filters) == 0 {
+			return nil, nil
+		}
+
+		return options.filters[0], nil
 	}
-}
 
-func (a *AccessProviderClient) ActivateAccessProvider(ctx context.Context, id string) (*types.AccessProvider, error) {
-	result, err := schema.ActivateAccessProvider(ctx, a.client, id)
+	merged, err := mergeAccessProviderListFilters(options.filters, options.search)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, err
 	}
 
-	switch response := result.ActivateAccessProvider.(type) {
-	case *schema.ActivateAccessProviderActivateAccessProvider:
-		return &response.AccessProvider, nil
-	case *schema.ActivateAccessProviderActivateAccessProviderNotFoundError:
-		return nil, types.NewErrNotFound(id, response.Typename, response.Message)
-	case *schema.ActivateAccessProviderActivateAccessProviderPermissionDeniedError:
-		return nil, types.NewErrPermissionDenied("activateAccessProvider", response.Message)
-	default:
-		return nil, fmt.Errorf("unexpected response type: %T", result.ActivateAccessProvider)
+	if options.dataSource != nil {
+		if merged.DataSource != nil && *merged.DataSource != *options.dataSource {
+			return nil, types.NewErrInvalidInput(fmt.Sprintf("data source %q conflicts with data source %q of the filter", *options.dataSource, *merged.DataSource))
+		}
+
+		merged.DataSource = options.dataSource
+	}
+
+	if options.includeDeleted {
+		if len(merged.States) == 0 {
+			merged.States = []models.AccessProviderState{models.AccessProviderStateActive, models.AccessProviderStateInactive, models.AccessProviderStateDeleted}
+		} else if !slices.Contains(merged.States, models.AccessProviderStateDeleted) {
+			merged.States = append(slices.Clone(merged.States), models.AccessProviderStateDeleted)
+		}
 	}
+
+	return &merged, nil
 }
 
-func (a *AccessProviderClient) DeactivateAccessProvider(ctx context.Context, id string) (*types.AccessProvider, error) {
-	result, err := schema.DeactivateAccessProvider(ctx, a.client, id)
+// mergeAccessProviderListFilters returns a copy of the filter matching all filters. If search is set, it replaces the Search field of the filters.
+func mergeAccessProviderListFilters(filters []*types.AccessProviderFilterInput, search *string) (types.AccessProviderFilterInput, error) {
+	conditions := make([]filter.AccessProviderCondition, 0, len(filters))
+
+	for _, input := range filters {
+		if search != nil {
+			withoutSearch := *input
+			withoutSearch.Search = nil
+			input = &withoutSearch
+		}
+
+		conditions = append(conditions, filter.Input(input))
+	}
+
+	merged, err := filter.Input(nil).And(conditions...).Build()
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return types.AccessProviderFilterInput{}, err
 	}
 
-	switch response := result.DeactivateAccessProvider.(type) {
-	case *schema.DeactivateAccessProviderDeactivateAccessProvider:
-		return &response.AccessProvider, nil
-	case *schema.DeactivateAccessProviderDeactivateAccessProviderNotFoundError:
-		return nil, types.NewErrNotFound(id, response.Typename, response.Message)
-	case *schema.DeactivateAccessProviderDeactivateAccessProviderPermissionDeniedError:
-		return nil, types.NewErrPermissionDenied("deactivateAccessProvider", response.Message)
-	default:
-		return nil, fmt.Errorf("unexpected response type: %T", result.DeactivateAccessProvider)
+	if search != nil {
+		merged.Search = search
 	}
+
+	return *merged, nil
 }
 
-// GetAccessProvider returns a specific AccessProvider
-func (a *AccessProviderClient) GetAccessProvider(ctx context.Context, id string) (*types.AccessProvider, error) {
-	result, err := schema.GetAccessProvider(ctx, a.client, id)
-	if err != nil {
-		return nil, types.NewErrClient(err)
+func reverseAccessProviderOrder(order []types.AccessProviderOrderByInput) []types.AccessProviderOrderByInput {
+	if len(order) == 0 {
+		return []types.AccessProviderOrderByInput{types.OrderByCreatedDesc()}
 	}
 
-	switch ap := result.AccessProvider.(type) {
-	case *schema.GetAccessProviderAccessProvider:
-		return &ap.AccessProvider, nil
-	case *schema.GetAccessProviderAccessProviderNotFoundError:
-		return nil, types.NewErrNotFound(id, ap.Typename, ap.Message)
-	case *schema.GetAccessProviderAccessProviderPermissionDeniedError:
-		return nil, types.NewErrPermissionDenied("getAccessProvider", ap.Message)
-	default:
-		return nil, fmt.Errorf("unexpected response type: %T", result.AccessProvider)
+	reversed := make([]types.AccessProviderOrderByInput, 0, len(order))
+
+	for _, o := range order {
+		reversed = append(reversed, types.AccessProviderOrderByInput{
+			Name:       reverseSort(o.Name),
+			CreatedAt:  reverseSort(o.CreatedAt),
+			ModifiedAt: reverseSort(o.ModifiedAt),
+			Action:     reverseSort(o.Action),
+			State:      reverseSort(o.State),
+			Sync:       reverseSort(o.Sync),
+		})
 	}
-}
 
-type AccessProviderListOptions struct {
-	order  []types.AccessProviderOrderByInput
-	filter *types.AccessProviderFilterInput
+	return reversed
 }
 
-// WithAccessProviderListOrder can be used to specify the order of the returned AccessProviders.
-func WithAccessProviderListOrder(input ...types.AccessProviderOrderByInput) func(options *AccessProviderListOptions) {
-	return func(options *AccessProviderListOptions) {
-		options.order = append(options.order, input...)
+func reverseSort(sort *types.Sort) *types.Sort {
+	if sort == nil {
+		return nil
 	}
-}
 
-// WithAccessProviderListFilter can be used to filter the returned AccessProviders.
-func WithAccessProviderListFilter(input *types.AccessProviderFilterInput) func(options *AccessProviderListOptions) {
-	return func(options *AccessProviderListOptions) {
-		options.filter = input
+	reversed := types.SortDesc
+	if *sort == types.SortDesc {
+		reversed = types.SortAsc
 	}
+
+	return &reversed
 }
 
-// ListAccessProviders returns a list of AccessProviders in Raito Cloud.
-// The order of the list can be specified with WithAccessProviderListOrder.
-// A filter can be specified with WithAccessProviderListFilter.
+// SearchAccessProviders returns all AccessProviders in Raito Cloud matching the given free text query, using the search filter of the Raito API.
+// The same options as ListAccessProviders can be used. The query is combined with the filters specified with WithAccessProviderListFilter, replacing their Search field.
 // A channel is returned that can be used to receive the list of AccessProviders.
 // To close the channel ensure to cancel the context.
-func (a *AccessProviderClient) ListAccessProviders(ctx context.Context, ops ...func(*AccessProviderListOptions)) <-chan types.ListItem[types.AccessProvider] {
-	options := AccessProviderListOptions{}
-	for _, op := range ops {
-		op(&options)
+func (a *AccessProviderClient) SearchAccessProviders(ctx context.Context, query string, ops ...func(*AccessProviderListOptions)) <-chan types.ListItem[types.AccessProvider] {
+	searchOp := func(options *AccessProviderListOptions) {
+		options.search = &query
 	}
 
-	loadPageFn := func(ctx context.Context, cursor *string) (*schema.PageInfo, []schema.AccessProviderPageEdgesEdge, error) {
-		output, err := schema.ListAccessProviders(ctx, a.client, cursor, ptr.Int(internal.MaxPageSize), options.filter, options.order)
-		if err != nil {
-			return nil, nil, types.NewErrClient(err)
-		}
+	return a.ListAccessProviders(ctx, append(ops, searchOp)...)
+}
 
-		switch page := output.AccessProviders.(type) {
-		case *schema.ListAccessProvidersAccessProvidersPagedResult:
-			return &page.PageInfo.PageInfo, page.Edges, nil
-		case *schema.ListAccessProvidersAccessProvidersPermissionDeniedError:
-			return nil, nil, types.NewErrPermissionDenied("listAccessProviders", page.Message)
-		default:
-			return nil, nil, errors.New("unreachable")
+// ListAccessProvidersCancelable lists the AccessProviders in Raito Cloud in the same way as ListAccessProviders, without requiring a cancelable context.
+// The returned cancel function stops the listing and must be called when the channel is no longer consumed, typically using defer.
+// Once cancel returns, the listing goroutine has terminated and the channel is closed.
+func (a *AccessProviderClient) ListAccessProvidersCancelable(ctx context.Context, ops ...func(*AccessProviderListOptions)) (<-chan types.ListItem[types.AccessProvider], func()) {
+	return internal.CancelableList(ctx, func(ctx context.Context) <-chan types.ListItem[types.AccessProvider] {
+		return a.ListAccessProviders(ctx, ops...)
+	})
+}
+
+// ListAccessProvidersAll returns all AccessProviders in Raito Cloud as a slice.
+// The same options as ListAccessProviders can be used.
+// Listing stops at the first error. The AccessProviders received until then are returned together with the error.
+func (a *AccessProviderClient) ListAccessProvidersAll(ctx context.Context, ops ...func(*AccessProviderListOptions)) ([]types.AccessProvider, error) {
+	return internal.CollectAll(ctx, func(ctx context.Context) <-chan types.ListItem[types.AccessProvider] {
+		return a.ListAccessProviders(ctx, ops...)
+	})
+}
+
+// ForEachAccessProvider calls fn for each AccessProvider in Raito Cloud, as an alternative to consuming the channel of ListAccessProviders.
+// The same options as ListAccessProviders can be used.
+// Iteration stops at the first error, either returned by the Raito API or by fn, and that error is returned. The listing is cleaned up before returning.
+func (a *AccessProviderClient) ForEachAccessProvider(ctx context.Context, fn func(types.AccessProvider) error, ops ...func(*AccessProviderListOptions)) error {
+	return internal.ForEach(ctx, func(ctx context.Context) <-chan types.ListItem[types.AccessProvider] {
+		return a.ListAccessProviders(ctx, ops...)
+	}, fn)
+}
+
+// CountAccessProviders returns the number of AccessProviders in Raito Cloud matching the given filter.
+// The filter is applied in the same way as WithAccessProviderListFilter in ListAccessProviders, so the count matches the number of listed AccessProviders.
+// As the Raito API does not expose a total count, all matching AccessProviders are paged through using the maximum page size.
+func (a *AccessProviderClient) CountAccessProviders(ctx context.Context, filter *types.AccessProviderFilterInput) (int, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	count := 0
+
+	for listItem := range a.ListAccessProviders(ctx, WithAccessProviderListFilter(filter), WithAccessProviderListPageSize(internal.MaxServerPageSize)) {
+		if listItem.HasError() {
+			return 0, listItem.GetError()
 		}
+
+		count++
 	}
 
-	edgeFn := func(edge *schema.AccessProviderPageEdgesEdge) (*string, *schema.AccessProvider, error) {
-		cursor := edge.Cursor
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
 
-		if edge.Node == nil {
-			return cursor, nil, nil
+	return count, nil
+}
+
+// CountAccessProviderWhoItems returns the number of who items of an AccessProvider in Raito Cloud, e.g. to warn before loading a very large who list.
+// Inherited who items are not counted. As the Raito API does not expose a total count, the who list is paged through using the maximum page size.
+func (a *AccessProviderClient) CountAccessProviderWhoItems(ctx context.Context, id string) (int, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	count := 0
+
+	for listItem := range a.GetAccessProviderWhoList(ctx, id, WithAccessProviderWhoListPageSize(internal.MaxServerPageSize)) {
+		if listItem.HasError() {
+			return 0, listItem.GetError()
 		}
 
-		listItem := (*edge.Node).(*schema.AccessProviderPageEdgesEdgeNodeAccessProvider)
+		count++
+	}
 
-		return cursor, &listItem.AccessProvider, nil
+	if err := ctx.Err(); err != nil {
+		return 0, err
 	}
 
-	return internal.PaginationExecutor(ctx, loadPageFn, edgeFn)
+	return count, nil
 }
 
 type AccessProviderWhoListOptions struct {
-	order []types.AccessProviderWhoOrderByInput
+	order         []types.AccessProviderWhoOrderByInput
+	filter        *types.AccessProviderWhoListFilter
+	identityStore *string
+	pageSize      int
+	expanded      bool
+	dedup         bool
+	progressFn    func(pageInfo types.PageInfo)
 }
 
 // WithAccessProviderWhoListOrder can be used to specify the order of the returned AccessProviderWhoList
@@ -241,20 +1264,101 @@ func WithAccessProviderWhoListOrder(input ...schema.AccessProviderWhoOrderByInpu
 	}
 }
 
+// WithAccessProviderWhoListFilter can be used to filter the returned AccessProviderWhoList.
+func WithAccessProviderWhoListFilter(input *types.AccessProviderWhoListFilter) func(options *AccessProviderWhoListOptions) {
+	return func(options *AccessProviderWhoListOptions) {
+		options.filter = input
+	}
+}
+
+// WithAccessProviderWhoListIdentityStore can be used to only return the who items of the identity store with the given id.
+// It is combined with the filter specified with WithAccessProviderWhoListFilter, regardless of the order of the options.
+// As the who list only includes the identity store of groups, only groups are returned.
+func WithAccessProviderWhoListIdentityStore(identityStoreId string) func(options *AccessProviderWhoListOptions) {
+	return func(options *AccessProviderWhoListOptions) {
+		options.identityStore = &identityStoreId
+	}
+}
+
+// WithAccessProviderWhoListPageSize can be used to specify the number of who items fetched per request.
+// The page size should be between 1 and 1000.
+func WithAccessProviderWhoListPageSize(pageSize int) func(options *AccessProviderWhoListOptions) {
+	return func(options *AccessProviderWhoListOptions) {
+		options.pageSize = pageSize
+	}
+}
+
+// WithAccessProviderWhoListExpanded can be used to also return the who items inherited from AccessProviders in the who list.
+// The who lists of referenced AccessProviders are fetched recursively. Each AccessProvider is visited once, so cycles in the references are not a problem.
+// Groups are returned as is, as their members are managed in the identity stores.
+// Each who item is returned once and cursors are not set, as the items originate from multiple lists.
+// Expansion can't be combined with a search filter.
+func WithAccessProviderWhoListExpanded() func(options *AccessProviderWhoListOptions) {
+	return func(options *AccessProviderWhoListOptions) {
+		options.expanded = true
+	}
+}
+
+// WithAccessProviderWhoListDedup can be used to drop who items that were already returned, e.g. because the who list changed while paging through it.
+// Users, groups and AccessProviders are identified by their id. The ids of all returned items are kept in memory until the list is completed.
+// An expanded who list is always de-duplicated.
+func WithAccessProviderWhoListDedup() func(options *AccessProviderWhoListOptions) {
+	return func(options *AccessProviderWhoListOptions) {
+		options.dedup = true
+	}
+}
+
+// WithAccessProviderWhoListProgress can be used to be notified of the PageInfo of each loaded page of who items, e.g. to report progress while draining a large who list.
+// For an expanded who list, the function is called for the pages of each visited AccessProvider.
+// The function is called from the pagination goroutine and should not block.
+func WithAccessProviderWhoListProgress(progressFn func(pageInfo types.PageInfo)) func(options *AccessProviderWhoListOptions) {
+	return func(options *AccessProviderWhoListOptions) {
+		options.progressFn = progressFn
+	}
+}
+
 // GetAccessProviderWhoList returns all who items of an AccessProvider in Raito Cloud.
 // The order of the list can be specified with WithAccessProviderWhoListOrder.
+// A filter can be specified with WithAccessProviderWhoListFilter, and the who items can be limited to a single identity store with WithAccessProviderWhoListIdentityStore.
+// The page size can be specified with WithAccessProviderWhoListPageSize.
+// Inherited who items can be included with WithAccessProviderWhoListExpanded.
+// Duplicate who items can be dropped with WithAccessProviderWhoListDedup.
 // A channel is returned that can be used to receive the list of AccessProviderWhoListItem.
 // To close the channel ensure to cancel the context.
 func (a *AccessProviderClient) GetAccessProviderWhoList(ctx context.Context, id string, ops ...func(*AccessProviderWhoListOptions)) <-chan types.ListItem[types.AccessProviderWhoListItem] {
-	options := AccessProviderWhoListOptions{}
+	options := AccessProviderWhoListOptions{pageSize: internal.MaxPageSize}
 	for _, op := range ops {
 		op(&options)
 	}
 
+	if err := internal.ValidatePageSize(options.pageSize); err != nil {
+		return internal.ErrorChannel[types.AccessProviderWhoListItem](err)
+	}
+
+	filter, err := accessProviderWhoListFilter(&options)
+	if err != nil {
+		return internal.ErrorChannel[types.AccessProviderWhoListItem](err)
+	}
+
+	options.filter = filter
+
+	var search *string
+	if options.filter != nil {
+		search = options.filter.Search
+	}
+
+	if options.expanded {
+		if search != nil {
+			return internal.ErrorChannel[types.AccessProviderWhoListItem](types.NewErrInvalidInput("search filter is not supported on an expanded who list"))
+		}
+
+		return a.getExpandedAccessProviderWhoList(ctx, id, &options)
+	}
+
 	loadPageFn := func(ctx context.Context, cursor *string) (*types.PageInfo, []types.AccessProviderWhoListEdgesEdge, error) {
-		output, err := schema.GetAccessProviderWhoList(ctx, a.client, id, cursor, ptr.Int(internal.MaxPageSize), nil, options.order)
+		output, err := schema.GetAccessProviderWhoList(ctx, a.client, id, cursor, ptr.Int(options.pageSize), search, options.order)
 		if err != nil {
-			return nil, nil, types.NewErrClient(err)
+			return nil, nil, clientError(err)
 		}
 
 		switch ap := output.AccessProvider.(type) {
@@ -264,18 +1368,20 @@ func (a *AccessProviderClient) GetAccessProviderWhoList(ctx context.Context, id
 				return &whoList.PageInfo.PageInfo, whoList.Edges, nil
 			case *schema.GetAccessProviderWhoListAccessProviderWhoListPermissionDeniedError:
 				return nil, nil, types.NewErrPermissionDenied("accessProviderWhoList", whoList.Message)
+			default:
+				return nil, nil, unexpectedResponse("GetAccessProviderWhoList", whoList)
 			}
 		case *schema.GetAccessProviderWhoListAccessProviderNotFoundError:
-			return nil, nil, types.NewErrNotFound(id, ap.Typename, ap.Message)
+			return nil, nil, types.NewErrResourceNotFound("accessProvider", "AccessProvider", id, ap.Typename, ap.Message)
 		case *schema.GetAccessProviderWhoListAccessProviderPermissionDeniedError:
 			return nil, nil, types.NewErrPermissionDenied("accessProvider", ap.Message)
 		default:
-			return nil, nil, fmt.Errorf("unexpected type '%T': %w", ap, types.ErrUnknownType)
+			return nil, nil, unexpectedResponse("GetAccessProviderWhoList", ap)
 		}
-
-		return nil, nil, errors.New("unreachable")
 	}
 
+	returned := map[string]struct{}{}
+
 	edgeFn := func(edge *types.AccessProviderWhoListEdgesEdge) (*string, *schema.AccessProviderWhoListItem, error) {
 		cursor := edge.Cursor
 
@@ -283,17 +1389,164 @@ func (a *AccessProviderClient) GetAccessProviderWhoList(ctx context.Context, id
 			return cursor, nil, nil
 		}
 
-		listItem := (*edge.Node).(*types.AccessProviderWhoListEdgesEdgeNodeAccessWhoItem)
+		listItem, err := internal.DecodeEdgeNode[*types.AccessProviderWhoListEdgesEdgeNodeAccessWhoItem](*edge.Node)
+		if err != nil {
+			return cursor, nil, err
+		}
+
+		// Filtered items are skipped, but their cursor is still returned to continue pagination after them
+		if !options.filter.Matches(&listItem.AccessProviderWhoListItem) {
+			return cursor, nil, nil
+		}
+
+		if options.dedup {
+			if key, ok := whoItemKey(&listItem.AccessProviderWhoListItem); ok {
+				if _, found := returned[key]; found {
+					return cursor, nil, nil
+				}
+
+				returned[key] = struct{}{}
+			}
+		}
 
 		return cursor, &listItem.AccessProviderWhoListItem, nil
 	}
 
-	return internal.PaginationExecutor(ctx, loadPageFn, edgeFn)
+	return internal.PaginationExecutor(ctx, loadPageFn, edgeFn, internal.WithPaginationProgress(options.progressFn))
+}
+
+// accessProviderWhoListFilter returns the filter of the who list, combined with the identity store of WithAccessProviderWhoListIdentityStore.
+func accessProviderWhoListFilter(options *AccessProviderWhoListOptions) (*types.AccessProviderWhoListFilter, error) {
+	if options.identityStore == nil {
+		return options.filter, nil
+	}
+
+	filter := types.AccessProviderWhoListFilter{}
+	if options.filter != nil {
+		filter = *options.filter
+	}
+
+	if filter.IdentityStoreId != nil && *filter.IdentityStoreId != *options.identityStore {
+		return nil, types.NewErrInvalidInput(fmt.Sprintf("identity store %q conflicts with identity store %q of the filter", *options.identityStore, *filter.IdentityStoreId))
+	}
+
+	filter.IdentityStoreId = options.identityStore
+
+	return &filter, nil
+}
+
+// getExpandedAccessProviderWhoList walks the AccessProvider references in the who lists, starting from the AccessProvider with the given id.
+func (a *AccessProviderClient) getExpandedAccessProviderWhoList(ctx context.Context, id string, options *AccessProviderWhoListOptions) <-chan types.ListItem[types.AccessProviderWhoListItem] {
+	outputChannel := make(chan types.ListItem[types.AccessProviderWhoListItem])
+
+	go func() {
+		defer close(outputChannel)
+
+		// Stops the who list of the current AccessProvider if we return early
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		send := func(item types.ListItem[types.AccessProviderWhoListItem]) bool {
+			select {
+			case <-ctx.Done():
+				return false
+			case outputChannel <- item:
+				return true
+			}
+		}
+
+		visited := map[string]struct{}{id: {}}
+		returned := map[string]struct{}{}
+		queue := []string{id}
+
+		for len(queue) > 0 {
+			apId := queue[0]
+			queue = queue[1:]
+
+			for listItem := range a.GetAccessProviderWhoList(ctx, apId, WithAccessProviderWhoListPageSize(options.pageSize), WithAccessProviderWhoListOrder(options.order...), WithAccessProviderWhoListProgress(options.progressFn)) {
+				if listItem.HasError() {
+					send(listItem)
+
+					return
+				}
+
+				item := listItem.GetItem()
+
+				if ref, ok := types.WhoItemAccessProviderRef(item); ok {
+					if _, found := visited[ref.Id]; !found {
+						visited[ref.Id] = struct{}{}
+						queue = append(queue, ref.Id)
+					}
+				}
+
+				key, ok := whoItemKey(item)
+				if ok {
+					if _, found := returned[key]; found {
+						continue
+					}
+
+					returned[key] = struct{}{}
+				}
+
+				if !options.filter.Matches(item) {
+					continue
+				}
+
+				if !send(types.NewListItemItem(item)) {
+					return
+				}
+			}
+		}
+	}()
+
+	return outputChannel
+}
+
+// whoItemKey returns a key identifying the user, group or AccessProvider of a who item.
+func whoItemKey(item *types.AccessProviderWhoListItem) (string, bool) {
+	switch whoItem := item.Item.(type) {
+	case *types.AccessProviderWhoListItemItemUser:
+		return types.WhoItemTypeUser + ":" + whoItem.Id, true
+	case *types.AccessProviderWhoListItemItemGroup:
+		return types.WhoItemTypeGroup + ":" + whoItem.Id, true
+	case *types.AccessProviderWhoListItemItemAccessProvider:
+		return types.WhoItemTypeAccessProvider + ":" + whoItem.Id, true
+	default:
+		return "", false
+	}
+}
+
+// GetAccessProviderWhoListGrouped returns the complete who list of the AccessProvider with the given id, partitioned into users, groups and AccessProviders.
+// The same options as GetAccessProviderWhoList can be used. The order of the items within each group is preserved.
+func (a *AccessProviderClient) GetAccessProviderWhoListGrouped(ctx context.Context, id string, ops ...func(*AccessProviderWhoListOptions)) (*types.WhoListGroups, error) {
+	groups := types.WhoListGroups{}
+
+	err := internal.ForEach(ctx, func(ctx context.Context) <-chan types.ListItem[types.AccessProviderWhoListItem] {
+		return a.GetAccessProviderWhoList(ctx, id, ops...)
+	}, func(item types.AccessProviderWhoListItem) error {
+		groups.Add(item)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &groups, nil
+}
+
+// GetAccessProviderWhoAccessProviderRefs returns the AccessProviders that are included in the who list of the AccessProvider with the given id.
+// Other who items, such as users and groups, are skipped. This can be used to traverse the inheritance tree of AccessProviders.
+func (a *AccessProviderClient) GetAccessProviderWhoAccessProviderRefs(ctx context.Context, id string) <-chan types.ListItem[types.AccessProviderWhoAccessProviderRef] {
+	filter := &types.AccessProviderWhoListFilter{ItemTypes: []string{types.WhoItemTypeAccessProvider}}
+
+	return internal.MapListItems(ctx, a.GetAccessProviderWhoList(ctx, id, WithAccessProviderWhoListFilter(filter)), types.WhoItemAccessProviderRef)
 }
 
 type AccessProviderWhatListOptions struct {
-	order  []types.AccessWhatOrderByInput
-	filter *types.AccessWhatFilterInput
+	order    []types.AccessWhatOrderByInput
+	filter   *types.AccessWhatFilterInput
+	pageSize int
 }
 
 // WithAccessProviderWhatListOrder can be used to specify the order of the returned AccessProviderWhatList
@@ -310,20 +1563,34 @@ func WithAccessProviderWhatListFilter(input *types.AccessWhatFilterInput) func(o
 	}
 }
 
+// WithAccessProviderWhatListPageSize can be used to specify the number of what items fetched per request.
+// The page size should be between 1 and 1000.
+func WithAccessProviderWhatListPageSize(pageSize int) func(options *AccessProviderWhatListOptions) {
+	return func(options *AccessProviderWhatListOptions) {
+		options.pageSize = pageSize
+	}
+}
+
 // GetAccessProviderWhatDataObjectList returns all what items of an AccessProvider in Raito Cloud.
+// Each item contains the data object together with the permissions and global permissions granted on it. These can be grouped per data object with types.WhatListPermissions.
 // The order of the list can be specified with WithAccessProviderWhatListOrder.
+// The page size can be specified with WithAccessProviderWhatListPageSize.
 // A channel is returned that can be used to receive the list of AccessProviderWhatDataObjectListItem.
 // To close the channel ensure to cancel the context.
 func (a *AccessProviderClient) GetAccessProviderWhatDataObjectList(ctx context.Context, id string, ops ...func(*AccessProviderWhatListOptions)) <-chan types.ListItem[types.AccessProviderWhatListItem] {
-	options := AccessProviderWhatListOptions{}
+	options := AccessProviderWhatListOptions{pageSize: internal.MaxPageSize}
 	for _, op := range ops {
 		op(&options)
 	}
 
+	if err := internal.ValidatePageSize(options.pageSize); err != nil {
+		return internal.ErrorChannel[types.AccessProviderWhatListItem](err)
+	}
+
 	loadPageFn := func(ctx context.Context, cursor *string) (*types.PageInfo, []types.AccessProviderWhatListEdgesEdge, error) {
-		output, err := schema.GetAccessProviderWhatDataObjectList(ctx, a.client, id, cursor, ptr.Int(internal.MaxPageSize), options.filter, options.order)
+		output, err := schema.GetAccessProviderWhatDataObjectList(ctx, a.client, id, cursor, ptr.Int(options.pageSize), options.filter, options.order)
 		if err != nil {
-			return nil, nil, types.NewErrClient(err)
+			return nil, nil, clientError(err)
 		}
 
 		switch ap := output.AccessProvider.(type) {
@@ -333,16 +1600,16 @@ func (a *AccessProviderClient) GetAccessProviderWhatDataObjectList(ctx context.C
 				return &whatList.PageInfo.PageInfo, whatList.Edges, nil
 			case *schema.GetAccessProviderWhatDataObjectListAccessProviderWhatDataObjectsPermissionDeniedError:
 				return nil, nil, types.NewErrPermissionDenied("accessProviderWhatDataObjectList", whatList.Message)
+			default:
+				return nil, nil, unexpectedResponse("GetAccessProviderWhatDataObjectList", whatList)
 			}
 		case *schema.GetAccessProviderWhatDataObjectListAccessProviderNotFoundError:
-			return nil, nil, types.NewErrNotFound(id, ap.Typename, ap.Message)
+			return nil, nil, types.NewErrResourceNotFound("accessProvider", "AccessProvider", id, ap.Typename, ap.Message)
 		case *schema.GetAccessProviderWhatDataObjectListAccessProviderPermissionDeniedError:
 			return nil, nil, types.NewErrPermissionDenied("accessProvider", ap.Message)
 		default:
-			return nil, nil, fmt.Errorf("unexpected type '%T': %w", ap, types.ErrUnknownType)
+			return nil, nil, unexpectedResponse("GetAccessProviderWhatDataObjectList", ap)
 		}
-
-		return nil, nil, errors.New("unreachable")
 	}
 
 	edgeFn := func(edge *types.AccessProviderWhatListEdgesEdge) (*string, *schema.AccessProviderWhatListItem, error) {
@@ -352,7 +1619,10 @@ func (a *AccessProviderClient) GetAccessProviderWhatDataObjectList(ctx context.C
 			return cursor, nil, nil
 		}
 
-		listItem := (*edge.Node).(*types.AccessProviderWhatListEdgesEdgeNodeAccessWhatItem)
+		listItem, err := internal.DecodeEdgeNode[*types.AccessProviderWhatListEdgesEdgeNodeAccessWhatItem](*edge.Node)
+		if err != nil {
+			return cursor, nil, err
+		}
 
 		return cursor, &listItem.AccessProviderWhatListItem, nil
 	}
@@ -360,6 +1630,169 @@ func (a *AccessProviderClient) GetAccessProviderWhatDataObjectList(ctx context.C
 	return internal.PaginationExecutor(ctx, loadPageFn, edgeFn)
 }
 
+// GetAccessProviderWhatColumnList returns the columns in the what list of an AccessProvider in Raito Cloud, e.g. to audit column level grants and masks.
+// Columns are returned as data objects of type column by the Raito API, so the what data object list is loaded and all other data objects are skipped.
+// The options of GetAccessProviderWhatDataObjectList can be used.
+// A channel is returned that can be used to receive the list of AccessProviderWhatColumnItem.
+// To close the channel ensure to cancel the context.
+func (a *AccessProviderClient) GetAccessProviderWhatColumnList(ctx context.Context, id string, ops ...func(*AccessProviderWhatListOptions)) <-chan types.ListItem[types.AccessProviderWhatColumnItem] {
+	return internal.MapListItems(ctx, a.GetAccessProviderWhatDataObjectList(ctx, id, ops...), types.WhatItemColumn)
+}
+
+// GetAccessProviderWhoWhatList returns every combination of a who item and a what data object item of an AccessProvider in Raito Cloud,
+// i.e. who has access to what through the AccessProvider.
+// The what data object list is loaded first and kept in memory, while the who list is streamed, so memory usage is bounded by the size of the what list.
+// Listing stops at the first error of either list, which is sent as the last element of the channel.
+// A channel is returned that can be used to receive the list of AccessProviderWhoWhatItem.
+// To close the channel ensure to cancel the context.
+func (a *AccessProviderClient) GetAccessProviderWhoWhatList(ctx context.Context, id string) <-chan types.ListItem[types.AccessProviderWhoWhatItem] {
+	outputChannel := make(chan types.ListItem[types.AccessProviderWhoWhatItem])
+
+	go func() {
+		defer close(outputChannel)
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		send := func(item types.ListItem[types.AccessProviderWhoWhatItem]) bool {
+			select {
+			case <-ctx.Done():
+				return false
+			case outputChannel <- item:
+				return true
+			}
+		}
+
+		whatItems, err := internal.CollectAll(ctx, func(ctx context.Context) <-chan types.ListItem[types.AccessProviderWhatListItem] {
+			return a.GetAccessProviderWhatDataObjectList(ctx, id)
+		})
+		if err != nil {
+			send(types.NewListItemError[types.AccessProviderWhoWhatItem](err))
+
+			return
+		}
+
+		for whoItem := range a.GetAccessProviderWhoList(ctx, id) {
+			if whoItem.HasError() {
+				send(types.NewListItemError[types.AccessProviderWhoWhatItem](whoItem.GetError()))
+
+				return
+			}
+
+			for i := range whatItems {
+				item := types.AccessProviderWhoWhatItem{Who: whoItem.GetItem(), What: &whatItems[i]}
+
+				if !send(types.NewListItemItem(&item)) {
+					return
+				}
+			}
+		}
+	}()
+
+	return outputChannel
+}
+
+const accessProviderHasWhatDataObjectOperation = `
+query AccessProviderHasWhatDataObject ($after: String, $limit: Int, $filter: AccessProviderFilterInput) {
+	accessProviders(after: $after, limit: $limit, filter: $filter) {
+		__typename
+		... on PagedResult {
+			pageInfo {
+				hasNextPage
+			}
+			edges {
+				cursor
+				node {
+					... on AccessProvider {
+						id
+					}
+				}
+			}
+		}
+		... on PermissionDeniedError {
+			message
+		}
+	}
+}
+`
+
+type accessProviderHasWhatDataObjectResponse struct {
+	AccessProviders struct {
+		Typename string `json:"__typename"`
+		Message  string `json:"message"`
+		PageInfo struct {
+			HasNextPage bool `json:"hasNextPage"`
+		} `json:"pageInfo"`
+		Edges []struct {
+			Cursor *string `json:"cursor"`
+			Node   *struct {
+				Id string `json:"id"`
+			} `json:"node"`
+		} `json:"edges"`
+	} `json:"accessProviders"`
+}
+
+// AccessProviderHasWhatDataObject returns whether the data object with id dataObjectId is in the what list of the AccessProvider with id apId.
+// The relationship is resolved by the Raito API, by requesting only the ids of the AccessProviders that have the data object in their what list.
+func (a *AccessProviderClient) AccessProviderHasWhatDataObject(ctx context.Context, apId, dataObjectId string) (bool, error) {
+	variables := struct {
+		After  *string                          `json:"after,omitempty"`
+		Limit  int                              `json:"limit"`
+		Filter *types.AccessProviderFilterInput `json:"filter"`
+	}{
+		Limit:  internal.MaxServerPageSize,
+		Filter: &types.AccessProviderFilterInput{DataObjectInWhat: &dataObjectId},
+	}
+
+	for {
+		pageVariables := variables
+
+		req := &graphql.Request{
+			OpName:    "AccessProviderHasWhatDataObject",
+			Query:     accessProviderHasWhatDataObjectOperation,
+			Variables: &pageVariables,
+		}
+
+		var result accessProviderHasWhatDataObjectResponse
+
+		err := a.client.MakeRequest(ctx, req, &graphql.Response{Data: &result})
+		if err != nil {
+			return false, clientError(err)
+		}
+
+		page := &result.AccessProviders
+
+		switch page.Typename {
+		case "PagedResult":
+		case "PermissionDeniedError":
+			return false, types.NewErrPermissionDenied("listAccessProviders", page.Message)
+		default:
+			return false, types.NewErrUnexpectedResponse("AccessProviderHasWhatDataObject", page.Typename)
+		}
+
+		cursor := variables.After
+
+		for _, edge := range page.Edges {
+			if edge.Node != nil && edge.Node.Id == apId {
+				return true, nil
+			}
+
+			if edge.Cursor != nil {
+				variables.After = edge.Cursor
+			}
+		}
+
+		if !page.PageInfo.HasNextPage {
+			return false, nil
+		}
+
+		// Requesting the next page without a new cursor would load the same page again
+		if variables.After == nil || (cursor != nil && *variables.After == *cursor) {
+			return false, types.NewErrUnexpectedResponse("AccessProviderHasWhatDataObject", page.Typename)
+		}
+	}
+}
+
 // AccessProviderWhatAccessProviderListOptions options for listing what access providers of an AccessProvider in Raito Cloud.
 type AccessProviderWhatAccessProviderListOptions struct {
 	order  []types.AccessWhatOrderByInput
@@ -390,7 +1823,7 @@ func (a *AccessProviderClient) GetAccessProviderWhatAccessProviderList(ctx conte
 	loadPageFn := func(ctx context.Context, cursor *string) (*types.PageInfo, []types.AccessProviderWhatAccessProviderListEdgesEdge, error) {
 		output, err := schema.GetAccessProviderWhatAccessProviders(ctx, a.client, id, cursor, ptr.Int(internal.MaxPageSize), nil, options.order, options.filter)
 		if err != nil {
-			return nil, nil, types.NewErrClient(err)
+			return nil, nil, clientError(err)
 		}
 
 		switch ap := output.AccessProvider.(type) {
@@ -401,14 +1834,14 @@ func (a *AccessProviderClient) GetAccessProviderWhatAccessProviderList(ctx conte
 			case *schema.GetAccessProviderWhatAccessProvidersAccessProviderWhatAccessProvidersPermissionDeniedError:
 				return nil, nil, types.NewErrPermissionDenied("accessProviderWhatAccessProviderList", whatList.Message)
 			default:
-				return nil, nil, fmt.Errorf("unexpected type '%T': %w", ap, types.ErrUnknownType)
+				return nil, nil, unexpectedResponse("GetAccessProviderWhatAccessProviders", ap)
 			}
 		case *schema.GetAccessProviderWhatAccessProvidersAccessProviderNotFoundError:
-			return nil, nil, types.NewErrNotFound(id, ap.Typename, ap.Message)
+			return nil, nil, types.NewErrResourceNotFound("accessProvider", "AccessProvider", id, ap.Typename, ap.Message)
 		case *schema.GetAccessProviderWhatAccessProvidersAccessProviderPermissionDeniedError:
 			return nil, nil, types.NewErrPermissionDenied("accessProvider", ap.Message)
 		default:
-			return nil, nil, fmt.Errorf("unexpected type '%T': %w", ap, types.ErrUnknownType)
+			return nil, nil, unexpectedResponse("GetAccessProviderWhatAccessProviders", ap)
 		}
 	}
 
@@ -419,7 +1852,10 @@ func (a *AccessProviderClient) GetAccessProviderWhatAccessProviderList(ctx conte
 			return cursor, nil, nil
 		}
 
-		listItem := (*edge.Node).(*types.AccessProviderWhatAccessProviderListEdgesEdgeNodeAccessWhatAccessProviderItem)
+		listItem, err := internal.DecodeEdgeNode[*types.AccessProviderWhatAccessProviderListEdgesEdgeNodeAccessWhatAccessProviderItem](*edge.Node)
+		if err != nil {
+			return cursor, nil, err
+		}
 
 		return cursor, &listItem.AccessWhatAccessProviderItem, nil
 	}
@@ -459,7 +1895,7 @@ func (a *AccessProviderClient) GetAccessProviderAbacWhatScope(ctx context.Contex
 	loadPageFn := func(ctx context.Context, cursor *string) (*types.PageInfo, []types.AccessProviderWhatAbacScopeListEdgesEdge, error) {
 		output, err := schema.ListAccessProviderAbacWhatScope(ctx, a.client, id, cursor, ptr.Int(internal.MaxPageSize), options.search, options.order)
 		if err != nil {
-			return nil, nil, types.NewErrClient(err)
+			return nil, nil, clientError(err)
 		}
 
 		switch ap := output.AccessProvider.(type) {
@@ -470,14 +1906,14 @@ func (a *AccessProviderClient) GetAccessProviderAbacWhatScope(ctx context.Contex
 			case *schema.ListAccessProviderAbacWhatScopeAccessProviderWhatAbacScopePermissionDeniedError:
 				return nil, nil, types.NewErrPermissionDenied("accessProviderWhatAbacScopeList", whatList.Message)
 			default:
-				return nil, nil, fmt.Errorf("unexpected type '%T': %w", whatList, types.ErrUnknownType)
+				return nil, nil, unexpectedResponse("ListAccessProviderAbacWhatScope", whatList)
 			}
 		case *schema.ListAccessProviderAbacWhatScopeAccessProviderPermissionDeniedError:
 			return nil, nil, types.NewErrPermissionDenied("accessProvider", ap.Message)
 		case *schema.ListAccessProviderAbacWhatScopeAccessProviderNotFoundError:
-			return nil, nil, types.NewErrNotFound(id, ap.Typename, ap.Message)
+			return nil, nil, types.NewErrResourceNotFound("accessProvider", "AccessProvider", id, ap.Typename, ap.Message)
 		default:
-			return nil, nil, fmt.Errorf("unexpected type '%T': %w", ap, types.ErrUnknownType)
+			return nil, nil, unexpectedResponse("ListAccessProviderAbacWhatScope", ap)
 		}
 	}
 
@@ -488,7 +1924,10 @@ func (a *AccessProviderClient) GetAccessProviderAbacWhatScope(ctx context.Contex
 			return cursor, nil, nil
 		}
 
-		listItem := (*edge.Node).(*types.AccessProviderWhatAbacScopeListEdgesEdgeNodeDataObject)
+		listItem, err := internal.DecodeEdgeNode[*types.AccessProviderWhatAbacScopeListEdgesEdgeNodeDataObject](*edge.Node)
+		if err != nil {
+			return cursor, nil, err
+		}
 
 		return cursor, &listItem.DataObject, nil
 	}
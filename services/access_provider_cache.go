@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/raito-io/sdk-go/types"
+)
+
+type bypassAccessProviderCacheKey struct{}
+
+// WithoutAccessProviderCache returns a context that makes a CachedAccessProviderClient fetch the AccessProvider from Raito Cloud, bypassing the cache.
+// The fetched AccessProvider is still stored in the cache.
+func WithoutAccessProviderCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassAccessProviderCacheKey{}, true)
+}
+
+type cachedAccessProvider struct {
+	accessProvider *types.AccessProvider
+	expiresAt      time.Time
+}
+
+// CachedAccessProviderClient is an AccessProviderService that caches the results of GetAccessProvider by id for a limited time.
+// The cached AccessProvider is invalidated when it is updated, activated, deactivated or deleted through the CachedAccessProviderClient.
+// Changes made in another way, e.g. by another client, are only visible once the cached AccessProvider expires.
+// All other methods are passed to the wrapped AccessProviderService. A CachedAccessProviderClient is safe for concurrent use.
+type CachedAccessProviderClient struct {
+	AccessProviderService
+
+	ttl time.Duration
+	now func() time.Time
+
+	mutex      sync.Mutex
+	cache      map[string]cachedAccessProvider
+	generation uint64
+}
+
+var _ AccessProviderService = (*CachedAccessProviderClient)(nil)
+
+// NewCachedAccessProviderClient wraps inner in a CachedAccessProviderClient, caching AccessProviders for ttl.
+func NewCachedAccessProviderClient(inner AccessProviderService, ttl time.Duration) *CachedAccessProviderClient {
+	return &CachedAccessProviderClient{
+		AccessProviderService: inner,
+		ttl:                   ttl,
+		now:                   time.Now,
+		cache:                 map[string]cachedAccessProvider{},
+	}
+}
+
+// GetAccessProvider returns the cached AccessProvider with the given id, if it has not expired.
+// Otherwise, the AccessProvider is fetched from Raito Cloud and stored in the cache. Errors are not cached.
+// The cache can be bypassed for a single call with WithoutAccessProviderCache.
+func (c *CachedAccessProviderClient) GetAccessProvider(ctx context.Context, id string) (*types.AccessProvider, error) {
+	c.mutex.Lock()
+	entry, found := c.cache[id]
+	generation := c.generation
+	c.mutex.Unlock()
+
+	if bypass, _ := ctx.Value(bypassAccessProviderCacheKey{}).(bool); !bypass && found && c.now().Before(entry.expiresAt) {
+		return types.CloneAccessProvider(entry.accessProvider), nil
+	}
+
+	ap, err := c.AccessProviderService.GetAccessProvider(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Do not store the AccessProvider if it was modified while it was fetched
+	if c.generation == generation {
+		c.cache[id] = cachedAccessProvider{accessProvider: types.CloneAccessProvider(ap), expiresAt: c.now().Add(c.ttl)}
+	}
+
+	return ap, nil
+}
+
+// InvalidateAccessProvider removes the AccessProvider with the given id from the cache.
+func (c *CachedAccessProviderClient) InvalidateAccessProvider(id string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.generation++
+	delete(c.cache, id)
+}
+
+func (c *CachedAccessProviderClient) invalidateAll() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.generation++
+	c.cache = map[string]cachedAccessProvider{}
+}
+
+// UpdateAccessProvider updates the AccessProvider and invalidates its cached version.
+func (c *CachedAccessProviderClient) UpdateAccessProvider(ctx context.Context, id string, ap types.AccessProviderInput, ops ...func(options *UpdateAccessProviderOptions)) (*types.AccessProvider, error) {
+	defer c.InvalidateAccessProvider(id)
+
+	return c.AccessProviderService.UpdateAccessProvider(ctx, id, ap, ops...)
+}
+
+// PatchAccessProvider patches the AccessProvider and invalidates its cached version.
+func (c *CachedAccessProviderClient) PatchAccessProvider(ctx context.Context, id string, patch types.AccessProviderPatch, ops ...func(options *UpdateAccessProviderOptions)) (*types.AccessProvider, error) {
+	defer c.InvalidateAccessProvider(id)
+
+	return c.AccessProviderService.PatchAccessProvider(ctx, id, patch, ops...)
+}
+
+// SetAccessProviderWhoList replaces the who list of the AccessProvider and invalidates its cached version.
+func (c *CachedAccessProviderClient) SetAccessProviderWhoList(ctx context.Context, id string, items []types.WhoItemInput, ops ...func(options *UpdateAccessProviderOptions)) error {
+	defer c.InvalidateAccessProvider(id)
+
+	return c.AccessProviderService.SetAccessProviderWhoList(ctx, id, items, ops...)
+}
+
+// DeleteAccessProvider deletes the AccessProvider and invalidates its cached version.
+func (c *CachedAccessProviderClient) DeleteAccessProvider(ctx context.Context, id string, ops ...func(options *UpdateAccessProviderOptions)) error {
+	defer c.InvalidateAccessProvider(id)
+
+	return c.AccessProviderService.DeleteAccessProvider(ctx, id, ops...)
+}
+
+// DeleteAccessProviders deletes the AccessProviders and invalidates their cached versions.
+func (c *CachedAccessProviderClient) DeleteAccessProviders(ctx context.Context, ids []string, ops ...func(options *AccessProviderBatchOptions)) ([]types.AccessProviderDeleteResult, error) {
+	defer func() {
+		for _, id := range ids {
+			c.InvalidateAccessProvider(id)
+		}
+	}()
+
+	return c.AccessProviderService.DeleteAccessProviders(ctx, ids, ops...)
+}
+
+// ActivateAccessProvider activates the AccessProvider and invalidates its cached version.
+func (c *CachedAccessProviderClient) ActivateAccessProvider(ctx context.Context, id string) (*types.AccessProvider, error) {
+	defer c.InvalidateAccessProvider(id)
+
+	return c.AccessProviderService.ActivateAccessProvider(ctx, id)
+}
+
+// DeactivateAccessProvider deactivates the AccessProvider and invalidates its cached version.
+func (c *CachedAccessProviderClient) DeactivateAccessProvider(ctx context.Context, id string) (*types.AccessProvider, error) {
+	defer c.InvalidateAccessProvider(id)
+
+	return c.AccessProviderService.DeactivateAccessProvider(ctx, id)
+}
+
+// ImportAccessProviders imports the AccessProviders and invalidates all cached AccessProviders.
+func (c *CachedAccessProviderClient) ImportAccessProviders(ctx context.Context, r io.Reader, ops ...func(options *AccessProviderImportOptions)) ([]types.AccessProviderImportResult, error) {
+	defer c.invalidateAll()
+
+	return c.AccessProviderService.ImportAccessProviders(ctx, r, ops...)
+}
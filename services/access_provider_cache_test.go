@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/types"
+)
+
+func TestCachedAccessProviderClient(t *testing.T) {
+	const apResponse = `{"accessProvider": {"__typename": "AccessProvider", "id": "ap-1", "name": "ap 1"}}`
+
+	newClient := func(responses ...string) (*CachedAccessProviderClient, *mockGraphqlClient, *time.Time) {
+		mockClient := &mockGraphqlClient{responses: responses}
+		inner := NewAccessProviderClient(mockClient)
+
+		now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		client := NewCachedAccessProviderClient(&inner, time.Minute)
+		client.now = func() time.Time { return now }
+
+		return client, mockClient, &now
+	}
+
+	t.Run("Cached until expired", func(t *testing.T) {
+		client, mockClient, now := newClient(apResponse, apResponse)
+
+		for range 3 {
+			ap, err := client.GetAccessProvider(context.Background(), "ap-1")
+
+			require.NoError(t, err)
+			assert.Equal(t, "ap 1", ap.Name)
+
+			ap.Name = "modified by caller"
+		}
+
+		assert.Len(t, mockClient.requests, 1)
+
+		*now = now.Add(time.Minute)
+
+		_, err := client.GetAccessProvider(context.Background(), "ap-1")
+
+		require.NoError(t, err)
+		assert.Len(t, mockClient.requests, 2)
+	})
+
+	t.Run("Nested fields are not shared", func(t *testing.T) {
+		client, mockClient, _ := newClient(`{"accessProvider": {"__typename": "AccessProvider", "id": "ap-1", "name": "ap 1", "policyRule": "rule", "locks": [{"lockKey": "WhoLock", "details": {"reason": "managed"}}]}}`)
+
+		ap, err := client.GetAccessProvider(context.Background(), "ap-1")
+		require.NoError(t, err)
+
+		*ap.PolicyRule = "modified by caller"
+		ap.Locks[0].LockKey = "DeleteLock"
+		ap.Locks = append(ap.Locks, ap.Locks[0])
+
+		ap, err = client.GetAccessProvider(context.Background(), "ap-1")
+		require.NoError(t, err)
+
+		*ap.PolicyRule = "modified by another caller"
+		ap.Locks[0].LockKey = "NameLock"
+
+		ap, err = client.GetAccessProvider(context.Background(), "ap-1")
+		require.NoError(t, err)
+
+		assert.Equal(t, "rule", *ap.PolicyRule)
+		require.Len(t, ap.Locks, 1)
+		assert.Equal(t, "WhoLock", string(ap.Locks[0].LockKey))
+		assert.Len(t, mockClient.requests, 1)
+	})
+
+	t.Run("Bypass", func(t *testing.T) {
+		client, mockClient, _ := newClient(apResponse, apResponse)
+
+		_, err := client.GetAccessProvider(context.Background(), "ap-1")
+		require.NoError(t, err)
+
+		_, err = client.GetAccessProvider(WithoutAccessProviderCache(context.Background()), "ap-1")
+		require.NoError(t, err)
+
+		assert.Len(t, mockClient.requests, 2)
+	})
+
+	t.Run("Invalidated on delete", func(t *testing.T) {
+		client, mockClient, _ := newClient(apResponse, `{"deleteAccessProvider": {"__typename": "AccessProvider", "id": "ap-1"}}`, `{"accessProvider": {"__typename": "NotFoundError", "message": "not found"}}`)
+
+		_, err := client.GetAccessProvider(context.Background(), "ap-1")
+		require.NoError(t, err)
+
+		require.NoError(t, client.DeleteAccessProvider(context.Background(), "ap-1"))
+
+		_, err = client.GetAccessProvider(context.Background(), "ap-1")
+
+		assert.ErrorIs(t, err, &types.ErrNotFound{Id: "ap-1", Operation: "getAccessProvider", Resource: "AccessProvider"})
+		assert.Len(t, mockClient.requests, 3)
+	})
+}
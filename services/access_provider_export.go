@@ -0,0 +1,237 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/aws/smithy-go/ptr"
+
+	"github.com/raito-io/sdk-go/internal"
+	"github.com/raito-io/sdk-go/types"
+)
+
+// ExportAccessProviders writes all AccessProviders in Raito Cloud to w in the JSON lines format, i.e. one types.AccessProviderExport per line.
+// The same options as ListAccessProviders can be used, e.g. to export only the AccessProviders matching a filter.
+// AccessProviders are written one by one, including their who and what lists, so memory usage is bounded by the size of a single AccessProvider.
+// If w implements Flush() error, such as a bufio.Writer, it is flushed after each AccessProvider.
+// Exporting stops at the first error. The AccessProviders written until then remain in w.
+func (a *AccessProviderClient) ExportAccessProviders(ctx context.Context, w io.Writer, ops ...func(*AccessProviderListOptions)) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	encoder := json.NewEncoder(w)
+	flusher, _ := w.(interface{ Flush() error })
+
+	for listItem := range a.ListAccessProviders(ctx, ops...) {
+		if listItem.HasError() {
+			return listItem.GetError()
+		}
+
+		export, err := a.exportAccessProvider(ctx, listItem.GetItem())
+		if err != nil {
+			return err
+		}
+
+		if err := encoder.Encode(export); err != nil {
+			return fmt.Errorf("write access provider %q: %w", export.AccessProvider.Id, err)
+		}
+
+		if flusher != nil {
+			if err := flusher.Flush(); err != nil {
+				return fmt.Errorf("flush access provider %q: %w", export.AccessProvider.Id, err)
+			}
+		}
+	}
+
+	return ctx.Err()
+}
+
+func (a *AccessProviderClient) exportAccessProvider(ctx context.Context, ap *types.AccessProvider) (*types.AccessProviderExport, error) {
+	whoList, err := internal.CollectAll(ctx, func(ctx context.Context) <-chan types.ListItem[types.AccessProviderWhoListItem] {
+		return a.GetAccessProviderWhoList(ctx, ap.Id)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("export who list of access provider %q: %w", ap.Id, err)
+	}
+
+	whatDataObjects, err := internal.CollectAll(ctx, func(ctx context.Context) <-chan types.ListItem[types.AccessProviderWhatListItem] {
+		return a.GetAccessProviderWhatDataObjectList(ctx, ap.Id)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("export what data objects of access provider %q: %w", ap.Id, err)
+	}
+
+	whatAccessProviders, err := internal.CollectAll(ctx, func(ctx context.Context) <-chan types.ListItem[types.AccessWhatAccessProviderItem] {
+		return a.GetAccessProviderWhatAccessProviderList(ctx, ap.Id)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("export what access providers of access provider %q: %w", ap.Id, err)
+	}
+
+	return &types.AccessProviderExport{
+		AccessProvider:      ap,
+		WhoList:             whoList,
+		WhatDataObjects:     whatDataObjects,
+		WhatAccessProviders: whatAccessProviders,
+	}, nil
+}
+
+// AccessProviderImportOptions options for importing AccessProviders with ImportAccessProviders.
+type AccessProviderImportOptions struct {
+	concurrency   int
+	createMissing bool
+	idMapping     map[string]string
+}
+
+// WithAccessProviderImportConcurrency can be used to specify the maximum number of AccessProviders that are imported concurrently.
+func WithAccessProviderImportConcurrency(concurrency int) func(options *AccessProviderImportOptions) {
+	return func(options *AccessProviderImportOptions) {
+		options.concurrency = concurrency
+	}
+}
+
+// WithAccessProviderImportCreateMissing can be used to create the AccessProviders of which the id does not exist in Raito Cloud.
+// Raito Cloud assigns a new id to a created AccessProvider, so importing the same export again creates it again,
+// unless the ids of the results are passed to the next import with WithAccessProviderImportIdMapping.
+func WithAccessProviderImportCreateMissing() func(options *AccessProviderImportOptions) {
+	return func(options *AccessProviderImportOptions) {
+		options.createMissing = true
+	}
+}
+
+// WithAccessProviderImportIdMapping can be used to map the AccessProvider ids in the export to the ids in Raito Cloud.
+// The mapping is applied to the imported AccessProviders and to the AccessProviders they refer to in their who and what lists.
+// Ids that are not in the mapping are used as is. types.AccessProviderImportIdMapping returns the mapping of a previous import.
+func WithAccessProviderImportIdMapping(mapping map[string]string) func(options *AccessProviderImportOptions) {
+	return func(options *AccessProviderImportOptions) {
+		options.idMapping = mapping
+	}
+}
+
+// ImportAccessProviders reads AccessProviders from r in the JSON lines format written by ExportAccessProviders and updates them in Raito Cloud.
+// As AccessProviders have no external id, they are matched on the id in the export, after applying WithAccessProviderImportIdMapping.
+// If no AccessProvider exists with that id, the Err of the line is a types.ErrNotFound, unless WithAccessProviderImportCreateMissing is used.
+// References to users, groups and data objects are imported as is, so they should exist with the same ids.
+// A result is returned for each non-empty line, in the same order as the lines. Failing lines do not stop the import of the other lines.
+// The maximum number of concurrent imports can be specified with WithAccessProviderImportConcurrency.
+// Once ctx is done, no new imports are started and the Err of each line that was not attempted is a types.ErrNotAttempted.
+// An error is only returned if r cannot be read, for transport-level problems or if the import was aborted.
+func (a *AccessProviderClient) ImportAccessProviders(ctx context.Context, r io.Reader, ops ...func(options *AccessProviderImportOptions)) ([]types.AccessProviderImportResult, error) {
+	options := AccessProviderImportOptions{concurrency: internal.DefaultBatchConcurrency}
+	for _, op := range ops {
+		op(&options)
+	}
+
+	if options.concurrency < 1 {
+		return nil, types.NewErrInvalidInput(fmt.Sprintf("import concurrency should be at least 1, got %d", options.concurrency))
+	}
+
+	records, err := readAccessProviderExports(r)
+	if err != nil {
+		return nil, err
+	}
+
+	results, errs := internal.BatchExecutor(ctx, records, options.concurrency, func(ctx context.Context, record accessProviderImportRecord) (types.AccessProviderImportResult, error) {
+		return a.importAccessProvider(ctx, record, &options)
+	})
+
+	for i := range results {
+		results[i].Line = records[i].line
+		results[i].Err = errs[i]
+	}
+
+	return results, batchError(errs)
+}
+
+// accessProviderImportRecord is a single non-empty line of an import.
+type accessProviderImportRecord struct {
+	line   int
+	export *types.AccessProviderExport
+	err    error
+}
+
+func readAccessProviderExports(r io.Reader) ([]accessProviderImportRecord, error) {
+	var records []accessProviderImportRecord
+
+	reader := bufio.NewReader(r)
+
+	for lineNr := 1; ; lineNr++ {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("read line %d: %w", lineNr, err)
+		}
+
+		if trimmed := bytes.TrimSpace(line); len(trimmed) > 0 {
+			record := accessProviderImportRecord{line: lineNr, export: &types.AccessProviderExport{}}
+
+			if decodeErr := json.Unmarshal(trimmed, record.export); decodeErr != nil {
+				record.err = types.NewErrInvalidInput(fmt.Sprintf("line %d is not a valid access provider export: %s", lineNr, decodeErr.Error()))
+			}
+
+			records = append(records, record)
+		}
+
+		if err != nil {
+			return records, nil
+		}
+	}
+}
+
+func (a *AccessProviderClient) importAccessProvider(ctx context.Context, record accessProviderImportRecord, options *AccessProviderImportOptions) (types.AccessProviderImportResult, error) {
+	result := types.AccessProviderImportResult{Line: record.line}
+
+	if record.err != nil {
+		return result, record.err
+	}
+
+	if record.export.AccessProvider != nil {
+		result.Id = record.export.AccessProvider.Id
+	}
+
+	input, err := types.AccessProviderExportInput(record.export)
+	if err != nil {
+		return result, err
+	}
+
+	mapId := func(id string) string {
+		if mapped, found := options.idMapping[id]; found {
+			return mapped
+		}
+
+		return id
+	}
+
+	for i := range input.WhoItems {
+		if input.WhoItems[i].AccessProvider != nil {
+			input.WhoItems[i].AccessProvider = ptr.String(mapId(*input.WhoItems[i].AccessProvider))
+		}
+	}
+
+	for i := range input.WhatAccessProviders {
+		input.WhatAccessProviders[i].AccessProvider = mapId(input.WhatAccessProviders[i].AccessProvider)
+	}
+
+	id := mapId(result.Id)
+
+	exists, err := a.AccessProviderExists(ctx, id)
+	if err != nil {
+		return result, err
+	}
+
+	switch {
+	case exists:
+		result.AccessProvider, err = a.UpdateAccessProvider(ctx, id, *input)
+	case options.createMissing:
+		result.AccessProvider, err = a.CreateAccessProvider(ctx, *input)
+		result.Created = err == nil
+	default:
+		err = types.NewErrResourceNotFound("importAccessProviders", "AccessProvider", id, nil, "access provider does not exist and WithAccessProviderImportCreateMissing is not used")
+	}
+
+	return result, err
+}
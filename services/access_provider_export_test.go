@@ -0,0 +1,154 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/types"
+)
+
+func TestAccessProviderClient_ExportAccessProviders(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{responses: []string{
+			`{"accessProviders": {"__typename": "PagedResult", "pageInfo": {"hasNextPage": false}, "edges": [
+				{"cursor": "1", "node": {"__typename": "AccessProvider", "id": "ap1", "name": "ap 1", "state": "Active", "action": "Grant"}}
+			]}}`,
+			whoListPage2,
+			whatListPage,
+			`{"accessProvider": {"__typename": "AccessProvider", "whatAccessProviders": {"__typename": "PagedResult", "pageInfo": {"hasNextPage": false}, "edges": [
+				{"cursor": "1", "node": {"__typename": "AccessWhatAccessProviderItem", "accessProvider": {"id": "ap2", "name": "ap 2", "state": "Active", "action": "Grant"}}}
+			]}}}`,
+		}}
+		client := NewAccessProviderClient(mockClient)
+
+		var buffer bytes.Buffer
+		writer := bufio.NewWriter(&buffer)
+
+		err := client.ExportAccessProviders(context.Background(), writer)
+		require.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+		require.Len(t, lines, 1)
+
+		var export types.AccessProviderExport
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &export))
+
+		assert.Equal(t, "ap1", export.AccessProvider.Id)
+		require.Len(t, export.WhoList, 2)
+		assert.Equal(t, "ap1", export.WhoList[1].Item.(*types.AccessProviderWhoListItemItemAccessProvider).Id)
+		require.Len(t, export.WhatDataObjects, 1)
+		assert.Equal(t, "do1", export.WhatDataObjects[0].DataObject.Id)
+		require.Len(t, export.WhatAccessProviders, 1)
+		assert.Equal(t, "ap2", export.WhatAccessProviders[0].AccessProvider.Id)
+	})
+
+	t.Run("Who list error", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{responses: []string{
+			accessProviderListPage,
+			`{"accessProvider": {"__typename": "PermissionDeniedError", "message": "denied"}}`,
+		}}
+		client := NewAccessProviderClient(mockClient)
+
+		var buffer bytes.Buffer
+
+		err := client.ExportAccessProviders(context.Background(), &buffer)
+
+		assert.ErrorIs(t, err, &types.ErrPermissionDenied{})
+		assert.Empty(t, buffer.String())
+	})
+}
+
+func TestAccessProviderClient_ImportAccessProviders(t *testing.T) {
+	input := strings.Join([]string{
+		`{"accessProvider": {"id": "ap1", "name": "ap 1", "state": "Active", "action": "Grant", "whoType": "Static", "whatType": "Static"}, "whoList": [{"type": "WhoGrant", "item": {"__typename": "User", "id": "u1", "name": "user 1"}}]}`,
+		`not json`,
+		``,
+		`{"accessProvider": {"id": "ap9", "name": "ap 9", "state": "Active", "action": "Grant", "whoType": "Static", "whatType": "Static"}, "whoList": [{"type": "WhoGrant", "item": {"__typename": "AccessProvider", "id": "ap9", "name": "ap 9"}}]}`,
+	}, "\n")
+
+	const (
+		ap1Exists  = `{"accessProvider": {"__typename": "AccessProvider", "id": "ap1"}}`
+		ap1Updated = `{"updateAccessProvider": {"__typename": "AccessProvider", "id": "ap1", "name": "ap 1", "state": "Active", "action": "Grant"}}`
+		notFound   = `{"accessProvider": {"__typename": "NotFoundError", "message": "not found"}}`
+	)
+
+	t.Run("Missing AccessProviders are not created", func(t *testing.T) {
+		for range 2 {
+			mockClient := &mockGraphqlClient{responses: []string{ap1Exists, ap1Updated, notFound}}
+			client := NewAccessProviderClient(mockClient)
+
+			results, err := client.ImportAccessProviders(context.Background(), strings.NewReader(input), WithAccessProviderImportConcurrency(1))
+
+			require.NoError(t, err)
+			require.Len(t, results, 3)
+
+			assert.Equal(t, 1, results[0].Line)
+			assert.Equal(t, "ap1", results[0].Id)
+			assert.NoError(t, results[0].Err)
+			assert.False(t, results[0].Created)
+			assert.Equal(t, "ap1", results[0].AccessProvider.Id)
+
+			assert.Equal(t, 2, results[1].Line)
+			assert.ErrorIs(t, results[1].Err, &types.ErrInvalidInput{})
+
+			assert.Equal(t, 4, results[2].Line)
+			assert.Equal(t, "ap9", results[2].Id)
+			assert.ErrorIs(t, results[2].Err, &types.ErrNotFound{Id: "ap9", Resource: "AccessProvider"})
+			assert.Nil(t, results[2].AccessProvider)
+
+			require.Len(t, mockClient.requests, 3)
+			assert.Equal(t, "UpdateAccessProvider", mockClient.requests[1].OpName)
+			assert.Equal(t, []interface{}{map[string]interface{}{"user": "u1", "type": "WhoGrant"}}, mockClient.variables(t, 1)["ap"].(map[string]interface{})["whoItems"])
+		}
+	})
+
+	t.Run("Imported twice with create missing", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{responses: []string{
+			ap1Exists, ap1Updated, notFound,
+			`{"createAccessProvider": {"__typename": "AccessProvider", "id": "ap10", "name": "ap 9", "state": "Active", "action": "Grant"}}`,
+		}}
+		client := NewAccessProviderClient(mockClient)
+
+		results, err := client.ImportAccessProviders(context.Background(), strings.NewReader(input), WithAccessProviderImportConcurrency(1), WithAccessProviderImportCreateMissing())
+
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		assert.NoError(t, results[2].Err)
+		assert.True(t, results[2].Created)
+		assert.Equal(t, "ap10", results[2].AccessProvider.Id)
+		require.Len(t, mockClient.requests, 4)
+		assert.Equal(t, "CreateAccessProvider", mockClient.requests[3].OpName)
+
+		mapping := types.AccessProviderImportIdMapping(results)
+		assert.Equal(t, map[string]string{"ap1": "ap1", "ap9": "ap10"}, mapping)
+
+		mockClient = &mockGraphqlClient{responses: []string{
+			ap1Exists, ap1Updated,
+			`{"accessProvider": {"__typename": "AccessProvider", "id": "ap10"}}`,
+			`{"updateAccessProvider": {"__typename": "AccessProvider", "id": "ap10", "name": "ap 9", "state": "Active", "action": "Grant"}}`,
+		}}
+		client = NewAccessProviderClient(mockClient)
+
+		results, err = client.ImportAccessProviders(context.Background(), strings.NewReader(input), WithAccessProviderImportConcurrency(1), WithAccessProviderImportCreateMissing(), WithAccessProviderImportIdMapping(mapping))
+
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		assert.NoError(t, results[2].Err)
+		assert.False(t, results[2].Created)
+		assert.Equal(t, "ap10", results[2].AccessProvider.Id)
+
+		// The second import updates the created AccessProvider, including the reference to itself
+		require.Len(t, mockClient.requests, 4)
+		assert.Equal(t, "ap10", mockClient.variables(t, 2)["id"])
+		assert.Equal(t, "UpdateAccessProvider", mockClient.requests[3].OpName)
+		assert.Equal(t, "ap10", mockClient.variables(t, 3)["id"])
+		assert.Equal(t, []interface{}{map[string]interface{}{"accessProvider": "ap10", "type": "WhoGrant"}}, mockClient.variables(t, 3)["ap"].(map[string]interface{})["whoItems"])
+	})
+}
@@ -0,0 +1,1067 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/aws/smithy-go/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/types"
+	"github.com/raito-io/sdk-go/types/models"
+)
+
+// mockGraphqlClient returns the given JSON responses in order and records all requests.
+type mockGraphqlClient struct {
+	responses []string
+	requests  []*graphql.Request
+}
+
+func (c *mockGraphqlClient) MakeRequest(_ context.Context, req *graphql.Request, resp *graphql.Response) error {
+	c.requests = append(c.requests, req)
+
+	return json.Unmarshal([]byte(c.responses[len(c.requests)-1]), resp.Data)
+}
+
+// variables returns the variables of the i-th request as a generic JSON map.
+func (c *mockGraphqlClient) variables(t *testing.T, i int) map[string]interface{} {
+	data, err := json.Marshal(c.requests[i].Variables)
+	require.NoError(t, err)
+
+	var variables map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &variables))
+
+	return variables
+}
+
+func collectItems[T any](t *testing.T, channel <-chan types.ListItem[T]) []T {
+	var items []T
+
+	for listItem := range channel {
+		require.NoError(t, listItem.GetError())
+
+		items = append(items, listItem.MustGetItem())
+	}
+
+	return items
+}
+
+const whoListPage1 = `{"accessProvider": {"__typename": "AccessProvider", "whoList": {"__typename": "PagedResult", "pageInfo": {"hasNextPage": true}, "edges": [
+	{"cursor": "1", "node": {"__typename": "AccessWhoItem", "type": "WhoGrant", "item": {"__typename": "User", "id": "u1", "name": "user 1"}}},
+	{"cursor": "2", "node": {"__typename": "AccessWhoItem", "type": "WhoGrant", "item": {"__typename": "Group", "id": "g1", "name": "group 1", "identityStore": {"id": "is1", "name": "is 1"}}}}
+]}}}`
+
+const whoListPage2 = `{"accessProvider": {"__typename": "AccessProvider", "whoList": {"__typename": "PagedResult", "pageInfo": {"hasNextPage": false}, "edges": [
+	{"cursor": "3", "node": {"__typename": "AccessWhoItem", "type": "WhoGrant", "item": {"__typename": "Group", "id": "g2", "name": "group 2", "identityStore": {"id": "is2", "name": "is 2"}}}},
+	{"cursor": "4", "node": {"__typename": "AccessWhoItem", "type": "WhoGrant", "item": {"__typename": "AccessProvider", "id": "ap1", "name": "ap 1"}}}
+]}}}`
+
+func TestAccessProviderClient_GetAccessProviderWhoList_Filter(t *testing.T) {
+	mockClient := &mockGraphqlClient{responses: []string{whoListPage1, whoListPage2}}
+	client := NewAccessProviderClient(mockClient)
+
+	search := "group"
+	items := collectItems(t, client.GetAccessProviderWhoList(context.Background(), "ap-id", WithAccessProviderWhoListFilter(&types.AccessProviderWhoListFilter{
+		Search:    &search,
+		ItemTypes: []string{types.WhoItemTypeGroup},
+	})))
+
+	require.Len(t, items, 2)
+	assert.Equal(t, "g1", items[0].Item.(*types.AccessProviderWhoListItemItemGroup).Id)
+	assert.Equal(t, "g2", items[1].Item.(*types.AccessProviderWhoListItemItemGroup).Id)
+
+	require.Len(t, mockClient.requests, 2)
+	assert.Equal(t, "group", mockClient.variables(t, 0)["search"])
+	assert.Nil(t, mockClient.variables(t, 0)["after"])
+	assert.Equal(t, "group", mockClient.variables(t, 1)["search"])
+	assert.Equal(t, "2", mockClient.variables(t, 1)["after"])
+}
+
+func TestAccessProviderClient_GetAccessProviderWhoList_IdentityStore(t *testing.T) {
+	t.Run("Combined with filter", func(t *testing.T) {
+		client := NewAccessProviderClient(&mockGraphqlClient{responses: []string{whoListPage1, whoListPage2}})
+
+		items := collectItems(t, client.GetAccessProviderWhoList(context.Background(), "ap-id", WithAccessProviderWhoListIdentityStore("is2"), WithAccessProviderWhoListFilter(&types.AccessProviderWhoListFilter{
+			ItemTypes: []string{types.WhoItemTypeGroup},
+		})))
+
+		require.Len(t, items, 1)
+		assert.Equal(t, "g2", items[0].Item.(*types.AccessProviderWhoListItemItemGroup).Id)
+	})
+
+	t.Run("Conflicting filter", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{}
+		client := NewAccessProviderClient(mockClient)
+
+		identityStore := "is1"
+
+		for item := range client.GetAccessProviderWhoList(context.Background(), "ap-id", WithAccessProviderWhoListIdentityStore("is2"), WithAccessProviderWhoListFilter(&types.AccessProviderWhoListFilter{
+			IdentityStoreId: &identityStore,
+		})) {
+			assert.ErrorIs(t, item.GetError(), &types.ErrInvalidInput{})
+		}
+
+		assert.Empty(t, mockClient.requests)
+	})
+}
+
+func TestAccessProviderClient_GetAccessProviderWhoList_Dedup(t *testing.T) {
+	// g1 shifted to the second page while paging
+	page2 := `{"accessProvider": {"__typename": "AccessProvider", "whoList": {"__typename": "PagedResult", "pageInfo": {"hasNextPage": false}, "edges": [
+		{"cursor": "3", "node": {"__typename": "AccessWhoItem", "type": "WhoGrant", "item": {"__typename": "Group", "id": "g1", "name": "group 1", "identityStore": {"id": "is1", "name": "is 1"}}}},
+		{"cursor": "4", "node": {"__typename": "AccessWhoItem", "type": "WhoGrant", "item": {"__typename": "User", "id": "u2", "name": "user 2"}}}
+	]}}}`
+
+	client := NewAccessProviderClient(&mockGraphqlClient{responses: []string{whoListPage1, page2}})
+
+	items := collectItems(t, client.GetAccessProviderWhoList(context.Background(), "ap-id", WithAccessProviderWhoListDedup()))
+
+	require.Len(t, items, 3)
+	assert.Equal(t, "u1", items[0].Item.(*types.AccessProviderWhoListItemItemUser).Id)
+	assert.Equal(t, "g1", items[1].Item.(*types.AccessProviderWhoListItemItemGroup).Id)
+	assert.Equal(t, "u2", items[2].Item.(*types.AccessProviderWhoListItemItemUser).Id)
+}
+
+func TestAccessProviderClient_GetAccessProviderWhoList_Expanded(t *testing.T) {
+	rootPage := `{"accessProvider": {"__typename": "AccessProvider", "whoList": {"__typename": "PagedResult", "pageInfo": {"hasNextPage": false}, "edges": [
+		{"cursor": "1", "node": {"__typename": "AccessWhoItem", "type": "WhoGrant", "item": {"__typename": "User", "id": "u1", "name": "user 1"}}},
+		{"cursor": "2", "node": {"__typename": "AccessWhoItem", "type": "WhoGrant", "item": {"__typename": "AccessProvider", "id": "ap1", "name": "ap 1"}}}
+	]}}}`
+	// ap1 refers back to the root AccessProvider
+	ap1Page := `{"accessProvider": {"__typename": "AccessProvider", "whoList": {"__typename": "PagedResult", "pageInfo": {"hasNextPage": false}, "edges": [
+		{"cursor": "1", "node": {"__typename": "AccessWhoItem", "type": "WhoGrant", "item": {"__typename": "User", "id": "u1", "name": "user 1"}}},
+		{"cursor": "2", "node": {"__typename": "AccessWhoItem", "type": "WhoGrant", "item": {"__typename": "Group", "id": "g1", "name": "group 1", "identityStore": {"id": "is1", "name": "is 1"}}}},
+		{"cursor": "3", "node": {"__typename": "AccessWhoItem", "type": "WhoGrant", "item": {"__typename": "AccessProvider", "id": "ap-id", "name": "root"}}}
+	]}}}`
+
+	t.Run("cycle", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{responses: []string{rootPage, ap1Page}}
+		client := NewAccessProviderClient(mockClient)
+
+		items := collectItems(t, client.GetAccessProviderWhoList(context.Background(), "ap-id", WithAccessProviderWhoListExpanded(), WithAccessProviderWhoListFilter(&types.AccessProviderWhoListFilter{
+			ItemTypes: []string{types.WhoItemTypeUser, types.WhoItemTypeGroup},
+		})))
+
+		require.Len(t, items, 2)
+		assert.Equal(t, "u1", items[0].Item.(*types.AccessProviderWhoListItemItemUser).Id)
+		assert.Equal(t, "g1", items[1].Item.(*types.AccessProviderWhoListItemItemGroup).Id)
+
+		require.Len(t, mockClient.requests, 2)
+		assert.Equal(t, "ap-id", mockClient.variables(t, 0)["id"])
+		assert.Equal(t, "ap1", mockClient.variables(t, 1)["id"])
+	})
+
+	t.Run("search", func(t *testing.T) {
+		client := NewAccessProviderClient(&mockGraphqlClient{})
+
+		search := "user"
+		listItem := <-client.GetAccessProviderWhoList(context.Background(), "ap-id", WithAccessProviderWhoListExpanded(), WithAccessProviderWhoListFilter(&types.AccessProviderWhoListFilter{Search: &search}))
+
+		assert.ErrorIs(t, listItem.GetError(), &types.ErrInvalidInput{})
+	})
+}
+
+func TestAccessProviderClient_GetAccessProviderWhoListGrouped(t *testing.T) {
+	client := NewAccessProviderClient(&mockGraphqlClient{responses: []string{whoListPage1, whoListPage2}})
+
+	groups, err := client.GetAccessProviderWhoListGrouped(context.Background(), "ap-id")
+
+	require.NoError(t, err)
+	require.Len(t, groups.Users, 1)
+	assert.Equal(t, "u1", groups.Users[0].Item.(*types.AccessProviderWhoListItemItemUser).Id)
+	require.Len(t, groups.Groups, 2)
+	assert.Equal(t, "g1", groups.Groups[0].Item.(*types.AccessProviderWhoListItemItemGroup).Id)
+	assert.Equal(t, "g2", groups.Groups[1].Item.(*types.AccessProviderWhoListItemItemGroup).Id)
+	require.Len(t, groups.AccessProviders, 1)
+	assert.Equal(t, "ap1", groups.AccessProviders[0].Item.(*types.AccessProviderWhoListItemItemAccessProvider).Id)
+}
+
+func TestAccessProviderClient_GetAccessProviderWhoList_Progress(t *testing.T) {
+	client := NewAccessProviderClient(&mockGraphqlClient{responses: []string{whoListPage1, whoListPage2}})
+
+	var hasNextPage []bool
+
+	items := collectItems(t, client.GetAccessProviderWhoList(context.Background(), "ap-id", WithAccessProviderWhoListProgress(func(pageInfo types.PageInfo) {
+		hasNextPage = append(hasNextPage, *pageInfo.HasNextPage)
+	})))
+
+	assert.Len(t, items, 4)
+	assert.Equal(t, []bool{true, false}, hasNextPage)
+}
+
+func TestAccessProviderClient_CountAccessProviderWhoItems(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{responses: []string{whoListPage1, whoListPage2}}
+		client := NewAccessProviderClient(mockClient)
+
+		count, err := client.CountAccessProviderWhoItems(context.Background(), "ap-id")
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, count)
+		assert.Equal(t, float64(1000), mockClient.variables(t, 0)["limit"])
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		client := NewAccessProviderClient(&mockGraphqlClient{responses: []string{`{"accessProvider": {"__typename": "NotFoundError", "message": "not found"}}`}})
+
+		_, err := client.CountAccessProviderWhoItems(context.Background(), "ap-id")
+
+		assert.ErrorIs(t, err, &types.ErrNotFound{})
+	})
+}
+
+func TestAccessProviderClient_GetAccessProviderWhoAccessProviderRefs(t *testing.T) {
+	mockClient := &mockGraphqlClient{responses: []string{whoListPage1, whoListPage2}}
+	client := NewAccessProviderClient(mockClient)
+
+	var refs []types.AccessProviderWhoAccessProviderRef
+	var cursors []string
+
+	for item := range client.GetAccessProviderWhoAccessProviderRefs(context.Background(), "ap-id") {
+		require.NoError(t, item.GetError())
+
+		refs = append(refs, item.MustGetItem())
+		cursors = append(cursors, *item.GetCursor())
+	}
+
+	assert.Equal(t, []types.AccessProviderWhoAccessProviderRef{{Id: "ap1", Name: "ap 1", Type: "WhoGrant"}}, refs)
+	assert.Equal(t, []string{"4"}, cursors)
+	assert.Len(t, mockClient.requests, 2)
+}
+
+const whatListPage = `{"accessProvider": {"__typename": "AccessProvider", "whatDataObjects": {"__typename": "PagedResult", "pageInfo": {"hasNextPage": false}, "edges": [
+	{"cursor": "1", "node": {"__typename": "AccessWhatItem", "permissions": ["SELECT"], "globalPermissions": [], "dataObject": {"id": "do1", "name": "table1", "fullName": "db.schema.table1", "type": "table"}}}
+]}}}`
+
+func TestAccessProviderClient_GetAccessProviderWhatDataObjectList_Filter(t *testing.T) {
+	mockClient := &mockGraphqlClient{responses: []string{whatListPage}}
+	client := NewAccessProviderClient(mockClient)
+
+	search := "db.schema"
+	items := collectItems(t, client.GetAccessProviderWhatDataObjectList(context.Background(), "ap-id", WithAccessProviderWhatListFilter(&types.AccessWhatFilterInput{
+		Search: &search,
+	})))
+
+	require.Len(t, items, 1)
+	assert.Equal(t, "db.schema.table1", items[0].DataObject.FullName)
+
+	require.Len(t, mockClient.requests, 1)
+	assert.Equal(t, "GetAccessProviderWhatDataObjectList", mockClient.requests[0].OpName)
+	assert.Equal(t, map[string]interface{}{"search": "db.schema", "owners": nil, "hasTags": nil}, mockClient.variables(t, 0)["filter"])
+}
+
+const accessProviderListPage = `{"accessProviders": {"__typename": "PagedResult", "pageInfo": {"hasNextPage": false}, "edges": [
+	{"cursor": "1", "node": {"__typename": "AccessProvider", "id": "ap2", "name": "ap 2"}},
+	{"cursor": "2", "node": {"__typename": "AccessProvider", "id": "ap1", "name": "ap 1"}}
+]}}`
+
+func TestAccessProviderClient_ListAccessProviders_Reverse(t *testing.T) {
+	t.Run("Default order", testListAccessProvidersReverseDefaultOrder)
+	t.Run("Specified order", testListAccessProvidersReverseSpecifiedOrder)
+}
+
+func testListAccessProvidersReverseDefaultOrder(t *testing.T) {
+	mockClient := &mockGraphqlClient{responses: []string{accessProviderListPage}}
+	client := NewAccessProviderClient(mockClient)
+
+	items := collectItems(t, client.ListAccessProviders(context.Background(), WithAccessProviderListReverse()))
+
+	require.Len(t, items, 2)
+	assert.Equal(t, "ap2", items[0].Id)
+	assert.Equal(t, "ap1", items[1].Id)
+
+	require.Len(t, mockClient.requests, 1)
+	assert.Equal(t, []interface{}{map[string]interface{}{"createdAt": "desc"}}, mockClient.variables(t, 0)["order"])
+}
+
+func testListAccessProvidersReverseSpecifiedOrder(t *testing.T) {
+	mockClient := &mockGraphqlClient{responses: []string{accessProviderListPage}}
+	client := NewAccessProviderClient(mockClient)
+
+	collectItems(t, client.ListAccessProviders(context.Background(),
+		WithAccessProviderListOrder(types.OrderByNameAsc(), types.OrderByModifiedDesc()),
+		WithAccessProviderListReverse(),
+	))
+
+	require.Len(t, mockClient.requests, 1)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "desc"},
+		map[string]interface{}{"modifiedAt": "asc"},
+	}, mockClient.variables(t, 0)["order"])
+}
+
+func TestAccessProviderClient_ListAccessProviders_StartCursor(t *testing.T) {
+	mockClient := &mockGraphqlClient{responses: []string{accessProviderListPage}}
+	client := NewAccessProviderClient(mockClient)
+
+	var cursors []string
+
+	for listItem := range client.ListAccessProviders(context.Background(), WithAccessProviderListStartCursor("checkpoint")) {
+		require.NoError(t, listItem.GetError())
+
+		cursors = append(cursors, *listItem.GetCursor())
+	}
+
+	assert.Equal(t, []string{"1", "2"}, cursors)
+
+	require.Len(t, mockClient.requests, 1)
+	assert.Equal(t, "checkpoint", mockClient.variables(t, 0)["after"])
+}
+
+func TestAccessProviderClient_ListAccessProviders_AdaptivePageSize(t *testing.T) {
+	firstPage := strings.Replace(accessProviderListPage, `"hasNextPage": false`, `"hasNextPage": true`, 1)
+
+	optionOrders := [][]func(*AccessProviderListOptions){
+		{WithAccessProviderListAdaptivePageSize(2, 5)},
+		{WithAccessProviderListAdaptivePageSize(2, 5), WithAccessProviderListPageSize(100)},
+		{WithAccessProviderListPageSize(100), WithAccessProviderListAdaptivePageSize(2, 5)},
+	}
+
+	for _, ops := range optionOrders {
+		mockClient := &mockGraphqlClient{responses: []string{firstPage, firstPage, accessProviderListPage}}
+		client := NewAccessProviderClient(mockClient)
+
+		items := collectItems(t, client.ListAccessProviders(context.Background(), ops...))
+
+		assert.Len(t, items, 6)
+
+		require.Len(t, mockClient.requests, 3)
+		assert.Equal(t, float64(2), mockClient.variables(t, 0)["limit"])
+		assert.Equal(t, float64(4), mockClient.variables(t, 1)["limit"])
+		assert.Equal(t, float64(5), mockClient.variables(t, 2)["limit"])
+	}
+}
+
+func TestAccessProviderClient_ListAccessProviders_ModifiedSince(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Stops at first older AccessProvider", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{responses: []string{
+			`{"accessProviders": {"__typename": "PagedResult", "pageInfo": {"hasNextPage": true}, "edges": [
+				{"cursor": "1", "node": {"__typename": "AccessProvider", "id": "ap1", "modifiedAt": "2024-01-03T00:00:00Z"}},
+				{"cursor": "2", "node": {"__typename": "AccessProvider", "id": "ap2", "modifiedAt": "2024-01-02T00:00:00Z"}}
+			]}}`,
+			`{"accessProviders": {"__typename": "PagedResult", "pageInfo": {"hasNextPage": true}, "edges": [
+				{"cursor": "3", "node": {"__typename": "AccessProvider", "id": "ap3", "modifiedAt": "2024-01-01T00:00:00Z"}},
+				{"cursor": "4", "node": {"__typename": "AccessProvider", "id": "ap4", "modifiedAt": "2023-12-31T00:00:00Z"}}
+			]}}`,
+		}}
+		client := NewAccessProviderClient(mockClient)
+
+		items := collectItems(t, client.ListAccessProviders(context.Background(), WithAccessProviderListModifiedSince(since)))
+
+		require.Len(t, items, 3)
+		assert.Equal(t, "ap3", items[2].Id)
+
+		require.Len(t, mockClient.requests, 2)
+		assert.Equal(t, []interface{}{map[string]interface{}{"modifiedAt": "desc"}}, mockClient.variables(t, 0)["order"])
+	})
+
+	t.Run("Custom order", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{}
+		client := NewAccessProviderClient(mockClient)
+
+		for listItem := range client.ListAccessProviders(context.Background(), WithAccessProviderListModifiedSince(since), WithAccessProviderListOrder(types.OrderByNameAsc())) {
+			assert.ErrorIs(t, listItem.GetError(), &types.ErrInvalidInput{})
+		}
+
+		assert.Empty(t, mockClient.requests)
+	})
+}
+
+// flakyGraphqlClient fails the request with the given index with err, e.g. to simulate a connection reset, and passes all other requests to client.
+type flakyGraphqlClient struct {
+	client   graphql.Client
+	failAt   int
+	err      error
+	requests []*graphql.Request
+}
+
+func (c *flakyGraphqlClient) MakeRequest(ctx context.Context, req *graphql.Request, resp *graphql.Response) error {
+	c.requests = append(c.requests, req)
+
+	if len(c.requests)-1 == c.failAt {
+		return c.err
+	}
+
+	return c.client.MakeRequest(ctx, req, resp)
+}
+
+func TestAccessProviderClient_ListAccessProviders_ConnectionReset(t *testing.T) {
+	firstPage := strings.Replace(accessProviderListPage, `"hasNextPage": false`, `"hasNextPage": true`, 1)
+	secondPage := strings.NewReplacer(`"1"`, `"3"`, `"2"`, `"4"`, "ap1", "ap3", "ap2", "ap4").Replace(accessProviderListPage)
+
+	t.Run("Page is retried", func(t *testing.T) {
+		for _, err := range []error{syscall.ECONNRESET, io.EOF} {
+			flakyClient := &flakyGraphqlClient{client: &mockGraphqlClient{responses: []string{firstPage, secondPage}}, failAt: 1, err: fmt.Errorf("reading response: %w", err)}
+			client := NewAccessProviderClient(flakyClient, WithRetry(3, time.Millisecond))
+
+			items := collectItems(t, client.ListAccessProviders(context.Background()))
+
+			ids := make([]string, 0, len(items))
+			for i := range items {
+				ids = append(ids, items[i].Id)
+			}
+
+			assert.Equal(t, []string{"ap2", "ap1", "ap4", "ap3"}, ids)
+
+			// The failed page is requested again after the last received cursor
+			require.Len(t, flakyClient.requests, 3)
+			assert.Equal(t, flakyClient.requests[1].Variables, flakyClient.requests[2].Variables)
+		}
+	})
+
+	t.Run("Without retry", func(t *testing.T) {
+		flakyClient := &flakyGraphqlClient{client: &mockGraphqlClient{responses: []string{firstPage, secondPage}}, failAt: 1, err: syscall.ECONNRESET}
+		client := NewAccessProviderClient(flakyClient)
+
+		var err error
+
+		for listItem := range client.ListAccessProviders(context.Background()) {
+			err = listItem.GetError()
+		}
+
+		assert.ErrorIs(t, err, syscall.ECONNRESET)
+		assert.Len(t, flakyClient.requests, 2)
+	})
+}
+
+func TestAccessProviderClient_ListAccessProviders_MultipleFilters(t *testing.T) {
+	t.Run("Combined with AND", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{responses: []string{accessProviderListPage}}
+		client := NewAccessProviderClient(mockClient)
+
+		// The values of a single field are combined with OR, the filters are combined with AND
+		first := &types.AccessProviderFilterInput{Search: ptr.String("sales"), States: []models.AccessProviderState{models.AccessProviderStateActive, models.AccessProviderStateInactive}, Exclude: []string{"ap-1"}}
+		second := &types.AccessProviderFilterInput{States: []models.AccessProviderState{models.AccessProviderStateActive}, Exclude: []string{"ap-2"}}
+
+		items := collectItems(t, client.ListAccessProviders(context.Background(), WithAccessProviderListFilter(first), WithAccessProviderListFilter(second), WithAccessProviderListDataSource("ds1")))
+
+		assert.Len(t, items, 2)
+		assert.Len(t, first.States, 2)
+
+		require.Len(t, mockClient.requests, 1)
+		requestFilter := mockClient.variables(t, 0)["filter"].(map[string]interface{})
+		assert.Equal(t, "sales", requestFilter["search"])
+		assert.Equal(t, "ds1", requestFilter["dataSource"])
+		assert.Equal(t, []interface{}{"Active"}, requestFilter["states"])
+		assert.Equal(t, []interface{}{"ap-1", "ap-2"}, requestFilter["exclude"])
+	})
+
+	t.Run("Never matches", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{}
+		client := NewAccessProviderClient(mockClient)
+
+		for listItem := range client.ListAccessProviders(context.Background(), WithAccessProviderListFilter(&types.AccessProviderFilterInput{Search: ptr.String("sales")}), WithAccessProviderListFilter(&types.AccessProviderFilterInput{Search: ptr.String("finance")})) {
+			assert.ErrorIs(t, listItem.GetError(), &types.ErrInvalidInput{})
+		}
+
+		assert.Empty(t, mockClient.requests)
+	})
+}
+
+func TestAccessProviderClient_ListAccessProviders_DataSource(t *testing.T) {
+	t.Run("Combined with filter", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{responses: []string{accessProviderListPage}}
+		client := NewAccessProviderClient(mockClient)
+
+		filter := &types.AccessProviderFilterInput{Search: ptr.String("sales")}
+
+		items := collectItems(t, client.ListAccessProviders(context.Background(), WithAccessProviderListDataSource("ds1"), WithAccessProviderListFilter(filter)))
+
+		assert.Len(t, items, 2)
+		assert.Nil(t, filter.DataSource)
+
+		require.Len(t, mockClient.requests, 1)
+		requestFilter := mockClient.variables(t, 0)["filter"].(map[string]interface{})
+		assert.Equal(t, "ds1", requestFilter["dataSource"])
+		assert.Equal(t, "sales", requestFilter["search"])
+	})
+
+	t.Run("Conflicting filter", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{}
+		client := NewAccessProviderClient(mockClient)
+
+		filter := &types.AccessProviderFilterInput{DataSource: ptr.String("ds2")}
+
+		for listItem := range client.ListAccessProviders(context.Background(), WithAccessProviderListFilter(filter), WithAccessProviderListDataSource("ds1")) {
+			assert.ErrorIs(t, listItem.GetError(), &types.ErrInvalidInput{})
+		}
+
+		assert.Empty(t, mockClient.requests)
+	})
+}
+
+func TestAccessProviderClient_ListAccessProviders_IncludeDeleted(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter *types.AccessProviderFilterInput
+		states []interface{}
+	}{
+		{name: "No filter", states: []interface{}{"Active", "Inactive", "Deleted"}},
+		{name: "Filter on states", filter: &types.AccessProviderFilterInput{States: []models.AccessProviderState{models.AccessProviderStateInactive}}, states: []interface{}{"Inactive", "Deleted"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockGraphqlClient{responses: []string{accessProviderListPage}}
+			client := NewAccessProviderClient(mockClient)
+
+			collectItems(t, client.ListAccessProviders(context.Background(), WithAccessProviderListIncludeDeleted(), WithAccessProviderListFilter(tt.filter)))
+
+			require.Len(t, mockClient.requests, 1)
+			assert.Equal(t, tt.states, mockClient.variables(t, 0)["filter"].(map[string]interface{})["states"])
+		})
+	}
+}
+
+func TestAccessProviderClient_CreateAccessProvider_ClientSideValidation(t *testing.T) {
+	mockClient := &mockGraphqlClient{}
+	client := NewAccessProviderClient(mockClient)
+
+	_, err := client.CreateAccessProvider(context.Background(), types.AccessProviderInput{}, WithAccessProviderCreateClientSideValidation())
+
+	assert.ErrorIs(t, err, &types.ErrInvalidInput{})
+	assert.Empty(t, mockClient.requests)
+}
+
+// partialResponseClient returns a response containing both data and errors.
+// If reportErrors is set, the errors are returned in the same way as the genqlient client.
+type partialResponseClient struct {
+	reportErrors bool
+}
+
+func (c partialResponseClient) MakeRequest(_ context.Context, _ *graphql.Request, resp *graphql.Response) error {
+	err := json.Unmarshal([]byte(`{
+		"data": {"createAccessProvider": {"__typename": "AccessProvider", "id": "ap-1", "name": "ap 1"}},
+		"errors": [{"message": "could not resolve whoList", "path": ["createAccessProvider", "whoList"]}]
+	}`), resp)
+	if err != nil {
+		return err
+	}
+
+	if c.reportErrors {
+		return resp.Errors
+	}
+
+	return nil
+}
+
+func TestAccessProviderClient_CreateAccessProvider_PartialResponse(t *testing.T) {
+	for _, reportErrors := range []bool{true, false} {
+		client := NewAccessProviderClient(partialResponseClient{reportErrors: reportErrors})
+
+		ap, err := client.CreateAccessProvider(context.Background(), types.AccessProviderInput{Name: ptr.String("ap 1")})
+
+		assert.ErrorIs(t, err, &types.ErrValidation{})
+		assert.Nil(t, ap)
+	}
+}
+
+// operationGraphqlClient returns the given JSON responses per operation in order. It can be used for concurrent requests.
+type operationGraphqlClient struct {
+	mutex     sync.Mutex
+	responses map[string][]string
+}
+
+func (c *operationGraphqlClient) MakeRequest(_ context.Context, req *graphql.Request, resp *graphql.Response) error {
+	c.mutex.Lock()
+	response := c.responses[req.OpName][0]
+	c.responses[req.OpName] = c.responses[req.OpName][1:]
+	c.mutex.Unlock()
+
+	return json.Unmarshal([]byte(response), resp.Data)
+}
+
+func TestAccessProviderClient_GetAccessProviderWithWhoWhat(t *testing.T) {
+	const apResponse = `{"accessProvider": {"__typename": "AccessProvider", "id": "ap-id", "name": "ap"}}`
+
+	t.Run("Small lists", func(t *testing.T) {
+		client := NewAccessProviderClient(&operationGraphqlClient{responses: map[string][]string{
+			"GetAccessProvider":                   {apResponse},
+			"GetAccessProviderWhoList":            {whoListPage2},
+			"GetAccessProviderWhatDataObjectList": {whatListPage},
+		}})
+
+		result, err := client.GetAccessProviderWithWhoWhat(context.Background(), "ap-id")
+
+		require.NoError(t, err)
+		assert.Equal(t, "ap-id", result.AccessProvider.Id)
+		assert.Len(t, result.WhoList, 2)
+		require.Len(t, result.WhatList, 1)
+		assert.Equal(t, "do1", result.WhatList[0].DataObject.Id)
+	})
+
+	t.Run("Too many items", func(t *testing.T) {
+		edges := make([]string, 0, types.AccessProviderWithWhoWhatLimit)
+		for i := range types.AccessProviderWithWhoWhatLimit {
+			edges = append(edges, fmt.Sprintf(`{"cursor": "%[1]d", "node": {"__typename": "AccessWhoItem", "type": "WhoGrant", "item": {"__typename": "User", "id": "u%[1]d", "name": "user"}}}`, i))
+		}
+
+		fullPage := `{"accessProvider": {"__typename": "AccessProvider", "whoList": {"__typename": "PagedResult", "pageInfo": {"hasNextPage": true}, "edges": [` + strings.Join(edges, ",") + `]}}}`
+
+		client := NewAccessProviderClient(&operationGraphqlClient{responses: map[string][]string{
+			"GetAccessProvider":                   {apResponse},
+			"GetAccessProviderWhoList":            {fullPage, whoListPage2},
+			"GetAccessProviderWhatDataObjectList": {whatListPage},
+		}})
+
+		result, err := client.GetAccessProviderWithWhoWhat(context.Background(), "ap-id")
+
+		assert.ErrorIs(t, err, &types.ErrTooManyItems{})
+		assert.Nil(t, result)
+	})
+}
+
+// cancelingGraphqlClient cancels a context after each request.
+type cancelingGraphqlClient struct {
+	client graphql.Client
+	cancel context.CancelFunc
+}
+
+func (c *cancelingGraphqlClient) MakeRequest(ctx context.Context, req *graphql.Request, resp *graphql.Response) error {
+	defer c.cancel()
+
+	return c.client.MakeRequest(ctx, req, resp)
+}
+
+func TestAccessProviderClient_DeleteAccessProviders_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The orchestrator cancels the job while the first AccessProvider is deleted
+	mockClient := &mockGraphqlClient{responses: []string{`{"deleteAccessProvider": {"__typename": "AccessProvider", "id": "ap1"}}`}}
+	client := NewAccessProviderClient(&cancelingGraphqlClient{client: mockClient, cancel: cancel})
+
+	results, err := client.DeleteAccessProviders(ctx, []string{"ap1", "ap2", "ap3"}, WithAccessProviderBatchConcurrency(1))
+
+	assert.ErrorIs(t, err, &types.ErrNotAttempted{})
+	require.Len(t, results, 3)
+	assert.NoError(t, results[0].Err)
+	assert.ErrorIs(t, results[1].Err, &types.ErrNotAttempted{})
+	assert.ErrorIs(t, results[2].Err, &types.ErrNotAttempted{})
+	assert.Equal(t, "ap3", results[2].Id)
+	assert.Len(t, mockClient.requests, 1)
+}
+
+func TestAccessProviderClient_GetAccessProviderSummary(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{responses: []string{`{"accessProvider": {"__typename": "AccessProvider", "id": "ap-1", "name": "ap 1", "state": "Active", "action": "Grant", "createdAt": "2024-01-02T03:04:05Z", "modifiedAt": "2024-01-02T03:04:05Z"}}`}}
+		client := NewAccessProviderClient(mockClient)
+
+		summary, err := client.GetAccessProviderSummary(context.Background(), "ap-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "ap-1", summary.Id)
+		assert.Equal(t, "ap 1", summary.Name)
+		assert.Equal(t, models.AccessProviderStateActive, summary.State)
+		assert.Equal(t, models.AccessProviderActionGrant, summary.Action)
+		assert.Equal(t, "ap-1", mockClient.variables(t, 0)["id"])
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{responses: []string{`{"accessProvider": {"__typename": "NotFoundError", "message": "not found"}}`}}
+		client := NewAccessProviderClient(mockClient)
+
+		_, err := client.GetAccessProviderSummary(context.Background(), "ap-1")
+
+		assert.ErrorIs(t, err, &types.ErrNotFound{Id: "ap-1", Operation: "getAccessProvider", Resource: "AccessProvider"})
+	})
+}
+
+func TestAccessProviderClient_AccessProviderExists(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		exists   bool
+		err      error
+	}{
+		{name: "Exists", response: `{"accessProvider": {"__typename": "AccessProvider", "id": "ap-1"}}`, exists: true},
+		{name: "Not found", response: `{"accessProvider": {"__typename": "NotFoundError", "message": "not found"}}`, exists: false},
+		{name: "Permission denied", response: `{"accessProvider": {"__typename": "PermissionDeniedError", "message": "denied"}}`, err: &types.ErrPermissionDenied{}},
+		{name: "Unexpected response", response: `{"accessProvider": {"__typename": "UnknownError", "message": "unknown"}}`, err: &types.ErrUnexpectedResponse{Operation: "AccessProviderExists"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewAccessProviderClient(&mockGraphqlClient{responses: []string{tt.response}})
+
+			exists, err := client.AccessProviderExists(context.Background(), "ap-1")
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.exists, exists)
+		})
+	}
+}
+
+func TestAccessProviderClient_GetAccessProviderSyncStatus(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{responses: []string{`{"accessProvider": {"__typename": "AccessProvider", "id": "ap-1", "state": "Active", "syncData": [
+			{"dataSource": {"id": "ds-1"}, "syncStatus": "SYNCED"},
+			{"dataSource": {"id": "ds-2"}, "syncStatus": "IN_PROGRESS"}
+		]}}`}}
+		client := NewAccessProviderClient(mockClient)
+
+		status, err := client.GetAccessProviderSyncStatus(context.Background(), "ap-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "GetAccessProviderSyncStatus", mockClient.requests[0].OpName)
+		assert.Equal(t, &types.AccessProviderSyncStatus{
+			Id:          "ap-1",
+			State:       models.AccessProviderStateActive,
+			DataSources: map[string]types.SyncStatus{"ds-1": types.SyncStatusSynced, "ds-2": types.SyncStatusInProgress},
+		}, status)
+		assert.False(t, status.IsSynced())
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		client := NewAccessProviderClient(&mockGraphqlClient{responses: []string{`{"accessProvider": {"__typename": "NotFoundError", "message": "not found"}}`}})
+
+		_, err := client.GetAccessProviderSyncStatus(context.Background(), "ap-1")
+
+		assert.ErrorIs(t, err, &types.ErrNotFound{})
+	})
+
+	t.Run("Permission denied", func(t *testing.T) {
+		client := NewAccessProviderClient(&mockGraphqlClient{responses: []string{`{"accessProvider": {"__typename": "PermissionDeniedError", "message": "denied"}}`}})
+
+		_, err := client.GetAccessProviderSyncStatus(context.Background(), "ap-1")
+
+		assert.ErrorIs(t, err, &types.ErrPermissionDenied{})
+	})
+}
+
+func TestAccessProviderClient_GetAccessProviderWhoWhatList(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{responses: []string{whatListPage, whoListPage1, whoListPage2}}
+		client := NewAccessProviderClient(mockClient)
+
+		items := collectItems(t, client.GetAccessProviderWhoWhatList(context.Background(), "ap-id"))
+
+		require.Len(t, items, 4)
+
+		for _, item := range items {
+			assert.Equal(t, "do1", item.What.DataObject.Id)
+		}
+
+		assert.Equal(t, "u1", items[0].Who.Item.(*types.AccessProviderWhoListItemItemUser).Id)
+		assert.Equal(t, "ap1", items[3].Who.Item.(*types.AccessProviderWhoListItemItemAccessProvider).Id)
+	})
+
+	t.Run("What list error", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{responses: []string{`{"accessProvider": {"__typename": "PermissionDeniedError", "message": "denied"}}`}}
+		client := NewAccessProviderClient(mockClient)
+
+		var errs []error
+
+		for item := range client.GetAccessProviderWhoWhatList(context.Background(), "ap-id") {
+			require.True(t, item.HasError())
+
+			errs = append(errs, item.GetError())
+		}
+
+		require.Len(t, errs, 1)
+		assert.ErrorIs(t, errs[0], &types.ErrPermissionDenied{})
+		assert.Len(t, mockClient.requests, 1)
+	})
+}
+
+func TestAccessProviderClient_UpdateAccessProvider_RefuseLocked(t *testing.T) {
+	mockClient := &mockGraphqlClient{responses: []string{`{"accessProvider": {"__typename": "AccessProvider", "id": "ap-1", "state": "Active", "action": "Grant", "locks": [{"lockKey": "WhoLock", "details": {"reason": "managed by terraform"}}]}}`}}
+	client := NewAccessProviderClient(mockClient)
+
+	_, err := client.UpdateAccessProvider(context.Background(), "ap-1", types.AccessProviderInput{}, WithAccessProviderUpdateRefuseLocked())
+
+	assert.ErrorIs(t, err, &types.ErrLocked{Id: "ap-1"})
+	assert.Equal(t, []types.AccessProviderLock{types.AccessProviderLockWholock}, err.(*types.ErrLocked).Locks)
+	assert.Len(t, mockClient.requests, 1)
+}
+
+func TestAccessProviderClient_SetAccessProviderWhoList(t *testing.T) {
+	mockClient := &mockGraphqlClient{responses: []string{
+		`{"updateAccessProvider": {"__typename": "AccessProvider", "id": "ap-1", "state": "Active", "action": "Grant"}}`,
+		`{"updateAccessProvider": {"__typename": "AccessProvider", "id": "ap-1", "state": "Active", "action": "Grant"}}`,
+		`{"updateAccessProvider": {"__typename": "NotFoundError", "message": "not found"}}`,
+	}}
+	client := NewAccessProviderClient(mockClient)
+
+	err := client.SetAccessProviderWhoList(context.Background(), "ap-1", []types.WhoItemInput{types.NewUserWhoItem("u1").Build()})
+	require.NoError(t, err)
+
+	err = client.SetAccessProviderWhoList(context.Background(), "ap-1", nil)
+	require.NoError(t, err)
+
+	err = client.SetAccessProviderWhoList(context.Background(), "ap-2", nil)
+	assert.ErrorIs(t, err, &types.ErrNotFound{Id: "ap-2"})
+
+	assert.Equal(t, map[string]interface{}{"whoItems": []interface{}{map[string]interface{}{"user": "u1"}}}, mockClient.variables(t, 0)["ap"])
+	assert.Equal(t, map[string]interface{}{"whoItems": []interface{}{}}, mockClient.variables(t, 1)["ap"])
+}
+
+func TestAccessProviderClient_DeactivateAccessProvider(t *testing.T) {
+	mockClient := &mockGraphqlClient{responses: []string{
+		`{"deactivateAccessProvider": {"__typename": "AccessProvider", "id": "ap-1", "state": "Inactive", "action": "Grant"}}`,
+		`{"deactivateAccessProvider": {"__typename": "NotFoundError", "message": "not found"}}`,
+	}}
+	client := NewAccessProviderClient(mockClient)
+
+	ap, err := client.DeactivateAccessProvider(context.Background(), "ap-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, models.AccessProviderStateInactive, ap.State)
+	assert.Equal(t, "DeactivateAccessProvider", mockClient.requests[0].OpName)
+
+	_, err = client.DeactivateAccessProvider(context.Background(), "ap-2")
+
+	assert.ErrorIs(t, err, &types.ErrNotFound{Id: "ap-2"})
+}
+
+func TestAccessProviderClient_GetAccessProviders(t *testing.T) {
+	mockClient := &mockGraphqlClient{responses: []string{
+		`{"accessProvider": {"__typename": "AccessProvider", "id": "ap-1", "state": "Active", "action": "Grant"}}`,
+		`{"accessProvider": {"__typename": "NotFoundError", "message": "not found"}}`,
+	}}
+	client := NewAccessProviderClient(mockClient)
+
+	results, err := client.GetAccessProviders(context.Background(), []string{"ap-1", "ap-2", "ap-1"}, WithAccessProviderBatchConcurrency(1))
+
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	assert.NoError(t, results["ap-1"].Err)
+	assert.Equal(t, "ap-1", results["ap-1"].AccessProvider.Id)
+
+	assert.Nil(t, results["ap-2"].AccessProvider)
+	assert.ErrorIs(t, results["ap-2"].Err, &types.ErrNotFound{Id: "ap-2"})
+
+	assert.Len(t, mockClient.requests, 2)
+}
+
+func TestAccessProviderClient_AccessProviderHasWhatDataObject(t *testing.T) {
+	page1 := `{"accessProviders": {"__typename": "PagedResult", "pageInfo": {"hasNextPage": true}, "edges": [{"cursor": "1", "node": {"id": "ap1"}}, {"cursor": "2", "node": {"id": "ap2"}}]}}`
+	page2 := `{"accessProviders": {"__typename": "PagedResult", "pageInfo": {"hasNextPage": false}, "edges": [{"cursor": "3", "node": {"id": "ap3"}}]}}`
+
+	t.Run("Found", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{responses: []string{page1, page2}}
+		client := NewAccessProviderClient(mockClient)
+
+		found, err := client.AccessProviderHasWhatDataObject(context.Background(), "ap3", "do1")
+
+		require.NoError(t, err)
+		assert.True(t, found)
+		require.Len(t, mockClient.requests, 2)
+		assert.Equal(t, "do1", mockClient.variables(t, 0)["filter"].(map[string]interface{})["dataObjectInWhat"])
+		assert.Nil(t, mockClient.variables(t, 0)["after"])
+		assert.Equal(t, "2", mockClient.variables(t, 1)["after"])
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{responses: []string{page1, page2}}
+		client := NewAccessProviderClient(mockClient)
+
+		found, err := client.AccessProviderHasWhatDataObject(context.Background(), "ap4", "do1")
+
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("Permission denied", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{responses: []string{`{"accessProviders": {"__typename": "PermissionDeniedError", "message": "denied"}}`}}
+		client := NewAccessProviderClient(mockClient)
+
+		_, err := client.AccessProviderHasWhatDataObject(context.Background(), "ap1", "do1")
+
+		assert.ErrorIs(t, err, &types.ErrPermissionDenied{})
+	})
+
+	t.Run("Next page without cursor", func(t *testing.T) {
+		noEdges := `{"accessProviders": {"__typename": "PagedResult", "pageInfo": {"hasNextPage": true}, "edges": []}}`
+		noCursor := `{"accessProviders": {"__typename": "PagedResult", "pageInfo": {"hasNextPage": true}, "edges": [{"node": {"id": "ap3"}}]}}`
+
+		for _, responses := range [][]string{{noEdges}, {noCursor}, {page1, page1}} {
+			mockClient := &mockGraphqlClient{responses: responses}
+			client := NewAccessProviderClient(mockClient)
+
+			_, err := client.AccessProviderHasWhatDataObject(context.Background(), "ap4", "do1")
+
+			assert.ErrorIs(t, err, &types.ErrUnexpectedResponse{Operation: "AccessProviderHasWhatDataObject"})
+			assert.Len(t, mockClient.requests, len(responses))
+		}
+	})
+}
+
+func TestAccessProviderClient_GetAccessProviderWhatColumnList(t *testing.T) {
+	page := `{"accessProvider": {"__typename": "AccessProvider", "whatDataObjects": {"__typename": "PagedResult", "pageInfo": {"hasNextPage": false}, "edges": [
+		{"cursor": "1", "node": {"__typename": "AccessWhatItem", "permissions": ["SELECT"], "globalPermissions": [], "dataObject": {"id": "do1", "name": "table1", "fullName": "db.schema.table1", "type": "table"}}},
+		{"cursor": "2", "node": {"__typename": "AccessWhatItem", "permissions": ["SELECT"], "globalPermissions": [], "dataObject": {"id": "do2", "name": "email", "fullName": "db.schema.table1.email", "type": "column"}}}
+	]}}}`
+
+	mockClient := &mockGraphqlClient{responses: []string{page}}
+	client := NewAccessProviderClient(mockClient)
+
+	items := collectItems(t, client.GetAccessProviderWhatColumnList(context.Background(), "ap-id"))
+
+	require.Len(t, items, 1)
+	assert.Equal(t, "email", items[0].Column)
+	assert.Equal(t, "db.schema.table1", items[0].ParentFullName)
+	assert.Equal(t, "do2", items[0].DataObject.Id)
+	assert.Equal(t, "SELECT", *items[0].Permissions[0])
+}
+
+func TestAccessProviderClient_MaskingPolicy(t *testing.T) {
+	maskResponse := `{"__typename": "AccessProvider", "id": "mask-1", "name": "mask", "state": "Active", "action": "Mask", "syncData": [
+		{"dataSource": {"id": "ds-1", "name": "snowflake"}, "maskType": {"externalId": "SHA256", "displayName": "Hash"}, "syncStatus": "Synced"},
+		{"dataSource": {"id": "ds-2", "name": "bigquery"}, "syncStatus": "Synced"}
+	]}`
+
+	t.Run("Create", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{responses: []string{`{"createAccessProvider": ` + maskResponse + `}`}}
+		client := NewAccessProviderClient(mockClient)
+
+		name := "mask"
+		maskType := "SHA256"
+
+		mask, err := client.CreateMaskingPolicy(context.Background(), types.AccessProviderInput{
+			Name:        &name,
+			DataSources: []types.AccessProviderDataSourceInput{{DataSource: "ds-1", Type: &maskType}},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "mask-1", mask.AccessProvider.Id)
+		assert.Equal(t, map[string]types.MaskType{"ds-1": {ExternalId: "SHA256", DisplayName: "Hash"}}, mask.MaskTypes)
+
+		require.Len(t, mockClient.requests, 1)
+		assert.Equal(t, "Mask", mockClient.variables(t, 0)["ap"].(map[string]interface{})["action"])
+	})
+
+	t.Run("Create with other action", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{}
+		client := NewAccessProviderClient(mockClient)
+
+		name := "mask"
+		action := models.AccessProviderActionGrant
+
+		_, err := client.CreateMaskingPolicy(context.Background(), types.AccessProviderInput{
+			Name:        &name,
+			Action:      &action,
+			DataSources: []types.AccessProviderDataSourceInput{{DataSource: "ds-1"}},
+		})
+
+		assert.ErrorIs(t, err, &types.ErrInvalidInput{})
+		assert.Empty(t, mockClient.requests)
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{responses: []string{
+			`{"accessProvider": ` + maskResponse + `}`,
+			`{"accessProvider": {"__typename": "AccessProvider", "id": "ap-1", "state": "Active", "action": "Grant"}}`,
+		}}
+		client := NewAccessProviderClient(mockClient)
+
+		mask, err := client.GetMaskingPolicy(context.Background(), "mask-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "SHA256", mask.MaskTypes["ds-1"].ExternalId)
+		assert.NotContains(t, mask.MaskTypes, "ds-2")
+
+		_, err = client.GetMaskingPolicy(context.Background(), "ap-1")
+
+		assert.ErrorIs(t, err, &types.ErrInvalidInput{})
+	})
+}
+
+func TestAccessProviderClient_RowFilter(t *testing.T) {
+	filterResponse := `{"__typename": "AccessProvider", "id": "filter-1", "name": "filter", "state": "Active", "action": "Filtered", "policyRule": "{region} = 'EU'"}`
+
+	t.Run("Create", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{responses: []string{`{"createAccessProvider": ` + filterResponse + `}`}}
+		client := NewAccessProviderClient(mockClient)
+
+		name := "filter"
+		policyRule := "{region} = 'EU'"
+
+		filter, err := client.CreateRowFilter(context.Background(), types.AccessProviderInput{Name: &name, PolicyRule: &policyRule})
+
+		require.NoError(t, err)
+		assert.Equal(t, "filter-1", filter.AccessProvider.Id)
+		assert.Equal(t, "{region} = 'EU'", filter.PolicyRule)
+
+		require.Len(t, mockClient.requests, 1)
+		assert.Equal(t, "Filtered", mockClient.variables(t, 0)["ap"].(map[string]interface{})["action"])
+	})
+
+	t.Run("Create without policy rule", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{}
+		client := NewAccessProviderClient(mockClient)
+
+		name := "filter"
+		policyRule := " "
+
+		_, err := client.CreateRowFilter(context.Background(), types.AccessProviderInput{Name: &name, PolicyRule: &policyRule})
+
+		assert.ErrorIs(t, err, &types.ErrInvalidInput{})
+		assert.Empty(t, mockClient.requests)
+	})
+
+	t.Run("Create with other action", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{}
+		client := NewAccessProviderClient(mockClient)
+
+		name := "filter"
+		policyRule := "{region} = 'EU'"
+		action := models.AccessProviderActionMask
+
+		_, err := client.CreateRowFilter(context.Background(), types.AccessProviderInput{Name: &name, Action: &action, PolicyRule: &policyRule})
+
+		assert.ErrorIs(t, err, &types.ErrInvalidInput{})
+		assert.Empty(t, mockClient.requests)
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{responses: []string{
+			`{"accessProvider": ` + filterResponse + `}`,
+			`{"accessProvider": {"__typename": "AccessProvider", "id": "ap-1", "state": "Active", "action": "Grant"}}`,
+		}}
+		client := NewAccessProviderClient(mockClient)
+
+		filter, err := client.GetRowFilter(context.Background(), "filter-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "{region} = 'EU'", filter.PolicyRule)
+
+		_, err = client.GetRowFilter(context.Background(), "ap-1")
+
+		assert.ErrorIs(t, err, &types.ErrInvalidInput{})
+	})
+}
+
+func TestAccessProviderClient_SearchAccessProviders(t *testing.T) {
+	mockClient := &mockGraphqlClient{responses: []string{accessProviderListPage}}
+	client := NewAccessProviderClient(mockClient)
+
+	filter := &types.AccessProviderFilterInput{Categories: []string{"purpose"}}
+
+	items := collectItems(t, client.SearchAccessProviders(context.Background(), "finance", WithAccessProviderListFilter(filter)))
+
+	require.Len(t, items, 2)
+	assert.Nil(t, filter.Search)
+
+	require.Len(t, mockClient.requests, 1)
+	requestFilter := mockClient.variables(t, 0)["filter"].(map[string]interface{})
+	assert.Equal(t, "finance", requestFilter["search"])
+	assert.Equal(t, []interface{}{"purpose"}, requestFilter["categories"])
+}
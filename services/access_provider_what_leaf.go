@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/raito-io/sdk-go/internal"
+	"github.com/raito-io/sdk-go/types"
+)
+
+type AccessProviderWhatLeafOptions struct {
+	maxDepth    int
+	concurrency int
+	leafTypes   []string
+}
+
+// WithAccessProviderWhatLeafMaxDepth can be used to limit the number of levels below a data object in the what list that are traversed.
+// Data objects at the maximum depth are returned as leaf, without checking if they have children. A depth of 0 returns the data objects of the what list as is.
+// By default, at most 10 levels are traversed.
+func WithAccessProviderWhatLeafMaxDepth(maxDepth int) func(options *AccessProviderWhatLeafOptions) {
+	return func(options *AccessProviderWhatLeafOptions) {
+		options.maxDepth = maxDepth
+	}
+}
+
+// WithAccessProviderWhatLeafConcurrency can be used to specify the number of data objects in the what list that are traversed at the same time.
+// By default, 10 data objects are traversed concurrently. The concurrency should be at least 1.
+func WithAccessProviderWhatLeafConcurrency(concurrency int) func(options *AccessProviderWhatLeafOptions) {
+	return func(options *AccessProviderWhatLeafOptions) {
+		options.concurrency = concurrency
+	}
+}
+
+// WithAccessProviderWhatLeafTypes can be used to treat data objects of the given types as leaf, e.g. "table" and "view" to not descend into columns.
+func WithAccessProviderWhatLeafTypes(dataObjectTypes ...string) func(options *AccessProviderWhatLeafOptions) {
+	return func(options *AccessProviderWhatLeafOptions) {
+		options.leafTypes = append(options.leafTypes, dataObjectTypes...)
+	}
+}
+
+// GetAccessProviderWhatLeafDataObjects returns the leaf data objects to which the AccessProvider with the given id grants access.
+// Data objects in the what list that contain other data objects, such as databases and schemas, are replaced by the data objects without children underneath them.
+// The maximum depth of the traversal can be specified with WithAccessProviderWhatLeafMaxDepth and the concurrency with WithAccessProviderWhatLeafConcurrency.
+// Data object types that should be treated as leaf can be specified with WithAccessProviderWhatLeafTypes.
+// As each data object in the what list is traversed separately, a data object is returned multiple times if it is contained in multiple data objects of the what list.
+// The order of the returned items is not deterministic, unless the concurrency is 1.
+// A channel is returned that can be used to receive the list of AccessProviderWhatLeafItem.
+// To close the channel ensure to cancel the context.
+func (a *AccessProviderClient) GetAccessProviderWhatLeafDataObjects(ctx context.Context, id string, ops ...func(*AccessProviderWhatLeafOptions)) <-chan types.ListItem[types.AccessProviderWhatLeafItem] {
+	options := AccessProviderWhatLeafOptions{maxDepth: internal.DefaultWhatLeafMaxDepth, concurrency: internal.DefaultBatchConcurrency}
+	for _, op := range ops {
+		op(&options)
+	}
+
+	if options.maxDepth < 0 {
+		return internal.ErrorChannel[types.AccessProviderWhatLeafItem](types.NewErrInvalidInput(fmt.Sprintf("max depth %d should not be negative", options.maxDepth)))
+	}
+
+	if options.concurrency < 1 {
+		return internal.ErrorChannel[types.AccessProviderWhatLeafItem](types.NewErrInvalidInput(fmt.Sprintf("concurrency should be at least 1, got %d", options.concurrency)))
+	}
+
+	outputChannel := make(chan types.ListItem[types.AccessProviderWhatLeafItem])
+
+	go func() {
+		defer close(outputChannel)
+
+		walkCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		var (
+			wg       sync.WaitGroup
+			errOnce  sync.Once
+			firstErr error
+		)
+
+		fail := func(err error) {
+			errOnce.Do(func() {
+				firstErr = err
+
+				cancel()
+			})
+		}
+
+		walker := whatLeafWalker{
+			dataObjectClient: &DataObjectClient{client: a.client},
+			options:          &options,
+			outputChannel:    outputChannel,
+		}
+
+		semaphore := make(chan struct{}, options.concurrency)
+
+	whatLoop:
+		for whatItem := range a.GetAccessProviderWhatDataObjectList(walkCtx, id) {
+			if whatItem.HasError() {
+				fail(whatItem.GetError())
+
+				break
+			}
+
+			item := whatItem.GetItem()
+			if item.DataObject == nil {
+				continue
+			}
+
+			select {
+			case <-walkCtx.Done():
+				break whatLoop
+			case semaphore <- struct{}{}:
+			}
+
+			wg.Add(1)
+
+			go func() {
+				defer func() {
+					<-semaphore
+					wg.Done()
+				}()
+
+				err := walker.walk(walkCtx, item, &item.DataObject.DataObject, 0)
+				if err != nil {
+					fail(err)
+				}
+			}()
+		}
+
+		wg.Wait()
+
+		// Context errors are not reported, as the channel is closed without error when the context is cancelled
+		if firstErr != nil && ctx.Err() == nil {
+			select {
+			case <-ctx.Done():
+			case outputChannel <- types.NewListItemError[types.AccessProviderWhatLeafItem](firstErr):
+			}
+		}
+	}()
+
+	return outputChannel
+}
+
+type whatLeafWalker struct {
+	dataObjectClient *DataObjectClient
+	options          *AccessProviderWhatLeafOptions
+	outputChannel    chan<- types.ListItem[types.AccessProviderWhatLeafItem]
+}
+
+// walk sends the leaf data objects underneath dataObject, which is depth levels below the data object of whatItem.
+func (w *whatLeafWalker) walk(ctx context.Context, whatItem *types.AccessProviderWhatListItem, dataObject *types.DataObject, depth int) error {
+	if depth >= w.options.maxDepth || slices.Contains(w.options.leafTypes, dataObject.Type) {
+		return w.send(ctx, whatItem, dataObject, depth)
+	}
+
+	hasChildren := false
+
+	for child := range w.dataObjectClient.ListDataObjects(ctx, WithDataObjectListFilter(&types.DataObjectFilterInput{Parents: []string{dataObject.Id}})) {
+		if child.HasError() {
+			return child.GetError()
+		}
+
+		hasChildren = true
+
+		err := w.walk(ctx, whatItem, child.GetItem(), depth+1)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if !hasChildren {
+		return w.send(ctx, whatItem, dataObject, depth)
+	}
+
+	return nil
+}
+
+func (w *whatLeafWalker) send(ctx context.Context, whatItem *types.AccessProviderWhatListItem, dataObject *types.DataObject, depth int) error {
+	item := &types.AccessProviderWhatLeafItem{
+		DataObject:        dataObject,
+		WhatDataObject:    whatItem.DataObject,
+		Depth:             depth,
+		Permissions:       whatItem.Permissions,
+		GlobalPermissions: whatItem.GlobalPermissions,
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case w.outputChannel <- types.NewListItemItem(item):
+		return nil
+	}
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/types"
+)
+
+// dataObjectListPage returns a ListDataObjects response containing tables with the given ids.
+func dataObjectListPage(ids ...string) string {
+	edges := make([]string, 0, len(ids))
+	for _, id := range ids {
+		edges = append(edges, fmt.Sprintf(`{"cursor": %q, "node": {"__typename": "DataObject", "id": %q, "name": %q, "fullName": "db.schema.%s", "type": "table"}}`, id, id, id, id))
+	}
+
+	return fmt.Sprintf(`{"dataObjects": {"pageInfo": {"hasNextPage": false}, "edges": [%s]}}`, strings.Join(edges, ","))
+}
+
+func TestAccessProviderClient_GetAccessProviderWhatLeafDataObjects(t *testing.T) {
+	whatSchemaPage := `{"accessProvider": {"__typename": "AccessProvider", "whatDataObjects": {"__typename": "PagedResult", "pageInfo": {"hasNextPage": false}, "edges": [
+		{"cursor": "1", "node": {"__typename": "AccessWhatItem", "permissions": ["SELECT"], "globalPermissions": [], "dataObject": {"id": "s1", "name": "schema", "fullName": "db.schema", "type": "schema"}}}
+	]}}}`
+
+	t.Run("leafs", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{responses: []string{whatSchemaPage, dataObjectListPage("t1", "t2"), dataObjectListPage(), dataObjectListPage()}}
+		client := NewAccessProviderClient(mockClient)
+
+		items := collectItems(t, client.GetAccessProviderWhatLeafDataObjects(context.Background(), "ap-id", WithAccessProviderWhatLeafConcurrency(1)))
+
+		require.Len(t, items, 2)
+		assert.Equal(t, "t1", items[0].DataObject.Id)
+		assert.Equal(t, "t2", items[1].DataObject.Id)
+		assert.Equal(t, "s1", items[1].WhatDataObject.Id)
+		assert.Equal(t, 1, items[1].Depth)
+		assert.Equal(t, "SELECT", *items[1].Permissions[0])
+
+		require.Len(t, mockClient.requests, 4)
+		assert.Equal(t, map[string]interface{}{"parents": []interface{}{"s1"}}, onlySet(mockClient.variables(t, 1)["filter"]))
+		assert.Equal(t, map[string]interface{}{"parents": []interface{}{"t1"}}, onlySet(mockClient.variables(t, 2)["filter"]))
+	})
+
+	t.Run("leaf types", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{responses: []string{whatSchemaPage, dataObjectListPage("t1")}}
+		client := NewAccessProviderClient(mockClient)
+
+		items := collectItems(t, client.GetAccessProviderWhatLeafDataObjects(context.Background(), "ap-id", WithAccessProviderWhatLeafTypes("table")))
+
+		require.Len(t, items, 1)
+		assert.Equal(t, "t1", items[0].DataObject.Id)
+		assert.Len(t, mockClient.requests, 2)
+	})
+
+	t.Run("max depth", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{responses: []string{whatSchemaPage}}
+		client := NewAccessProviderClient(mockClient)
+
+		items := collectItems(t, client.GetAccessProviderWhatLeafDataObjects(context.Background(), "ap-id", WithAccessProviderWhatLeafMaxDepth(0)))
+
+		require.Len(t, items, 1)
+		assert.Equal(t, "s1", items[0].DataObject.Id)
+		assert.Equal(t, 0, items[0].Depth)
+		assert.Len(t, mockClient.requests, 1)
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		for _, op := range []func(*AccessProviderWhatLeafOptions){WithAccessProviderWhatLeafMaxDepth(-1), WithAccessProviderWhatLeafConcurrency(0)} {
+			mockClient := &mockGraphqlClient{}
+			client := NewAccessProviderClient(mockClient)
+
+			listItem, ok := <-client.GetAccessProviderWhatLeafDataObjects(context.Background(), "ap-id", op)
+
+			require.True(t, ok)
+			assert.ErrorIs(t, listItem.GetError(), &types.ErrInvalidInput{})
+			assert.Empty(t, mockClient.requests)
+		}
+	})
+}
+
+// onlySet drops the nil fields of a JSON object.
+func onlySet(value interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+
+	for key, v := range value.(map[string]interface{}) {
+		if v != nil {
+			result[key] = v
+		}
+	}
+
+	return result
+}
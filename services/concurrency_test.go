@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/internal"
+)
+
+// TestAccessProviderClient_Concurrent shares a single AccessProviderClient across goroutines, using the same transport as the RaitoClient.
+// Run with -race to detect data races.
+func TestAccessProviderClient_Concurrent(t *testing.T) {
+	var tokenRequests atomic.Int32
+
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests.Add(1)
+
+		_, _ = w.Write([]byte(`{"access_token":"access-token","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer tokenServer.Close()
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req graphql.Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		switch req.OpName {
+		case "ListAccessProviders":
+			_, _ = w.Write([]byte(`{"data": ` + accessProviderListPage + `}`))
+		case "GetAccessProvider":
+			_, _ = w.Write([]byte(`{"data": {"accessProvider": {"__typename": "AccessProvider", "id": "ap1", "name": "ap 1"}}}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer apiServer.Close()
+
+	doer := &internal.AuthedDoer{
+		Domain: "test",
+		ClientCredentials: &internal.ClientCredentials{
+			ClientId:     "client-id",
+			ClientSecret: "client-secret",
+			TokenUrl:     tokenServer.URL,
+		},
+	}
+
+	client := NewAccessProviderClient(graphql.NewClient(apiServer.URL, doer), WithRetry(3, time.Millisecond), WithOperationTimeout(time.Second))
+
+	var wg sync.WaitGroup
+
+	for range 20 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for range 10 {
+				aps, err := client.ListAccessProvidersAll(context.Background())
+				if assert.NoError(t, err) {
+					assert.Len(t, aps, 2)
+				}
+
+				ap, err := client.GetAccessProvider(context.Background(), "ap1")
+				if assert.NoError(t, err) {
+					assert.Equal(t, "ap 1", ap.Name)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	require.Equal(t, int32(1), tokenRequests.Load())
+}
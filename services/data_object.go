@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/Khan/genqlient/graphql"
 	"github.com/aws/smithy-go/ptr"
@@ -16,25 +17,37 @@ type DataObjectClient struct {
 	client graphql.Client
 }
 
-func NewDataObjectClient(client graphql.Client) DataObjectClient {
+func NewDataObjectClient(client graphql.Client, ops ...func(options *ClientOptions)) DataObjectClient {
 	return DataObjectClient{
-		client: client,
+		client: newGraphqlClient(client, ops...),
 	}
 }
 
+// DataObjectService is implemented by DataObjectClient.
+// It can be used to depend on an abstraction of the DataObjectClient, e.g. to substitute it in tests.
+type DataObjectService interface {
+	GetDataObject(ctx context.Context, id string) (*types.DataObject, error)
+	ListDataObjects(ctx context.Context, ops ...func(options *DataObjectListOptions)) <-chan types.ListItem[types.DataObject]
+	GetDataObjectIdByName(ctx context.Context, fullname string, dataSource string) (string, error)
+	GetDataObjectByFullName(ctx context.Context, fullName string, ops ...func(options *DataObjectByFullNameOptions)) (*types.DataObject, error)
+}
+
+var _ DataObjectService = (*DataObjectClient)(nil)
+
 // GetDataObject returns a DataObject by id.
 func (c *DataObjectClient) GetDataObject(ctx context.Context, id string) (*types.DataObject, error) {
 	result, err := schema.GetDataObject(ctx, c.client, id)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	return &result.DataObject.DataObject, nil
 }
 
 type DataObjectListOptions struct {
-	order  []types.DataObjectOrderByInput
-	filter *types.DataObjectFilterInput
+	order    []types.DataObjectOrderByInput
+	filter   *types.DataObjectFilterInput
+	pageSize int
 }
 
 // WithDataObjectListOrder sets the order of the returned DataObjects in the ListDataObjects call
@@ -51,21 +64,34 @@ func WithDataObjectListFilter(input *types.DataObjectFilterInput) func(options *
 	}
 }
 
+// WithDataObjectListPageSize sets the number of DataObjects fetched per request in the ListDataObjects call
+// The page size should be between 1 and 1000.
+func WithDataObjectListPageSize(pageSize int) func(options *DataObjectListOptions) {
+	return func(options *DataObjectListOptions) {
+		options.pageSize = pageSize
+	}
+}
+
 // ListDataObjects returns a list of DataObjects
 // The order of the list can be specified with WithDataObjectListOrder
-// A filter can be specified with WithDataObjectListFilter
+// A filter can be specified with WithDataObjectListFilter. Use the Parents field of the filter to list the children of a DataObject.
+// The page size can be specified with WithDataObjectListPageSize
 // A channel is returned that can be used to receive the list of DataObjectListItem
 // To close the channel ensure to cancel the context
 func (c *DataObjectClient) ListDataObjects(ctx context.Context, ops ...func(options *DataObjectListOptions)) <-chan types.ListItem[types.DataObject] { //nolint:dupl
-	options := DataObjectListOptions{}
+	options := DataObjectListOptions{pageSize: internal.MaxPageSize}
 	for _, op := range ops {
 		op(&options)
 	}
 
+	if err := internal.ValidatePageSize(options.pageSize); err != nil {
+		return internal.ErrorChannel[types.DataObject](err)
+	}
+
 	loadPageFn := func(ctx context.Context, cursor *string) (*types.PageInfo, []types.DataObjectPageEdgesEdge, error) {
-		output, err := schema.ListDataObjects(ctx, c.client, cursor, ptr.Int(internal.MaxPageSize), options.filter, options.order)
+		output, err := schema.ListDataObjects(ctx, c.client, cursor, ptr.Int(options.pageSize), options.filter, options.order)
 		if err != nil {
-			return nil, nil, types.NewErrClient(err)
+			return nil, nil, clientError(err)
 		}
 
 		return &output.DataObjects.PageInfo.PageInfo, output.DataObjects.Edges, nil
@@ -78,7 +104,10 @@ func (c *DataObjectClient) ListDataObjects(ctx context.Context, ops ...func(opti
 			return cursor, nil, nil
 		}
 
-		listItem := (*edge.Node).(*types.DataObjectPageEdgesEdgeNodeDataObject)
+		listItem, err := internal.DecodeEdgeNode[*types.DataObjectPageEdgesEdgeNodeDataObject](*edge.Node)
+		if err != nil {
+			return cursor, nil, err
+		}
 
 		return cursor, &listItem.DataObject, nil
 	}
@@ -90,12 +119,63 @@ func (c *DataObjectClient) ListDataObjects(ctx context.Context, ops ...func(opti
 func (c *DataObjectClient) GetDataObjectIdByName(ctx context.Context, fullname string, dataSource string) (string, error) {
 	result, err := schema.DataObjectByExternalId(ctx, c.client, fullname, dataSource)
 	if err != nil {
-		return "", types.NewErrClient(err)
+		return "", clientError(err)
 	}
 
 	if len(result.DataObjects.Edges) != 1 || result.DataObjects.Edges[0].Node == nil {
 		return "", errors.New("unexpected number of results")
 	}
 
-	return (*result.DataObjects.Edges[0].Node).(*schema.DataObjectByExternalIdDataObjectsPagedResultEdgesEdgeNodeDataObject).Id, nil
+	dataObject, err := internal.DecodeEdgeNode[*schema.DataObjectByExternalIdDataObjectsPagedResultEdgesEdgeNodeDataObject](*result.DataObjects.Edges[0].Node)
+	if err != nil {
+		return "", err
+	}
+
+	return dataObject.Id, nil
+}
+
+type DataObjectByFullNameOptions struct {
+	dataSource *string
+}
+
+// WithDataObjectByFullNameDataSource restricts the GetDataObjectByFullName lookup to the DataSource with the given id
+func WithDataObjectByFullNameDataSource(dataSourceId string) func(options *DataObjectByFullNameOptions) {
+	return func(options *DataObjectByFullNameOptions) {
+		options.dataSource = &dataSourceId
+	}
+}
+
+// GetDataObjectByFullName returns the DataObject with the given full name.
+// A types.ErrNotFound is returned if no DataObject matches.
+// As full names are only unique within a DataSource, a types.ErrInvalidInput is returned if multiple DataObjects match.
+// The lookup can be restricted to a single DataSource with WithDataObjectByFullNameDataSource.
+func (c *DataObjectClient) GetDataObjectByFullName(ctx context.Context, fullName string, ops ...func(options *DataObjectByFullNameOptions)) (*types.DataObject, error) {
+	options := DataObjectByFullNameOptions{}
+	for _, op := range ops {
+		op(&options)
+	}
+
+	filter := types.DataObjectFilterInput{
+		FullNames: []string{fullName},
+	}
+
+	if options.dataSource != nil {
+		filter.DataSources = []string{*options.dataSource}
+	}
+
+	dataObjects, err := internal.CollectAll(ctx, func(ctx context.Context) <-chan types.ListItem[types.DataObject] {
+		return c.ListDataObjects(ctx, WithDataObjectListFilter(&filter))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(dataObjects) {
+	case 0:
+		return nil, types.NewErrResourceNotFound("getDataObjectByFullName", "DataObject", fullName, ptr.String("dataObject"), "no data object found with the given full name")
+	case 1:
+		return &dataObjects[0], nil
+	default:
+		return nil, types.NewErrInvalidInput(fmt.Sprintf("found %d data objects with full name %q, specify the data source", len(dataObjects), fullName))
+	}
 }
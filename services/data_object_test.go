@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/types"
+)
+
+func TestDataObjectClient_GetDataObjectByFullName(t *testing.T) {
+	const (
+		noResults = `{"dataObjects": {"pageInfo": {"hasNextPage": false}, "edges": []}}`
+		oneResult = `{"dataObjects": {"pageInfo": {"hasNextPage": false}, "edges": [
+			{"cursor": "1", "node": {"__typename": "DataObject", "id": "do1", "name": "table", "fullName": "db.schema.table", "type": "table", "dataSource": {"id": "ds1"}}}
+		]}}`
+		twoResults = `{"dataObjects": {"pageInfo": {"hasNextPage": false}, "edges": [
+			{"cursor": "1", "node": {"__typename": "DataObject", "id": "do1", "name": "table", "fullName": "db.schema.table", "type": "table", "dataSource": {"id": "ds1"}}},
+			{"cursor": "2", "node": {"__typename": "DataObject", "id": "do2", "name": "table", "fullName": "db.schema.table", "type": "table", "dataSource": {"id": "ds2"}}}
+		]}}`
+	)
+
+	t.Run("Found", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{responses: []string{oneResult}}
+		client := NewDataObjectClient(mockClient)
+
+		do, err := client.GetDataObjectByFullName(context.Background(), "db.schema.table")
+
+		require.NoError(t, err)
+		assert.Equal(t, "do1", do.Id)
+		filter := mockClient.variables(t, 0)["filter"].(map[string]interface{})
+		assert.Equal(t, []interface{}{"db.schema.table"}, filter["fullNames"])
+		assert.Nil(t, filter["dataSources"])
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		client := NewDataObjectClient(&mockGraphqlClient{responses: []string{noResults}})
+
+		_, err := client.GetDataObjectByFullName(context.Background(), "db.schema.table")
+
+		assert.ErrorIs(t, err, &types.ErrNotFound{Id: "db.schema.table", Operation: "getDataObjectByFullName", Resource: "DataObject"})
+	})
+
+	t.Run("Multiple matches", func(t *testing.T) {
+		client := NewDataObjectClient(&mockGraphqlClient{responses: []string{twoResults}})
+
+		_, err := client.GetDataObjectByFullName(context.Background(), "db.schema.table")
+
+		assert.ErrorIs(t, err, &types.ErrInvalidInput{})
+	})
+
+	t.Run("Restricted to data source", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{responses: []string{oneResult}}
+		client := NewDataObjectClient(mockClient)
+
+		do, err := client.GetDataObjectByFullName(context.Background(), "db.schema.table", WithDataObjectByFullNameDataSource("ds1"))
+
+		require.NoError(t, err)
+		assert.Equal(t, "do1", do.Id)
+		filter := mockClient.variables(t, 0)["filter"].(map[string]interface{})
+		assert.Equal(t, []interface{}{"db.schema.table"}, filter["fullNames"])
+		assert.Equal(t, []interface{}{"ds1"}, filter["dataSources"])
+	})
+}
@@ -2,8 +2,6 @@ package services
 
 import (
 	"context"
-	"errors"
-	"fmt"
 
 	"github.com/Khan/genqlient/graphql"
 	"github.com/aws/smithy-go/ptr"
@@ -17,30 +15,46 @@ type DataSourceClient struct {
 	client graphql.Client
 }
 
-func NewDataSourceClient(client graphql.Client) DataSourceClient {
+func NewDataSourceClient(client graphql.Client, ops ...func(options *ClientOptions)) DataSourceClient {
 	return DataSourceClient{
-		client: client,
+		client: newGraphqlClient(client, ops...),
 	}
 }
 
+// DataSourceService is implemented by DataSourceClient.
+// It can be used to depend on an abstraction of the DataSourceClient, e.g. to substitute it in tests.
+type DataSourceService interface {
+	CreateDataSource(ctx context.Context, ds types.DataSourceInput) (*types.DataSource, error)
+	UpdateDataSource(ctx context.Context, id string, ds types.DataSourceInput) (*types.DataSource, error)
+	DeleteDataSource(ctx context.Context, id string) error
+	AddIdentityStoreToDataSource(ctx context.Context, dsId string, isId string) error
+	RemoveIdentityStoreFromDataSource(ctx context.Context, dsId string, isId string) error
+	GetDataSource(ctx context.Context, id string) (*types.DataSource, error)
+	GetMaskingMetadata(ctx context.Context, id string) (*types.MaskingMetadata, error)
+	ListDataSources(ctx context.Context, ops ...func(*DataSourceListOptions)) <-chan types.ListItem[types.DataSource]
+	ListIdentityStores(ctx context.Context, dsId string) ([]types.IdentityStore, error)
+}
+
+var _ DataSourceService = (*DataSourceClient)(nil)
+
 // CreateDataSource creates a new DataSource.
 // Returns the newly created DataSource if successful.
 // Otherwise, returns an error.
 func (c *DataSourceClient) CreateDataSource(ctx context.Context, ds types.DataSourceInput) (*types.DataSource, error) {
 	result, err := schema.CreateDataSource(ctx, c.client, ds)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	switch response := result.CreateDataSource.(type) {
 	case *schema.CreateDataSourceCreateDataSource:
 		return &response.DataSource, nil
 	case *schema.CreateDataSourceCreateDataSourceNotFoundError:
-		return nil, types.NewErrNotFound("", response.Typename, response.Message)
+		return nil, types.NewErrResourceNotFound("createDataSource", "DataSource", "", response.Typename, response.Message)
 	case *schema.CreateDataSourceCreateDataSourcePermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("createDataSource", response.Message)
 	default:
-		return nil, fmt.Errorf("unexpected response type: %T", result.CreateDataSource)
+		return nil, unexpectedResponse("CreateDataSource", result.CreateDataSource)
 	}
 }
 
@@ -50,18 +64,18 @@ func (c *DataSourceClient) CreateDataSource(ctx context.Context, ds types.DataSo
 func (c *DataSourceClient) UpdateDataSource(ctx context.Context, id string, ds types.DataSourceInput) (*types.DataSource, error) {
 	result, err := schema.UpdateDataSource(ctx, c.client, id, ds)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	switch response := result.UpdateDataSource.(type) {
 	case *schema.UpdateDataSourceUpdateDataSource:
 		return &response.DataSource, nil
 	case *schema.UpdateDataSourceUpdateDataSourceNotFoundError:
-		return nil, types.NewErrNotFound(id, response.Typename, response.Message)
+		return nil, types.NewErrResourceNotFound("updateDataSource", "DataSource", id, response.Typename, response.Message)
 	case *schema.UpdateDataSourceUpdateDataSourcePermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("updateDataSource", response.Message)
 	default:
-		return nil, fmt.Errorf("unexpected response type: %T", result.UpdateDataSource)
+		return nil, unexpectedResponse("UpdateDataSource", result.UpdateDataSource)
 	}
 }
 
@@ -71,7 +85,7 @@ func (c *DataSourceClient) UpdateDataSource(ctx context.Context, id string, ds t
 func (c *DataSourceClient) DeleteDataSource(ctx context.Context, id string) error {
 	result, err := schema.DeleteDataSource(ctx, c.client, id)
 	if err != nil {
-		return types.NewErrClient(err)
+		return clientError(err)
 	}
 
 	switch response := result.DeleteDataSource.(type) {
@@ -80,7 +94,7 @@ func (c *DataSourceClient) DeleteDataSource(ctx context.Context, id string) erro
 	case *schema.DeleteDataSourceDeleteDataSourcePermissionDeniedError:
 		return types.NewErrPermissionDenied("deleteDataSource", response.Message)
 	default:
-		return fmt.Errorf("unexpected response type: %T", result.DeleteDataSource)
+		return unexpectedResponse("DeleteDataSource", result.DeleteDataSource)
 	}
 }
 
@@ -90,36 +104,36 @@ func (c *DataSourceClient) DeleteDataSource(ctx context.Context, id string) erro
 func (c *DataSourceClient) AddIdentityStoreToDataSource(ctx context.Context, dsId string, isId string) error {
 	result, err := schema.AddIdentityStoreToDataSource(ctx, c.client, dsId, isId)
 	if err != nil {
-		return types.NewErrClient(err)
+		return clientError(err)
 	}
 
 	switch response := result.AddIdentityStoreToDataSource.(type) {
 	case *schema.AddIdentityStoreToDataSourceAddIdentityStoreToDataSource:
 		return nil
 	case *schema.AddIdentityStoreToDataSourceAddIdentityStoreToDataSourceNotFoundError:
-		return types.NewErrNotFound(dsId, response.Typename, response.Message)
+		return types.NewErrResourceNotFound("addIdentityStoreToDataSource", "DataSource", dsId, response.Typename, response.Message)
 	case *schema.AddIdentityStoreToDataSourceAddIdentityStoreToDataSourcePermissionDeniedError:
 		return types.NewErrPermissionDenied("addIdentityStoreToDataSource", response.Message)
 	default:
-		return fmt.Errorf("unexpected response type: %T", result.AddIdentityStoreToDataSource)
+		return unexpectedResponse("AddIdentityStoreToDataSource", result.AddIdentityStoreToDataSource)
 	}
 }
 
 func (c *DataSourceClient) RemoveIdentityStoreFromDataSource(ctx context.Context, dsId string, isId string) error {
 	result, err := schema.RemoveIdentityStoreFromDataSource(ctx, c.client, dsId, isId)
 	if err != nil {
-		return types.NewErrClient(err)
+		return clientError(err)
 	}
 
 	switch response := result.RemoveIdentityStoreFromDataSource.(type) {
 	case *schema.RemoveIdentityStoreFromDataSourceRemoveIdentityStoreFromDataSource:
 		return nil
 	case *schema.RemoveIdentityStoreFromDataSourceRemoveIdentityStoreFromDataSourceNotFoundError:
-		return types.NewErrNotFound(dsId, response.Typename, response.Message)
+		return types.NewErrResourceNotFound("removeIdentityStoreFromDataSource", "DataSource", dsId, response.Typename, response.Message)
 	case *schema.RemoveIdentityStoreFromDataSourceRemoveIdentityStoreFromDataSourcePermissionDeniedError:
 		return types.NewErrPermissionDenied("removeIdentityStoreFromDataSource", response.Message)
 	default:
-		return fmt.Errorf("unexpected response type: %T", result.RemoveIdentityStoreFromDataSource)
+		return unexpectedResponse("RemoveIdentityStoreFromDataSource", result.RemoveIdentityStoreFromDataSource)
 	}
 }
 
@@ -127,7 +141,7 @@ func (c *DataSourceClient) RemoveIdentityStoreFromDataSource(ctx context.Context
 func (c *DataSourceClient) GetDataSource(ctx context.Context, id string) (*types.DataSource, error) {
 	result, err := schema.GetDataSource(ctx, c.client, id)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	switch ds := result.DataSource.(type) {
@@ -136,9 +150,9 @@ func (c *DataSourceClient) GetDataSource(ctx context.Context, id string) (*types
 	case *schema.GetDataSourceDataSourcePermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("dataSource", ds.Message)
 	case *schema.GetDataSourceDataSourceNotFoundError:
-		return nil, types.NewErrNotFound(id, ds.Typename, ds.Message)
+		return nil, types.NewErrResourceNotFound("dataSource", "DataSource", id, ds.Typename, ds.Message)
 	default:
-		return nil, fmt.Errorf("unexpected response type: %T", result.DataSource)
+		return nil, unexpectedResponse("GetDataSource", result.DataSource)
 	}
 }
 
@@ -146,7 +160,7 @@ func (c *DataSourceClient) GetDataSource(ctx context.Context, id string) (*types
 func (c *DataSourceClient) GetMaskingMetadata(ctx context.Context, id string) (*types.MaskingMetadata, error) {
 	result, err := schema.DataSourceMaskInformation(ctx, c.client, id)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	switch ds := result.DataSource.(type) {
@@ -159,17 +173,18 @@ func (c *DataSourceClient) GetMaskingMetadata(ctx context.Context, id string) (*
 	case *schema.DataSourceMaskInformationDataSourcePermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("dataSource", ds.Message)
 	case *schema.DataSourceMaskInformationDataSourceNotFoundError:
-		return nil, types.NewErrNotFound(id, ds.Typename, ds.Message)
+		return nil, types.NewErrResourceNotFound("dataSource", "DataSource", id, ds.Typename, ds.Message)
 	default:
-		return nil, fmt.Errorf("unexpected response type: %T", result.DataSource)
+		return nil, unexpectedResponse("DataSourceMaskInformation", result.DataSource)
 	}
 }
 
 // DataSourceListOptions list options for listing DataSources.
 type DataSourceListOptions struct {
-	order  []types.DataSourceOrderByInput
-	filter *types.DataSourceFilterInput
-	search *string
+	order    []types.DataSourceOrderByInput
+	filter   *types.DataSourceFilterInput
+	search   *string
+	pageSize int
 }
 
 // WithDataSourceListOrder sets the order of the returned DataSources in the ListDataSources call.
@@ -193,21 +208,34 @@ func WithDataSourceListSearch(input *string) func(options *DataSourceListOptions
 	}
 }
 
+// WithDataSourceListPageSize sets the number of DataSources fetched per request in the ListDataSources call.
+// The page size should be between 1 and 1000.
+func WithDataSourceListPageSize(pageSize int) func(options *DataSourceListOptions) {
+	return func(options *DataSourceListOptions) {
+		options.pageSize = pageSize
+	}
+}
+
 // ListDataSources return a list of DataSources
 // The order of the list can be specified with WithDataSourceListOrder.
 // A filter can be specified with WithDataSourceListFilter.
+// The page size can be specified with WithDataSourceListPageSize.
 // A channel is returned that can be used to receive the list of DataSourceListItem.
 // To close the channel ensure to cancel the context.
 func (c *DataSourceClient) ListDataSources(ctx context.Context, ops ...func(*DataSourceListOptions)) <-chan types.ListItem[types.DataSource] {
-	options := DataSourceListOptions{}
+	options := DataSourceListOptions{pageSize: internal.MaxPageSize}
 	for _, op := range ops {
 		op(&options)
 	}
 
+	if err := internal.ValidatePageSize(options.pageSize); err != nil {
+		return internal.ErrorChannel[types.DataSource](err)
+	}
+
 	loadPageFn := func(ctx context.Context, cursor *string) (*types.PageInfo, []types.DataSourcePageEdgesEdge, error) {
-		output, err := schema.ListDataSources(ctx, c.client, cursor, ptr.Int(internal.MaxPageSize), options.filter, nil, options.order)
+		output, err := schema.ListDataSources(ctx, c.client, cursor, ptr.Int(options.pageSize), options.filter, options.search, options.order)
 		if err != nil {
-			return nil, nil, types.NewErrClient(err)
+			return nil, nil, clientError(err)
 		}
 
 		switch page := output.DataSources.(type) {
@@ -215,9 +243,9 @@ func (c *DataSourceClient) ListDataSources(ctx context.Context, ops ...func(*Dat
 			return &page.PageInfo.PageInfo, page.Edges, nil
 		case *schema.ListDataSourcesDataSourcesPermissionDeniedError:
 			return nil, nil, types.NewErrPermissionDenied("listDataSources", page.Message)
+		default:
+			return nil, nil, unexpectedResponse("ListDataSources", page)
 		}
-
-		return nil, nil, errors.New("unreachable")
 	}
 
 	edgeFn := func(edge *types.DataSourcePageEdgesEdge) (*string, *schema.DataSource, error) {
@@ -227,7 +255,10 @@ func (c *DataSourceClient) ListDataSources(ctx context.Context, ops ...func(*Dat
 			return cursor, nil, nil
 		}
 
-		listItem := (*edge.Node).(*types.DataSourcePageEdgesEdgeNodeDataSource)
+		listItem, err := internal.DecodeEdgeNode[*types.DataSourcePageEdgesEdgeNodeDataSource](*edge.Node)
+		if err != nil {
+			return cursor, nil, err
+		}
 
 		return cursor, &listItem.DataSource, nil
 	}
@@ -239,7 +270,7 @@ func (c *DataSourceClient) ListDataSources(ctx context.Context, ops ...func(*Dat
 func (c *DataSourceClient) ListIdentityStores(ctx context.Context, dsId string) ([]types.IdentityStore, error) {
 	result, err := schema.DataSourceIdentityStores(ctx, c.client, dsId)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	switch datasource := result.DataSource.(type) {
@@ -253,6 +284,6 @@ func (c *DataSourceClient) ListIdentityStores(ctx context.Context, dsId string)
 	case *schema.DataSourceIdentityStoresDataSourcePermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("listIdentityStores", datasource.Message)
 	default:
-		return nil, fmt.Errorf("unexpected type '%T': %w", datasource, types.ErrUnknownType)
+		return nil, unexpectedResponse("DataSourceIdentityStores", datasource)
 	}
 }
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/types"
+)
+
+func TestDataSourceClient_ListDataSources(t *testing.T) {
+	const page = `{"dataSources": {"__typename": "PagedResult", "pageInfo": {"hasNextPage": false}, "edges": [
+		{"cursor": "1", "node": {"__typename": "DataSource", "id": "ds1", "name": "data source 1"}}
+	]}}`
+
+	t.Run("Page size and search", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{responses: []string{page}}
+		client := NewDataSourceClient(mockClient)
+
+		search := "snowflake"
+
+		items := collectItems(t, client.ListDataSources(context.Background(), WithDataSourceListPageSize(50), WithDataSourceListSearch(&search)))
+
+		require.Len(t, items, 1)
+		assert.Equal(t, "ds1", items[0].Id)
+		assert.Equal(t, float64(50), mockClient.variables(t, 0)["limit"])
+		assert.Equal(t, "snowflake", mockClient.variables(t, 0)["search"])
+	})
+
+	t.Run("Invalid page size", func(t *testing.T) {
+		for _, pageSize := range []int{0, 1001} {
+			mockClient := &mockGraphqlClient{}
+			client := NewDataSourceClient(mockClient)
+
+			listItem, ok := <-client.ListDataSources(context.Background(), WithDataSourceListPageSize(pageSize))
+
+			require.True(t, ok)
+			assert.ErrorIs(t, listItem.GetError(), &types.ErrInvalidInput{})
+
+			assert.Empty(t, mockClient.requests)
+		}
+	})
+}
@@ -0,0 +1,23 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/raito-io/sdk-go/types"
+)
+
+// clientError wraps err in a types.ErrClient, unless the Raito API rejected the request with a types.ErrValidation.
+// Validation errors are returned as is, so callers can distinguish invalid input from transport-level failures.
+func clientError(err error) error {
+	if errors.Is(err, &types.ErrValidation{}) {
+		return err
+	}
+
+	return types.NewErrClient(err)
+}
+
+// unexpectedResponse returns a types.ErrUnexpectedResponse for a response of the GraphQL operation with a type that is not handled.
+func unexpectedResponse(operation string, response any) error {
+	return types.NewErrUnexpectedResponse(operation, fmt.Sprintf("%T", response))
+}
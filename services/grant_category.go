@@ -14,19 +14,31 @@ type GrantCategoryClient struct {
 	client graphql.Client
 }
 
-func NewGrantCategoryClient(client graphql.Client) GrantCategoryClient {
+func NewGrantCategoryClient(client graphql.Client, ops ...func(options *ClientOptions)) GrantCategoryClient {
 	return GrantCategoryClient{
-		client: client,
+		client: newGraphqlClient(client, ops...),
 	}
 }
 
+// GrantCategoryService is implemented by GrantCategoryClient.
+// It can be used to depend on an abstraction of the GrantCategoryClient, e.g. to substitute it in tests.
+type GrantCategoryService interface {
+	CreateGrantCategory(ctx context.Context, category types.GrantCategoryInput) (*types.GrantCategoryDetails, error)
+	UpdateGrantCategory(ctx context.Context, id string, category types.GrantCategoryInput) (*types.GrantCategoryDetails, error)
+	DeleteGrantCategory(ctx context.Context, id string) error
+	GetGrantCategory(ctx context.Context, id string) (*types.GrantCategoryDetails, error)
+	ListGrantCategories(ctx context.Context) ([]types.GrantCategoryDetails, error)
+}
+
+var _ GrantCategoryService = (*GrantCategoryClient)(nil)
+
 // CreateGrantCategory creates a new GrantCategory.
 // The newly created GrantCategory is returned if successful.
 // Otherwise, an error is returned.
 func (a *GrantCategoryClient) CreateGrantCategory(ctx context.Context, category types.GrantCategoryInput) (*types.GrantCategoryDetails, error) {
 	result, err := schema.CreateGrantCategory(ctx, a.client, category)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	switch response := result.CreateGrantCategory.(type) {
@@ -35,11 +47,11 @@ func (a *GrantCategoryClient) CreateGrantCategory(ctx context.Context, category
 	case *schema.CreateGrantCategoryCreateGrantCategoryPermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("createGrantCategory", response.Message)
 	case *schema.CreateGrantCategoryCreateGrantCategoryNotFoundError:
-		return nil, types.NewErrNotFound("", response.Typename, response.Message)
+		return nil, types.NewErrResourceNotFound("createGrantCategory", "GrantCategory", "", response.Typename, response.Message)
 	case *schema.CreateGrantCategoryCreateGrantCategoryInvalidInputError:
 		return nil, types.NewErrInvalidInput(response.Message)
 	default:
-		return nil, fmt.Errorf("unexpected response type: %T", result.CreateGrantCategory)
+		return nil, unexpectedResponse("CreateGrantCategory", result.CreateGrantCategory)
 	}
 }
 
@@ -49,7 +61,7 @@ func (a *GrantCategoryClient) CreateGrantCategory(ctx context.Context, category
 func (a *GrantCategoryClient) UpdateGrantCategory(ctx context.Context, id string, category types.GrantCategoryInput) (*types.GrantCategoryDetails, error) {
 	result, err := schema.UpdateGrantCategory(ctx, a.client, id, category)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	switch response := result.UpdateGrantCategory.(type) {
@@ -58,11 +70,11 @@ func (a *GrantCategoryClient) UpdateGrantCategory(ctx context.Context, id string
 	case *schema.UpdateGrantCategoryUpdateGrantCategoryPermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("updateGrantCategory", response.Message)
 	case *schema.UpdateGrantCategoryUpdateGrantCategoryNotFoundError:
-		return nil, types.NewErrNotFound(id, response.Typename, response.Message)
+		return nil, types.NewErrResourceNotFound("updateGrantCategory", "GrantCategory", id, response.Typename, response.Message)
 	case *schema.UpdateGrantCategoryUpdateGrantCategoryInvalidInputError:
 		return nil, types.NewErrInvalidInput(response.Message)
 	default:
-		return nil, fmt.Errorf("unexpected response type: %T", result.UpdateGrantCategory)
+		return nil, unexpectedResponse("UpdateGrantCategory", result.UpdateGrantCategory)
 	}
 }
 
@@ -72,7 +84,7 @@ func (a *GrantCategoryClient) UpdateGrantCategory(ctx context.Context, id string
 func (a *GrantCategoryClient) DeleteGrantCategory(ctx context.Context, id string) error {
 	result, err := schema.DeleteGrantCategory(ctx, a.client, id)
 	if err != nil {
-		return types.NewErrClient(err)
+		return clientError(err)
 	}
 
 	switch response := result.DeleteGrantCategory.(type) {
@@ -85,11 +97,11 @@ func (a *GrantCategoryClient) DeleteGrantCategory(ctx context.Context, id string
 	case *schema.DeleteGrantCategoryDeleteGrantCategoryPermissionDeniedError:
 		return types.NewErrPermissionDenied("deleteGrantCategory", response.Message)
 	case *schema.DeleteGrantCategoryDeleteGrantCategoryNotFoundError:
-		return types.NewErrNotFound(id, response.Typename, response.Message)
+		return types.NewErrResourceNotFound("deleteGrantCategory", "GrantCategory", id, response.Typename, response.Message)
 	case *schema.DeleteGrantCategoryDeleteGrantCategoryInvalidInputError:
 		return types.NewErrInvalidInput(response.Message)
 	default:
-		return fmt.Errorf("unexpected response type: %T", result.DeleteGrantCategory)
+		return unexpectedResponse("DeleteGrantCategory", result.DeleteGrantCategory)
 	}
 }
 
@@ -99,7 +111,7 @@ func (a *GrantCategoryClient) DeleteGrantCategory(ctx context.Context, id string
 func (a *GrantCategoryClient) GetGrantCategory(ctx context.Context, id string) (*types.GrantCategoryDetails, error) {
 	result, err := schema.GetGrantCategory(ctx, a.client, id)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	switch response := result.GrantCategory.(type) {
@@ -108,11 +120,11 @@ func (a *GrantCategoryClient) GetGrantCategory(ctx context.Context, id string) (
 	case *schema.GetGrantCategoryGrantCategoryPermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("getGrantCategory", response.Message)
 	case *schema.GetGrantCategoryGrantCategoryNotFoundError:
-		return nil, types.NewErrNotFound(id, response.Typename, response.Message)
+		return nil, types.NewErrResourceNotFound("getGrantCategory", "GrantCategory", id, response.Typename, response.Message)
 	case *schema.GetGrantCategoryGrantCategoryInvalidInputError:
 		return nil, types.NewErrInvalidInput(response.Message)
 	default:
-		return nil, fmt.Errorf("unexpected response type: %T", result.GetGrantCategory)
+		return nil, unexpectedResponse("GetGrantCategory", result.GetGrantCategory)
 	}
 }
 
@@ -122,7 +134,7 @@ func (a *GrantCategoryClient) GetGrantCategory(ctx context.Context, id string) (
 func (a *GrantCategoryClient) ListGrantCategories(ctx context.Context) ([]types.GrantCategoryDetails, error) {
 	result, err := schema.ListGrantCategories(ctx, a.client)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	grantCategories := make([]types.GrantCategoryDetails, 0, len(result.GrantCategories))
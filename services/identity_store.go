@@ -2,7 +2,6 @@ package services
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/Khan/genqlient/graphql"
 	"github.com/aws/smithy-go/ptr"
@@ -16,32 +15,45 @@ type IdentityStoreClient struct {
 	client graphql.Client
 }
 
-func NewIdentityStoreClient(client graphql.Client) IdentityStoreClient {
+func NewIdentityStoreClient(client graphql.Client, ops ...func(options *ClientOptions)) IdentityStoreClient {
 	return IdentityStoreClient{
-		client: client,
+		client: newGraphqlClient(client, ops...),
 	}
 }
 
+// IdentityStoreService is implemented by IdentityStoreClient.
+// It can be used to depend on an abstraction of the IdentityStoreClient, e.g. to substitute it in tests.
+type IdentityStoreService interface {
+	CreateIdentityStore(ctx context.Context, is types.IdentityStoreInput) (*types.IdentityStore, error)
+	UpdateIdentityStore(ctx context.Context, id string, is types.IdentityStoreInput) (*types.IdentityStore, error)
+	DeleteIdentityStore(ctx context.Context, id string) error
+	UpdateIdentityStoreMasterFlag(ctx context.Context, id string, master bool) (*types.IdentityStore, error)
+	GetIdentityStore(ctx context.Context, id string) (*types.IdentityStore, error)
+	ListIdentityStores(ctx context.Context, ops ...func(options *ListIdentityStoresOptions)) <-chan types.ListItem[types.IdentityStore]
+}
+
+var _ IdentityStoreService = (*IdentityStoreClient)(nil)
+
 // CreateIdentityStore creates a new IdentityStore for a given DataSource.
 // Returns the newly created IdentityStore if successful.
 // Otherwise, returns an error.
 func (c *IdentityStoreClient) CreateIdentityStore(ctx context.Context, is types.IdentityStoreInput) (*types.IdentityStore, error) {
 	result, err := schema.CreateIdentityStore(ctx, c.client, is)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	switch response := result.CreateIdentityStore.(type) {
 	case *types.CreateIdentityStoreCreateIdentityStore:
 		return &response.IdentityStore, nil
 	case *types.CreateIdentityStoreCreateIdentityStoreNotFoundError:
-		return nil, types.NewErrNotFound("", response.Typename, response.Message)
+		return nil, types.NewErrResourceNotFound("createIdentityStore", "IdentityStore", "", response.Typename, response.Message)
 	case *types.CreateIdentityStoreCreateIdentityStorePermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("createIdentityStore", response.Message)
 	case *types.CreateIdentityStoreCreateIdentityStoreAlreadyExistsError:
 		return nil, types.NewErrAlreadyExists("identityStore", response.Message)
 	default:
-		return nil, fmt.Errorf("unexpected type '%T'", response)
+		return nil, unexpectedResponse("CreateIdentityStore", response)
 	}
 }
 
@@ -51,7 +63,7 @@ func (c *IdentityStoreClient) CreateIdentityStore(ctx context.Context, is types.
 func (c *IdentityStoreClient) UpdateIdentityStore(ctx context.Context, id string, is types.IdentityStoreInput) (*types.IdentityStore, error) {
 	result, err := schema.UpdateIdentityStore(ctx, c.client, id, is)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	switch response := result.UpdateIdentityStore.(type) {
@@ -60,11 +72,11 @@ func (c *IdentityStoreClient) UpdateIdentityStore(ctx context.Context, id string
 	case *types.UpdateIdentityStoreUpdateIdentityStoreAlreadyExistsError:
 		return nil, types.NewErrAlreadyExists("identityStore", response.Message)
 	case *types.UpdateIdentityStoreUpdateIdentityStoreNotFoundError:
-		return nil, types.NewErrNotFound(id, response.Typename, response.Message)
+		return nil, types.NewErrResourceNotFound("updateIdentityStore", "IdentityStore", id, response.Typename, response.Message)
 	case *types.UpdateIdentityStoreUpdateIdentityStorePermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("updateIdentityStore", response.Message)
 	default:
-		return nil, fmt.Errorf("unexpected type '%T'", response)
+		return nil, unexpectedResponse("UpdateIdentityStore", response)
 	}
 }
 
@@ -74,7 +86,7 @@ func (c *IdentityStoreClient) UpdateIdentityStore(ctx context.Context, id string
 func (c *IdentityStoreClient) DeleteIdentityStore(ctx context.Context, id string) error {
 	result, err := schema.DeleteIdentityStore(ctx, c.client, id)
 	if err != nil {
-		return types.NewErrClient(err)
+		return clientError(err)
 	}
 
 	switch response := result.DeleteIdentityStore.(type) {
@@ -83,7 +95,7 @@ func (c *IdentityStoreClient) DeleteIdentityStore(ctx context.Context, id string
 	case *types.DeleteIdentityStoreDeleteIdentityStorePermissionDeniedError:
 		return types.NewErrPermissionDenied("deleteIdentityStore", response.Message)
 	default:
-		return fmt.Errorf("unexpected type '%T'", response)
+		return unexpectedResponse("DeleteIdentityStore", response)
 	}
 }
 
@@ -93,7 +105,7 @@ func (c *IdentityStoreClient) DeleteIdentityStore(ctx context.Context, id string
 func (c *IdentityStoreClient) UpdateIdentityStoreMasterFlag(ctx context.Context, id string, master bool) (*types.IdentityStore, error) {
 	result, err := schema.UpdateIdentityStoreMasterFlag(ctx, c.client, id, master)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	switch response := result.UpdateIdentityStoreMasterFlag.(type) {
@@ -102,11 +114,11 @@ func (c *IdentityStoreClient) UpdateIdentityStoreMasterFlag(ctx context.Context,
 	case *types.UpdateIdentityStoreMasterFlagUpdateIdentityStoreMasterFlagAlreadyExistsError:
 		return nil, types.NewErrAlreadyExists("identityStore", response.Message)
 	case *types.UpdateIdentityStoreMasterFlagUpdateIdentityStoreMasterFlagNotFoundError:
-		return nil, types.NewErrNotFound(id, response.Typename, response.Message)
+		return nil, types.NewErrResourceNotFound("updateIdentityStore", "IdentityStore", id, response.Typename, response.Message)
 	case *types.UpdateIdentityStoreMasterFlagUpdateIdentityStoreMasterFlagPermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("updateIdentityStore", response.Message)
 	default:
-		return nil, fmt.Errorf("unexpected type '%T'", response)
+		return nil, unexpectedResponse("UpdateIdentityStoreMasterFlag", response)
 	}
 }
 
@@ -116,7 +128,7 @@ func (c *IdentityStoreClient) UpdateIdentityStoreMasterFlag(ctx context.Context,
 func (c *IdentityStoreClient) GetIdentityStore(ctx context.Context, id string) (*types.IdentityStore, error) {
 	result, err := schema.GetIdentityStore(ctx, c.client, id)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	switch response := result.IdentityStore.(type) {
@@ -127,15 +139,17 @@ func (c *IdentityStoreClient) GetIdentityStore(ctx context.Context, id string) (
 	case *types.GetIdentityStoreIdentityStorePermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("getIdentityStore", response.Message)
 	case *types.GetIdentityStoreIdentityStoreNotFoundError:
-		return nil, types.NewErrNotFound(id, response.Typename, response.Message)
+		return nil, types.NewErrResourceNotFound("getIdentityStore", "IdentityStore", id, response.Typename, response.Message)
 	default:
-		return nil, fmt.Errorf("unexpected type '%T'", response)
+		return nil, unexpectedResponse("GetIdentityStore", response)
 	}
 }
 
 type ListIdentityStoresOptions struct {
-	order  []schema.IdentityStoreOrderByInput
-	filter *schema.IdentityStoreFilterInput
+	order    []schema.IdentityStoreOrderByInput
+	filter   *schema.IdentityStoreFilterInput
+	search   *string
+	pageSize int
 }
 
 // WithListIdentityStoresOrder sets the order of the returned IdentityStores in the ListIdentityStores call.
@@ -152,21 +166,42 @@ func WithListIdentityStoresFilter(input *schema.IdentityStoreFilterInput) func(o
 	}
 }
 
+// WithListIdentityStoresSearch sets the search query of the returned IdentityStores in the ListIdentityStores call.
+func WithListIdentityStoresSearch(search string) func(options *ListIdentityStoresOptions) {
+	return func(options *ListIdentityStoresOptions) {
+		options.search = &search
+	}
+}
+
+// WithListIdentityStoresPageSize sets the number of IdentityStores fetched per request in the ListIdentityStores call.
+// The page size should be between 1 and 1000.
+func WithListIdentityStoresPageSize(pageSize int) func(options *ListIdentityStoresOptions) {
+	return func(options *ListIdentityStoresOptions) {
+		options.pageSize = pageSize
+	}
+}
+
 // ListIdentityStores returns a list of IdentityStores for a given DataSource.
 // The order of the list can be specified with WithListIdentityStoresOrder.
 // A filter can be specified with WithListIdentityStoresFilter.
+// A search query can be specified with WithListIdentityStoresSearch.
+// The page size can be specified with WithListIdentityStoresPageSize.
 // A channel is returned that can be used to receive the list of IdentityStores.
 // To close the channel ensure to cancel the context.
 func (c *IdentityStoreClient) ListIdentityStores(ctx context.Context, ops ...func(options *ListIdentityStoresOptions)) <-chan types.ListItem[types.IdentityStore] {
-	options := ListIdentityStoresOptions{}
+	options := ListIdentityStoresOptions{pageSize: internal.MaxPageSize}
 	for _, op := range ops {
 		op(&options)
 	}
 
+	if err := internal.ValidatePageSize(options.pageSize); err != nil {
+		return internal.ErrorChannel[types.IdentityStore](err)
+	}
+
 	loadPageFn := func(ctx context.Context, cursor *string) (*types.PageInfo, []types.IdentityStorePageEdgesEdge, error) {
-		output, err := schema.ListIdentityStores(ctx, c.client, cursor, ptr.Int(internal.MaxPageSize), nil, options.filter, options.order)
+		output, err := schema.ListIdentityStores(ctx, c.client, cursor, ptr.Int(options.pageSize), options.search, options.filter, options.order)
 		if err != nil {
-			return nil, nil, types.NewErrClient(err)
+			return nil, nil, clientError(err)
 		}
 
 		switch page := output.IdentityStores.(type) {
@@ -175,7 +210,7 @@ func (c *IdentityStoreClient) ListIdentityStores(ctx context.Context, ops ...fun
 		case *schema.ListIdentityStoresIdentityStoresPermissionDeniedError:
 			return nil, nil, types.NewErrPermissionDenied("listIdentityStores", page.Message)
 		default:
-			return nil, nil, fmt.Errorf("unexpected type '%T'", page)
+			return nil, nil, unexpectedResponse("ListIdentityStores", page)
 		}
 	}
 
@@ -186,7 +221,10 @@ func (c *IdentityStoreClient) ListIdentityStores(ctx context.Context, ops ...fun
 			return cursor, nil, nil
 		}
 
-		listItem := (*edge.Node).(*types.IdentityStorePageEdgesEdgeNodeIdentityStore)
+		listItem, err := internal.DecodeEdgeNode[*types.IdentityStorePageEdgesEdgeNodeIdentityStore](*edge.Node)
+		if err != nil {
+			return cursor, nil, err
+		}
 
 		return cursor, &listItem.IdentityStore, nil
 	}
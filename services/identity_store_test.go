@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/types"
+)
+
+func TestIdentityStoreClient_ListIdentityStores(t *testing.T) {
+	const page = `{"identityStores": {"__typename": "PagedResult", "pageInfo": {"hasNextPage": false}, "edges": [
+		{"cursor": "1", "node": {"__typename": "IdentityStore", "id": "is1", "name": "identity store 1"}}
+	]}}`
+
+	t.Run("Page size and search", func(t *testing.T) {
+		mockClient := &mockGraphqlClient{responses: []string{page}}
+		client := NewIdentityStoreClient(mockClient)
+
+		items := collectItems(t, client.ListIdentityStores(context.Background(), WithListIdentityStoresPageSize(50), WithListIdentityStoresSearch("okta")))
+
+		require.Len(t, items, 1)
+		assert.Equal(t, "is1", items[0].Id)
+		assert.Equal(t, float64(50), mockClient.variables(t, 0)["limit"])
+		assert.Equal(t, "okta", mockClient.variables(t, 0)["search"])
+	})
+
+	t.Run("Invalid page size", func(t *testing.T) {
+		for _, pageSize := range []int{0, 1001} {
+			mockClient := &mockGraphqlClient{}
+			client := NewIdentityStoreClient(mockClient)
+
+			listItem, ok := <-client.ListIdentityStores(context.Background(), WithListIdentityStoresPageSize(pageSize))
+
+			require.True(t, ok)
+			assert.ErrorIs(t, listItem.GetError(), &types.ErrInvalidInput{})
+			assert.Empty(t, mockClient.requests)
+		}
+	})
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+
+	"github.com/raito-io/sdk-go/internal"
+)
+
+// MetricsObserver is notified after each GraphQL round trip, e.g. to expose Prometheus metrics.
+type MetricsObserver = internal.MetricsObserver
+
+// RetryClassifier decides whether a request is retried after a failed attempt, and how long to wait before the next attempt.
+type RetryClassifier = internal.RetryClassifier
+
+// DefaultRetryClassifier returns a RetryClassifier retrying network errors and HTTP 502, 503 and 504 responses after a fixed backoff.
+// It can be used as fallback in a custom RetryClassifier.
+func DefaultRetryClassifier(backoff time.Duration) RetryClassifier {
+	return internal.DefaultRetryClassifier(backoff)
+}
+
+// ExponentialRetryClassifier returns the RetryClassifier used by WithRetry, retrying network errors and HTTP 502, 503 and 504 responses.
+// The delay before a retry is chosen randomly between 0 and base * 2^(attempt-1), capped at maxDelay.
+// It can be used as fallback in a custom RetryClassifier.
+func ExponentialRetryClassifier(base, maxDelay time.Duration) RetryClassifier {
+	return internal.ExponentialRetryClassifier(base, maxDelay)
+}
+
+// GraphQLInvoker executes a GraphQL request by passing it to the next graphql.Client in the chain.
+type GraphQLInvoker = internal.GraphQLInvoker
+
+// GraphQLInterceptor is called for each GraphQL request and must call next to execute it.
+// It can inspect or modify the context, the request and the response, e.g. to add tracing or to strip fields.
+type GraphQLInterceptor = internal.GraphQLInterceptor
+
+// ClientOptions options for creating a service client.
+type ClientOptions struct {
+	retryMaxAttempts int
+	retryBackoff     time.Duration
+	retryMaxBackoff  time.Duration
+	retryMutations   bool
+	retryClassifier  RetryClassifier
+	operationTimeout time.Duration
+	logger           *slog.Logger
+	metricsObserver  MetricsObserver
+	middlewares      []func(client graphql.Client) graphql.Client
+	interceptors     []GraphQLInterceptor
+}
+
+// WithRetry can be used to retry requests failing with a transient error, such as a network error or an HTTP 502, 503 or 504 response.
+// A request is executed at most maxAttempts times. No retry is attempted if it cannot be executed before the context deadline.
+// The delay between attempts grows exponentially from backoff with full jitter, capped at 30 seconds unless specified otherwise with WithBackoff.
+// For list methods, a failed page is retried after the cursor of the last loaded page, so a connection reset while listing does not restart the list or return items twice.
+// Only queries are retried, unless WithRetryMutations is specified.
+func WithRetry(maxAttempts int, backoff time.Duration) func(options *ClientOptions) {
+	return func(options *ClientOptions) {
+		options.retryMaxAttempts = maxAttempts
+		options.retryBackoff = backoff
+	}
+}
+
+// WithBackoff can be used in combination with WithRetry to tune the delay between attempts.
+// The delay before a retry is chosen randomly between 0 and base * 2^(attempt-1), capped at maxDelay.
+// The random jitter prevents clients that failed at the same time from retrying at the same time.
+func WithBackoff(base, maxDelay time.Duration) func(options *ClientOptions) {
+	return func(options *ClientOptions) {
+		options.retryBackoff = base
+		options.retryMaxBackoff = maxDelay
+	}
+}
+
+// WithRetryMutations can be used in combination with WithRetry to also retry mutations.
+// Only use this option if all mutations executed by the client are idempotent.
+func WithRetryMutations() func(options *ClientOptions) {
+	return func(options *ClientOptions) {
+		options.retryMutations = true
+	}
+}
+
+// WithRetryClassifier can be used to decide which failed requests are retried and how long to wait before the next attempt.
+// The classifier is called after each failed attempt. If WithRetry is also specified, a request is executed at most maxAttempts times and the backoff of WithRetry is ignored.
+// Only queries are retried, unless WithRetryMutations is specified.
+func WithRetryClassifier(classifier RetryClassifier) func(options *ClientOptions) {
+	return func(options *ClientOptions) {
+		options.retryClassifier = classifier
+	}
+}
+
+// WithOperationTimeout can be used to limit the duration of each GraphQL operation, including retries.
+// If the context passed to a method already has an earlier deadline, that deadline is used instead.
+// For list methods, the timeout applies to each page separately, not to the whole list.
+func WithOperationTimeout(timeout time.Duration) func(options *ClientOptions) {
+	return func(options *ClientOptions) {
+		options.operationTimeout = timeout
+	}
+}
+
+// WithLogger can be used to log each executed GraphQL operation at debug level, including its duration and error class.
+// Operation variables are never logged.
+func WithLogger(logger *slog.Logger) func(options *ClientOptions) {
+	return func(options *ClientOptions) {
+		options.logger = logger
+	}
+}
+
+// WithMetricsObserver can be used to observe the name, duration and error of each GraphQL round trip.
+// Retried requests are observed once per attempt.
+func WithMetricsObserver(observer MetricsObserver) func(options *ClientOptions) {
+	return func(options *ClientOptions) {
+		options.metricsObserver = observer
+	}
+}
+
+// WithMiddleware can be used to wrap the graphql.Client used by the service client, e.g. to add tracing.
+// Middlewares are applied in the given order, so the last middleware is the outermost one.
+func WithMiddleware(middlewares ...func(client graphql.Client) graphql.Client) func(options *ClientOptions) {
+	return func(options *ClientOptions) {
+		options.middlewares = append(options.middlewares, middlewares...)
+	}
+}
+
+// WithGraphQLInterceptor can be used to inspect or modify each GraphQL request sent by the service client.
+// Interceptors are applied in the given order, so the last interceptor is the outermost one and is called first.
+// Unlike middlewares, interceptors are applied below the retry and the operation timeout.
+// They are called once per attempt with the context of that attempt, so retried requests pass the interceptors multiple times.
+func WithGraphQLInterceptor(interceptors ...GraphQLInterceptor) func(options *ClientOptions) {
+	return func(options *ClientOptions) {
+		options.interceptors = append(options.interceptors, interceptors...)
+	}
+}
+
+func newGraphqlClient(client graphql.Client, ops ...func(options *ClientOptions)) graphql.Client {
+	options := ClientOptions{}
+	for _, op := range ops {
+		op(&options)
+	}
+
+	client = internal.NewValidationClient(client)
+
+	if options.metricsObserver != nil {
+		client = internal.NewMetricsClient(client, options.metricsObserver)
+	}
+
+	for _, interceptor := range options.interceptors {
+		client = internal.NewInterceptorClient(client, interceptor)
+	}
+
+	if options.retryClassifier != nil {
+		client = internal.NewRetryClientWithClassifier(client, options.retryMaxAttempts, options.retryClassifier, options.retryMutations)
+	} else if options.retryMaxAttempts > 1 {
+		maxBackoff := options.retryMaxBackoff
+		if maxBackoff <= 0 {
+			maxBackoff = internal.DefaultMaxRetryBackoff
+		}
+
+		client = internal.NewRetryClientWithClassifier(client, options.retryMaxAttempts, internal.ExponentialRetryClassifier(options.retryBackoff, maxBackoff), options.retryMutations)
+	}
+
+	if options.operationTimeout > 0 {
+		client = internal.NewTimeoutClient(client, options.operationTimeout)
+	}
+
+	if options.logger != nil {
+		client = internal.NewLoggingClient(client, options.logger)
+	}
+
+	for _, middleware := range options.middlewares {
+		client = middleware(client)
+	}
+
+	client = internal.NewCorrelationClient(client)
+
+	return client
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingMiddleware struct {
+	name   string
+	client graphql.Client
+	calls  *[]string
+}
+
+func (m *recordingMiddleware) MakeRequest(ctx context.Context, req *graphql.Request, resp *graphql.Response) error {
+	*m.calls = append(*m.calls, m.name)
+
+	return m.client.MakeRequest(ctx, req, resp)
+}
+
+func TestWithMiddleware(t *testing.T) {
+	var calls []string
+
+	middleware := func(name string) func(client graphql.Client) graphql.Client {
+		return func(client graphql.Client) graphql.Client {
+			return &recordingMiddleware{name: name, client: client, calls: &calls}
+		}
+	}
+
+	mock := &mockGraphqlClient{responses: []string{`{"currentUser":{"id":"user-1"}}`}}
+	client := NewUserClient(mock, WithMiddleware(middleware("inner")), WithMiddleware(middleware("outer")))
+
+	user, err := client.GetCurrentUser(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "user-1", user.Id)
+	assert.Equal(t, []string{"outer", "inner"}, calls)
+	assert.Len(t, mock.requests, 1)
+}
+
+func TestWithGraphQLInterceptor(t *testing.T) {
+	var calls []string
+
+	interceptor := func(name string) GraphQLInterceptor {
+		return func(ctx context.Context, req *graphql.Request, resp *graphql.Response, next GraphQLInvoker) error {
+			calls = append(calls, name+":"+req.OpName)
+
+			return next(ctx, req, resp)
+		}
+	}
+
+	mock := &mockGraphqlClient{responses: []string{`{"currentUser":{"id":"user-1"}}`}}
+	client := NewUserClient(mock, WithGraphQLInterceptor(interceptor("inner"), interceptor("outer")))
+
+	user, err := client.GetCurrentUser(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "user-1", user.Id)
+	assert.Equal(t, []string{"outer:CurrentUser", "inner:CurrentUser"}, calls)
+	assert.Len(t, mock.requests, 1)
+
+	t.Run("Called once per attempt", func(t *testing.T) {
+		var attempts []context.Context
+
+		flakyClient := &flakyGraphqlClient{client: &mockGraphqlClient{responses: []string{`{"currentUser":{"id":"user-1"}}`}}, failAt: 0, err: syscall.ECONNRESET}
+		client := NewUserClient(flakyClient, WithRetry(3, time.Millisecond), WithOperationTimeout(time.Minute), WithGraphQLInterceptor(func(ctx context.Context, req *graphql.Request, resp *graphql.Response, next GraphQLInvoker) error {
+			attempts = append(attempts, ctx)
+
+			return next(ctx, req, resp)
+		}))
+
+		user, err := client.GetCurrentUser(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", user.Id)
+		assert.Len(t, flakyClient.requests, 2)
+		require.Len(t, attempts, 2)
+
+		// The interceptor sees the context with the operation timeout, not the context of the caller
+		_, hasDeadline := attempts[0].Deadline()
+		assert.True(t, hasDeadline)
+	})
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/Khan/genqlient/graphql"
+
+	"github.com/raito-io/sdk-go/internal"
+	"github.com/raito-io/sdk-go/types"
+)
+
+var operationNameRegex = regexp.MustCompile(`^\s*(?:query|mutation)\s+(\w+)`)
+
+// RawClient can be used to execute custom GraphQL operations that are not covered by the other clients.
+type RawClient struct {
+	client graphql.Client
+}
+
+func NewRawClient(client graphql.Client, ops ...func(options *ClientOptions)) RawClient {
+	return RawClient{
+		client: newGraphqlClient(client, ops...),
+	}
+}
+
+// RawService is implemented by RawClient.
+// It can be used to depend on an abstraction of the RawClient, e.g. to substitute it in tests.
+type RawService interface {
+	Client() graphql.Client
+	Query(ctx context.Context, query string, vars map[string]any, out any) error
+}
+
+var _ RawService = (*RawClient)(nil)
+
+// Client returns the underlying graphql.Client, which can be used with other genqlient generated operations.
+func (c *RawClient) Client() graphql.Client {
+	return c.client
+}
+
+// Query executes the given GraphQL query or mutation with the given variables and unmarshals the data of the response into out.
+// The operation name is derived from the query, if it is named.
+func (c *RawClient) Query(ctx context.Context, query string, vars map[string]any, out any) error {
+	req := &graphql.Request{
+		Query:     query,
+		Variables: vars,
+	}
+
+	if match := operationNameRegex.FindStringSubmatch(query); match != nil {
+		req.OpName = match[1]
+	}
+
+	err := c.client.MakeRequest(ctx, req, &graphql.Response{Data: out})
+	if err != nil {
+		return clientError(err)
+	}
+
+	return nil
+}
+
+// PaginationOptions options for Paginate.
+type PaginationOptions = internal.PaginationOptions
+
+// WithPaginationPrefetch can be used to load up to depth pages ahead of the consumer. The order of the items is preserved.
+func WithPaginationPrefetch(depth int) func(options *PaginationOptions) {
+	return internal.WithPaginationPrefetch(depth)
+}
+
+// WithPaginationBufferSize can be used to buffer up to size items in the returned channel.
+func WithPaginationBufferSize(size int) func(options *PaginationOptions) {
+	return internal.WithPaginationBufferSize(size)
+}
+
+// WithPaginationStartCursor can be used to resume listing after the cursor of a previously received ListItem.
+func WithPaginationStartCursor(cursor *string) func(options *PaginationOptions) {
+	return internal.WithPaginationStartCursor(cursor)
+}
+
+// WithPaginationProgress can be used to be notified of the PageInfo of each loaded page.
+func WithPaginationProgress(progressFn func(pageInfo types.PageInfo)) func(options *PaginationOptions) {
+	return internal.WithPaginationProgress(progressFn)
+}
+
+// WithPaginationStrictDecode can be used to return a types.ErrUnexpectedEdge if edgeFn fails to decode an edge, instead of skipping the edge.
+func WithPaginationStrictDecode() func(options *PaginationOptions) {
+	return internal.WithPaginationStrictDecode(true)
+}
+
+// WithPaginationAdaptivePageSize can be used to load initial items in the first page and to double the page size for each next page, up to maxPageSize.
+// loadPageFn should request the page size returned by PageSize for the page it loads.
+func WithPaginationAdaptivePageSize(initial, maxPageSize int) func(options *PaginationOptions) {
+	return internal.WithPaginationAdaptivePageSize(initial, maxPageSize)
+}
+
+// PageSize returns the page size loadPageFn should request for the page being loaded, as set by WithPaginationAdaptivePageSize.
+// If WithPaginationAdaptivePageSize is not specified, pageSize is returned.
+func PageSize(ctx context.Context, pageSize int) int {
+	return internal.PageSize(ctx, pageSize)
+}
+
+// DecodeEdgeNode can be used in edgeFn to get the node of an edge as N. A types.ErrUnexpectedEdge is returned if the node is not of type N.
+func DecodeEdgeNode[N any, I any](node I) (N, error) {
+	return internal.DecodeEdgeNode[N](node)
+}
+
+// Paginate walks a cursor paginated GraphQL connection, using the same machinery as the list methods of the other clients.
+// This is an advanced API, intended to be used together with RawClient for connections that are not covered by the SDK.
+//
+// loadPageFn loads the page after the given cursor, which is nil for the first page, and returns its PageInfo and edges.
+// edgeFn returns the cursor and item of an edge. A nil item skips the edge, while its cursor is still used to load the next page.
+// Pages are loaded until PageInfo.HasNextPage is false. The page size can grow from page to page with WithPaginationAdaptivePageSize.
+//
+// Each item is sent on the returned channel as a ListItem carrying the cursor of its edge.
+// If loadPageFn or edgeFn returns an error, a ListItem carrying that error is sent as the last element before the channel is closed.
+// Edges for which edgeFn returns a types.ErrUnexpectedEdge are skipped, unless WithPaginationStrictDecode is specified.
+// To close the channel ensure to cancel the context.
+func Paginate[T any, E any](ctx context.Context, loadPageFn func(ctx context.Context, cursor *string) (*types.PageInfo, []E, error), edgeFn func(edge *E) (*string, *T, error), ops ...func(options *PaginationOptions)) <-chan types.ListItem[T] {
+	return internal.PaginationExecutor(ctx, loadPageFn, edgeFn, ops...)
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/types"
+)
+
+func TestRawClient_Query(t *testing.T) {
+	mockClient := &mockGraphqlClient{responses: []string{`{"accessProvider": {"id": "ap-1", "name": "ap 1"}}`}}
+	client := NewRawClient(mockClient)
+
+	var out struct {
+		AccessProvider struct {
+			Id   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"accessProvider"`
+	}
+
+	err := client.Query(context.Background(), "query CustomAccessProvider($id: ID!) { accessProvider(id: $id) { ... on AccessProvider { id name } } }", map[string]any{"id": "ap-1"}, &out)
+
+	require.NoError(t, err)
+	assert.Equal(t, "ap-1", out.AccessProvider.Id)
+	assert.Equal(t, "ap 1", out.AccessProvider.Name)
+
+	require.Len(t, mockClient.requests, 1)
+	assert.Equal(t, "CustomAccessProvider", mockClient.requests[0].OpName)
+	assert.Equal(t, "ap-1", mockClient.variables(t, 0)["id"])
+}
+
+func TestPaginate(t *testing.T) {
+	type edge struct {
+		Cursor string `json:"cursor"`
+		Node   struct {
+			Id string `json:"id"`
+		} `json:"node"`
+	}
+
+	mockClient := &mockGraphqlClient{responses: []string{
+		`{"items": {"pageInfo": {"hasNextPage": true}, "edges": [{"cursor": "1", "node": {"id": "a"}}, {"cursor": "2", "node": {"id": "b"}}]}}`,
+		`{"items": {"pageInfo": {"hasNextPage": false}, "edges": [{"cursor": "3", "node": {"id": "c"}}]}}`,
+	}}
+	client := NewRawClient(mockClient)
+
+	loadPageFn := func(ctx context.Context, cursor *string) (*types.PageInfo, []edge, error) {
+		var out struct {
+			Items struct {
+				PageInfo types.PageInfo `json:"pageInfo"`
+				Edges    []edge         `json:"edges"`
+			} `json:"items"`
+		}
+
+		err := client.Query(ctx, "query Items($after: String) { items(after: $after) { pageInfo { hasNextPage } edges { cursor node { id } } } }", map[string]any{"after": cursor}, &out)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return &out.Items.PageInfo, out.Items.Edges, nil
+	}
+
+	edgeFn := func(e *edge) (*string, *string, error) {
+		return &e.Cursor, &e.Node.Id, nil
+	}
+
+	items := collectItems(t, Paginate(context.Background(), loadPageFn, edgeFn))
+
+	assert.Equal(t, []string{"a", "b", "c"}, items)
+
+	require.Len(t, mockClient.requests, 2)
+	assert.Nil(t, mockClient.variables(t, 0)["after"])
+	assert.Equal(t, "2", mockClient.variables(t, 1)["after"])
+}
+
+func TestPaginate_AdaptivePageSize(t *testing.T) {
+	var pageSizes []int
+
+	loadPageFn := func(ctx context.Context, cursor *string) (*types.PageInfo, []string, error) {
+		pageSizes = append(pageSizes, PageSize(ctx, 100))
+
+		hasNextPage := len(pageSizes) < 4
+
+		return &types.PageInfo{HasNextPage: &hasNextPage}, []string{fmt.Sprintf("%d", len(pageSizes))}, nil
+	}
+
+	edgeFn := func(e *string) (*string, *string, error) {
+		return e, e, nil
+	}
+
+	collectItems(t, Paginate(context.Background(), loadPageFn, edgeFn))
+	assert.Equal(t, []int{100, 100, 100, 100}, pageSizes)
+
+	pageSizes = nil
+
+	collectItems(t, Paginate(context.Background(), loadPageFn, edgeFn, WithPaginationAdaptivePageSize(10, 25)))
+	assert.Equal(t, []int{10, 20, 25, 25}, pageSizes)
+}
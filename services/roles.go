@@ -3,7 +3,6 @@ package services
 import (
 	"context"
 	"errors"
-	"fmt"
 
 	"github.com/Khan/genqlient/graphql"
 	"github.com/aws/smithy-go/ptr"
@@ -17,18 +16,48 @@ type RoleClient struct {
 	client graphql.Client
 }
 
-func NewRoleClient(client graphql.Client) RoleClient {
+func NewRoleClient(client graphql.Client, ops ...func(options *ClientOptions)) RoleClient {
 	return RoleClient{
-		client: client,
-	}
-}
+		client: newGraphqlClient(client, ops...),
+	}
+}
+
+// RoleService is implemented by RoleClient.
+// It can be used to depend on an abstraction of the RoleClient, e.g. to substitute it in tests.
+type RoleService interface {
+	GetRole(ctx context.Context, id string) (*types.Role, error)
+	ListRoles(ctx context.Context, ops ...func(*RoleListOptions)) <-chan types.ListItem[types.Role]
+	ListRoleAssignments(ctx context.Context, ops ...func(*RoleAssignmentListOptions)) <-chan types.ListItem[types.RoleAssignment]
+	ListRoleAssignmentsOnIdentityStore(ctx context.Context, identityId string, ops ...func(*RoleAssignmentListOptions)) <-chan types.ListItem[types.RoleAssignment]
+	ListRoleAssignmentsOnDataObject(ctx context.Context, objectId string, ops ...func(*RoleAssignmentListOptions)) <-chan types.ListItem[types.RoleAssignment]
+	ListRoleAssignmentsOnDataSource(ctx context.Context, dataSourceId string, ops ...func(*RoleAssignmentListOptions)) <-chan types.ListItem[types.RoleAssignment]
+	ListRoleAssignmentsOnAccessProvider(ctx context.Context, accessProviderId string, ops ...func(*RoleAssignmentListOptions)) <-chan types.ListItem[types.RoleAssignment]
+	ListRoleAssignmentsOnUser(ctx context.Context, userId string, ops ...func(*RoleAssignmentListOptions)) <-chan types.ListItem[types.RoleAssignment]
+	AssignRoleOnIdentityStore(ctx context.Context, roleId string, isId string, to ...string) (*types.Role, error)
+	AssignRoleOnDataObject(ctx context.Context, roleId string, doId string, to ...string) (*types.Role, error)
+	AssignRoleOnDataSource(ctx context.Context, roleId string, dataSourceId string, to ...string) (*types.Role, error)
+	AssignRoleOnAccessProvider(ctx context.Context, roleId string, accessProviderId string, to ...string) (*types.Role, error)
+	AssignGlobalRole(ctx context.Context, roelId string, to ...string) (*types.Role, error)
+	UnassignRoleFromIdentityStore(ctx context.Context, roleId string, isId string, from ...string) (*types.Role, error)
+	UnassignRoleFromDataObject(ctx context.Context, roleId string, doId string, from ...string) (*types.Role, error)
+	UnassignRoleFromDataSource(ctx context.Context, roleId string, dataSourceId string, from ...string) (*types.Role, error)
+	UnassignRoleFromAccessProvider(ctx context.Context, roleId string, accessProviderId string, from ...string) (*types.Role, error)
+	UnassignGlobalRole(ctx context.Context, roleId string, from ...string) (*types.Role, error)
+	UpdateRoleAssigneesOnIdentityStore(ctx context.Context, isId string, roleId string, assignees ...string) (*types.Role, error)
+	UpdateRoleAssigneesOnDataObject(ctx context.Context, doId string, roleId string, assignees ...string) (*types.Role, error)
+	UpdateRoleAssigneesOnDataSource(ctx context.Context, dataSourceId string, roleId string, assignees ...string) (*types.Role, error)
+	UpdateRoleAssigneesOnAccessProvider(ctx context.Context, accessProviderId string, roleId string, assignees ...string) (*types.Role, error)
+	SetGlobalRoleForUsers(ctx context.Context, roleId string, assignees ...string) error
+}
+
+var _ RoleService = (*RoleClient)(nil)
 
 // GetRole returns a role by ID
 // Returns a Role if role is retrieved successfully, otherwise returns an error.
 func (c *RoleClient) GetRole(ctx context.Context, id string) (*types.Role, error) {
 	result, err := schema.GetRole(ctx, c.client, id)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	return &result.Role.Role, nil
@@ -67,7 +96,7 @@ func (c *RoleClient) ListRoles(ctx context.Context, ops ...func(*RoleListOptions
 	loadPageFn := func(ctx context.Context, cursor *string) (*types.PageInfo, []types.RolePageEdgesEdge, error) {
 		output, err := schema.ListRoles(ctx, c.client, cursor, ptr.Int(internal.MaxPageSize), options.filter, options.order)
 		if err != nil {
-			return nil, nil, types.NewErrClient(err)
+			return nil, nil, clientError(err)
 		}
 
 		return &output.Roles.PageInfo.PageInfo, output.Roles.Edges, nil
@@ -80,7 +109,10 @@ func (c *RoleClient) ListRoles(ctx context.Context, ops ...func(*RoleListOptions
 			return cursor, nil, nil
 		}
 
-		listItem := (*edge.Node).(*types.RolePageEdgesEdgeNodeRole)
+		listItem, err := internal.DecodeEdgeNode[*types.RolePageEdgesEdgeNodeRole](*edge.Node)
+		if err != nil {
+			return cursor, nil, err
+		}
 
 		return cursor, &listItem.Role, nil
 	}
@@ -121,7 +153,7 @@ func (c *RoleClient) ListRoleAssignments(ctx context.Context, ops ...func(*RoleA
 	loadPageFn := func(ctx context.Context, cursor *string) (*types.PageInfo, []types.RoleAssignmentPageEdgesEdge, error) {
 		output, err := schema.ListRoleAssignments(ctx, c.client, cursor, ptr.Int(internal.MaxPageSize), options.filter, options.order)
 		if err != nil {
-			return nil, nil, types.NewErrClient(err)
+			return nil, nil, clientError(err)
 		}
 
 		return &output.RoleAssignments.PageInfo.PageInfo, output.RoleAssignments.Edges, nil
@@ -144,7 +176,7 @@ func (c *RoleClient) ListRoleAssignmentsOnIdentityStore(ctx context.Context, ide
 	loadPageFn := func(ctx context.Context, cursor *string) (*types.PageInfo, []types.RoleAssignmentPageEdgesEdge, error) {
 		output, err := schema.ListRoleAssignmentsOnIdentityStore(ctx, c.client, identityId, cursor, ptr.Int(internal.MaxPageSize), options.filter, options.order)
 		if err != nil {
-			return nil, nil, types.NewErrClient(err)
+			return nil, nil, clientError(err)
 		}
 
 		switch is := output.IdentityStore.(type) {
@@ -153,11 +185,11 @@ func (c *RoleClient) ListRoleAssignmentsOnIdentityStore(ctx context.Context, ide
 		case *schema.ListRoleAssignmentsOnIdentityStoreIdentityStoreAlreadyExistsError:
 			return nil, nil, types.NewErrAlreadyExists("listRoleAssignmentsOnIdentityStore", is.Message)
 		case *schema.ListRoleAssignmentsOnIdentityStoreIdentityStoreNotFoundError:
-			return nil, nil, types.NewErrNotFound(identityId, is.Typename, is.Message)
+			return nil, nil, types.NewErrResourceNotFound("listRoleAssignmentsOnIdentityStore", "IdentityStore", identityId, is.Typename, is.Message)
 		case *schema.ListRoleAssignmentsOnIdentityStoreIdentityStorePermissionDeniedError:
 			return nil, nil, types.NewErrPermissionDenied("listRoleAssignmentsOnIdentityStore", is.Message)
 		default:
-			return nil, nil, fmt.Errorf("unexpected type '%T'", is)
+			return nil, nil, unexpectedResponse("ListRoleAssignmentsOnIdentityStore", is)
 		}
 	}
 
@@ -178,7 +210,7 @@ func (c *RoleClient) ListRoleAssignmentsOnDataObject(ctx context.Context, object
 	loadPageFn := func(ctx context.Context, cursor *string) (*types.PageInfo, []types.RoleAssignmentPageEdgesEdge, error) {
 		output, err := schema.ListRoleAssignmentsOnDataObject(ctx, c.client, objectId, cursor, ptr.Int(internal.MaxPageSize), options.filter, options.order)
 		if err != nil {
-			return nil, nil, types.NewErrClient(err)
+			return nil, nil, clientError(err)
 		}
 
 		return &output.DataObject.RoleAssignments.RoleAssignmentPage.PageInfo.PageInfo, output.DataObject.RoleAssignments.RoleAssignmentPage.Edges, nil
@@ -201,7 +233,7 @@ func (c *RoleClient) ListRoleAssignmentsOnDataSource(ctx context.Context, dataSo
 	loadPageFn := func(ctx context.Context, cursor *string) (*types.PageInfo, []types.RoleAssignmentPageEdgesEdge, error) {
 		output, err := schema.ListRoleAssignmentsOnDataSource(ctx, c.client, dataSourceId, cursor, ptr.Int(internal.MaxPageSize), options.filter, options.order)
 		if err != nil {
-			return nil, nil, types.NewErrClient(err)
+			return nil, nil, clientError(err)
 		}
 
 		switch ds := output.DataSource.(type) {
@@ -210,9 +242,9 @@ func (c *RoleClient) ListRoleAssignmentsOnDataSource(ctx context.Context, dataSo
 		case *schema.ListRoleAssignmentsOnDataSourceDataSourcePermissionDeniedError:
 			return nil, nil, types.NewErrPermissionDenied("listRoleAssignmentsOnDataSource", ds.Message)
 		case *schema.ListRoleAssignmentsOnDataSourceDataSourceNotFoundError:
-			return nil, nil, types.NewErrNotFound(dataSourceId, ds.Typename, ds.Message)
+			return nil, nil, types.NewErrResourceNotFound("listRoleAssignmentsOnDataSource", "DataSource", dataSourceId, ds.Typename, ds.Message)
 		default:
-			return nil, nil, fmt.Errorf("unexpected type '%T'", ds)
+			return nil, nil, unexpectedResponse("ListRoleAssignmentsOnDataSource", ds)
 		}
 	}
 
@@ -233,7 +265,7 @@ func (c *RoleClient) ListRoleAssignmentsOnAccessProvider(ctx context.Context, ac
 	loadPageFn := func(ctx context.Context, cursor *string) (*types.PageInfo, []types.RoleAssignmentPageEdgesEdge, error) {
 		output, err := schema.ListRoleAssignmentsOnAccessProvider(ctx, c.client, accessProviderId, cursor, ptr.Int(internal.MaxPageSize), options.filter, options.order)
 		if err != nil {
-			return nil, nil, types.NewErrClient(err)
+			return nil, nil, clientError(err)
 		}
 
 		switch ap := output.AccessProvider.(type) {
@@ -242,9 +274,9 @@ func (c *RoleClient) ListRoleAssignmentsOnAccessProvider(ctx context.Context, ac
 		case *schema.ListRoleAssignmentsOnAccessProviderAccessProviderPermissionDeniedError:
 			return nil, nil, types.NewErrPermissionDenied("listRoleAssignmentsOnAccessProvider", ap.Message)
 		case *schema.ListRoleAssignmentsOnAccessProviderAccessProviderNotFoundError:
-			return nil, nil, types.NewErrNotFound(accessProviderId, ap.Typename, ap.Message)
+			return nil, nil, types.NewErrResourceNotFound("listRoleAssignmentsOnAccessProvider", "AccessProvider", accessProviderId, ap.Typename, ap.Message)
 		default:
-			return nil, nil, fmt.Errorf("unexpected type '%T'", ap)
+			return nil, nil, unexpectedResponse("ListRoleAssignmentsOnAccessProvider", ap)
 		}
 	}
 
@@ -265,7 +297,7 @@ func (c *RoleClient) ListRoleAssignmentsOnUser(ctx context.Context, userId strin
 	loadPageFn := func(ctx context.Context, cursor *string) (*types.PageInfo, []types.RoleAssignmentPageEdgesEdge, error) {
 		output, err := schema.ListRoleAssignmentsOnUser(ctx, c.client, userId, cursor, ptr.Int(internal.MaxPageSize), options.filter, options.order)
 		if err != nil {
-			return nil, nil, types.NewErrClient(err)
+			return nil, nil, clientError(err)
 		}
 
 		switch r := output.User.(type) {
@@ -274,13 +306,13 @@ func (c *RoleClient) ListRoleAssignmentsOnUser(ctx context.Context, userId strin
 		case *schema.ListRoleAssignmentsOnUserUserPermissionDeniedError:
 			return nil, nil, types.NewErrPermissionDenied("listRoleAssignmentsOnUser", r.Message)
 		case *schema.ListRoleAssignmentsOnUserUserNotFoundError:
-			return nil, nil, types.NewErrNotFound(userId, r.Typename, r.Message)
+			return nil, nil, types.NewErrResourceNotFound("listRoleAssignmentsOnUser", "User", userId, r.Typename, r.Message)
 		case *schema.ListRoleAssignmentsOnUserUserInvalidEmailError:
 			return nil, nil, types.NewErrInvalidEmail(userId, r.Message)
 		case *schema.ListRoleAssignmentsOnUserUserInvalidInputError:
 			return nil, nil, types.NewErrInvalidInput(r.Message)
 		default:
-			return nil, nil, fmt.Errorf("unexpected type '%T'", r)
+			return nil, nil, unexpectedResponse("ListRoleAssignmentsOnUser", r)
 		}
 	}
 
@@ -294,7 +326,7 @@ func (c *RoleClient) ListRoleAssignmentsOnUser(ctx context.Context, userId strin
 func (c *RoleClient) AssignRoleOnIdentityStore(ctx context.Context, roleId string, isId string, to ...string) (*types.Role, error) {
 	output, err := schema.AssignRoleOnIdentityStore(ctx, c.client, roleId, isId, to)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	switch r := output.AssignRoleOnIdentityStore.(type) {
@@ -303,9 +335,9 @@ func (c *RoleClient) AssignRoleOnIdentityStore(ctx context.Context, roleId strin
 	case *schema.AssignRoleOnIdentityStoreAssignRoleOnIdentityStorePermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("assignRoleOnIdentityStore", r.Message)
 	case *schema.AssignRoleOnIdentityStoreAssignRoleOnIdentityStoreNotFoundError:
-		return nil, types.NewErrNotFound(isId, r.Typename, r.Message)
+		return nil, types.NewErrResourceNotFound("assignRoleOnIdentityStore", "IdentityStore", isId, r.Typename, r.Message)
 	default:
-		return nil, fmt.Errorf("unexpected type '%T'", r)
+		return nil, unexpectedResponse("AssignRoleOnIdentityStore", r)
 	}
 }
 
@@ -316,7 +348,7 @@ func (c *RoleClient) AssignRoleOnIdentityStore(ctx context.Context, roleId strin
 func (c *RoleClient) AssignRoleOnDataObject(ctx context.Context, roleId string, doId string, to ...string) (*types.Role, error) {
 	output, err := schema.AssignRoleOnDataObject(ctx, c.client, roleId, doId, to)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	switch r := output.AssignRoleOnDataObject.(type) {
@@ -325,9 +357,9 @@ func (c *RoleClient) AssignRoleOnDataObject(ctx context.Context, roleId string,
 	case *schema.AssignRoleOnDataObjectAssignRoleOnDataObjectPermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("assignRoleOnDataObject", r.Message)
 	case *schema.AssignRoleOnDataObjectAssignRoleOnDataObjectNotFoundError:
-		return nil, types.NewErrNotFound(doId, r.Typename, r.Message)
+		return nil, types.NewErrResourceNotFound("assignRoleOnDataObject", "DataObject", doId, r.Typename, r.Message)
 	default:
-		return nil, fmt.Errorf("unexpected type '%T'", r)
+		return nil, unexpectedResponse("AssignRoleOnDataObject", r)
 	}
 }
 
@@ -338,7 +370,7 @@ func (c *RoleClient) AssignRoleOnDataObject(ctx context.Context, roleId string,
 func (c *RoleClient) AssignRoleOnDataSource(ctx context.Context, roleId string, dataSourceId string, to ...string) (*types.Role, error) {
 	output, err := schema.AssignRoleOnDataSource(ctx, c.client, roleId, dataSourceId, to)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	switch r := output.AssignRoleOnDataSource.(type) {
@@ -347,9 +379,9 @@ func (c *RoleClient) AssignRoleOnDataSource(ctx context.Context, roleId string,
 	case *schema.AssignRoleOnDataSourceAssignRoleOnDataSourcePermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("assignRoleOnDataSource", r.Message)
 	case *schema.AssignRoleOnDataSourceAssignRoleOnDataSourceNotFoundError:
-		return nil, types.NewErrNotFound(dataSourceId, r.Typename, r.Message)
+		return nil, types.NewErrResourceNotFound("assignRoleOnDataSource", "DataSource", dataSourceId, r.Typename, r.Message)
 	default:
-		return nil, fmt.Errorf("unexpected type '%T'", r)
+		return nil, unexpectedResponse("AssignRoleOnDataSource", r)
 	}
 }
 
@@ -360,7 +392,7 @@ func (c *RoleClient) AssignRoleOnDataSource(ctx context.Context, roleId string,
 func (c *RoleClient) AssignRoleOnAccessProvider(ctx context.Context, roleId string, accessProviderId string, to ...string) (*types.Role, error) {
 	output, err := schema.AssignRoleOnAccessProvider(ctx, c.client, roleId, accessProviderId, to)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	switch r := output.AssignRoleOnAccessProvider.(type) {
@@ -369,9 +401,9 @@ func (c *RoleClient) AssignRoleOnAccessProvider(ctx context.Context, roleId stri
 	case *schema.AssignRoleOnAccessProviderAssignRoleOnAccessProviderPermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("assignRoleOnAccessProvider", r.Message)
 	case *schema.AssignRoleOnAccessProviderAssignRoleOnAccessProviderNotFoundError:
-		return nil, types.NewErrNotFound(accessProviderId, r.Typename, r.Message)
+		return nil, types.NewErrResourceNotFound("assignRoleOnAccessProvider", "AccessProvider", accessProviderId, r.Typename, r.Message)
 	default:
-		return nil, fmt.Errorf("unexpected type '%T'", r)
+		return nil, unexpectedResponse("AssignRoleOnAccessProvider", r)
 	}
 }
 
@@ -381,7 +413,7 @@ func (c *RoleClient) AssignRoleOnAccessProvider(ctx context.Context, roleId stri
 func (c *RoleClient) AssignGlobalRole(ctx context.Context, roelId string, to ...string) (*types.Role, error) {
 	output, err := schema.AssignGlobalRole(ctx, c.client, roelId, to)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	switch r := output.AssignGlobalRole.(type) {
@@ -390,9 +422,9 @@ func (c *RoleClient) AssignGlobalRole(ctx context.Context, roelId string, to ...
 	case *schema.AssignGlobalRoleAssignGlobalRolePermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("assignGlobalRole", r.Message)
 	case *schema.AssignGlobalRoleAssignGlobalRoleNotFoundError:
-		return nil, types.NewErrNotFound(roelId, r.Typename, r.Message)
+		return nil, types.NewErrResourceNotFound("assignGlobalRole", "Role", roelId, r.Typename, r.Message)
 	default:
-		return nil, fmt.Errorf("unexpected type '%T'", r)
+		return nil, unexpectedResponse("AssignGlobalRole", r)
 	}
 }
 
@@ -403,7 +435,7 @@ func (c *RoleClient) AssignGlobalRole(ctx context.Context, roelId string, to ...
 func (c *RoleClient) UnassignRoleFromIdentityStore(ctx context.Context, roleId string, isId string, from ...string) (*types.Role, error) {
 	output, err := schema.UnassignRoleFromIdentityStore(ctx, c.client, roleId, isId, from)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	switch r := output.UnassignRoleFromIdentityStore.(type) {
@@ -412,9 +444,9 @@ func (c *RoleClient) UnassignRoleFromIdentityStore(ctx context.Context, roleId s
 	case *schema.UnassignRoleFromIdentityStoreUnassignRoleFromIdentityStorePermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("unassignRoleFromIdentityStore", r.Message)
 	case *schema.UnassignRoleFromIdentityStoreUnassignRoleFromIdentityStoreNotFoundError:
-		return nil, types.NewErrNotFound(isId, r.Typename, r.Message)
+		return nil, types.NewErrResourceNotFound("unassignRoleFromIdentityStore", "IdentityStore", isId, r.Typename, r.Message)
 	default:
-		return nil, fmt.Errorf("unexpected type '%T'", r)
+		return nil, unexpectedResponse("UnassignRoleFromIdentityStore", r)
 	}
 }
 
@@ -425,7 +457,7 @@ func (c *RoleClient) UnassignRoleFromIdentityStore(ctx context.Context, roleId s
 func (c *RoleClient) UnassignRoleFromDataObject(ctx context.Context, roleId string, doId string, from ...string) (*types.Role, error) {
 	output, err := schema.UnassignRoleFromDataObject(ctx, c.client, roleId, doId, from)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	switch r := output.UnassignRoleFromDataObject.(type) {
@@ -434,9 +466,9 @@ func (c *RoleClient) UnassignRoleFromDataObject(ctx context.Context, roleId stri
 	case *schema.UnassignRoleFromDataObjectUnassignRoleFromDataObjectPermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("unassignRoleFromDataObject", r.Message)
 	case *schema.UnassignRoleFromDataObjectUnassignRoleFromDataObjectNotFoundError:
-		return nil, types.NewErrNotFound(doId, r.Typename, r.Message)
+		return nil, types.NewErrResourceNotFound("unassignRoleFromDataObject", "DataObject", doId, r.Typename, r.Message)
 	default:
-		return nil, fmt.Errorf("unexpected type '%T'", r)
+		return nil, unexpectedResponse("UnassignRoleFromDataObject", r)
 	}
 }
 
@@ -447,7 +479,7 @@ func (c *RoleClient) UnassignRoleFromDataObject(ctx context.Context, roleId stri
 func (c *RoleClient) UnassignRoleFromDataSource(ctx context.Context, roleId string, dataSourceId string, from ...string) (*types.Role, error) {
 	output, err := schema.UnassignRoleFromDataSource(ctx, c.client, roleId, dataSourceId, from)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	switch r := output.UnassignRoleFromDataSource.(type) {
@@ -456,9 +488,9 @@ func (c *RoleClient) UnassignRoleFromDataSource(ctx context.Context, roleId stri
 	case *schema.UnassignRoleFromDataSourceUnassignRoleFromDataSourcePermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("unassignRoleFromDataSource", r.Message)
 	case *schema.UnassignRoleFromDataSourceUnassignRoleFromDataSourceNotFoundError:
-		return nil, types.NewErrNotFound(dataSourceId, r.Typename, r.Message)
+		return nil, types.NewErrResourceNotFound("unassignRoleFromDataSource", "DataSource", dataSourceId, r.Typename, r.Message)
 	default:
-		return nil, fmt.Errorf("unexpected type '%T'", r)
+		return nil, unexpectedResponse("UnassignRoleFromDataSource", r)
 	}
 }
 
@@ -469,7 +501,7 @@ func (c *RoleClient) UnassignRoleFromDataSource(ctx context.Context, roleId stri
 func (c *RoleClient) UnassignRoleFromAccessProvider(ctx context.Context, roleId string, accessProviderId string, from ...string) (*types.Role, error) {
 	output, err := schema.UnassignRoleFromAccessProvider(ctx, c.client, roleId, accessProviderId, from)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	if output.UnassignRoleFromAccessProvider == nil {
@@ -482,9 +514,9 @@ func (c *RoleClient) UnassignRoleFromAccessProvider(ctx context.Context, roleId
 	case *schema.UnassignRoleFromAccessProviderUnassignRoleFromAccessProviderPermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("unassignRoleFromAccessProvider", r.Message)
 	case *schema.UnassignRoleFromAccessProviderUnassignRoleFromAccessProviderNotFoundError:
-		return nil, types.NewErrNotFound(accessProviderId, r.Typename, r.Message)
+		return nil, types.NewErrResourceNotFound("unassignRoleFromAccessProvider", "AccessProvider", accessProviderId, r.Typename, r.Message)
 	default:
-		return nil, fmt.Errorf("unexpected type '%T'", r)
+		return nil, unexpectedResponse("UnassignRoleFromAccessProvider", r)
 	}
 }
 
@@ -494,7 +526,7 @@ func (c *RoleClient) UnassignRoleFromAccessProvider(ctx context.Context, roleId
 func (c *RoleClient) UnassignGlobalRole(ctx context.Context, roleId string, from ...string) (*types.Role, error) {
 	output, err := schema.UnassignGlobalRole(ctx, c.client, roleId, from)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	switch r := output.UnassignGlobalRole.(type) {
@@ -503,9 +535,9 @@ func (c *RoleClient) UnassignGlobalRole(ctx context.Context, roleId string, from
 	case *schema.UnassignGlobalRoleUnassignGlobalRolePermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("unassignGlobalRole", r.Message)
 	case *schema.UnassignGlobalRoleUnassignGlobalRoleNotFoundError:
-		return nil, types.NewErrNotFound(roleId, r.Typename, r.Message)
+		return nil, types.NewErrResourceNotFound("unassignGlobalRole", "Role", roleId, r.Typename, r.Message)
 	default:
-		return nil, fmt.Errorf("unexpected type '%T'", r)
+		return nil, unexpectedResponse("UnassignGlobalRole", r)
 	}
 }
 
@@ -517,7 +549,7 @@ func (c *RoleClient) UnassignGlobalRole(ctx context.Context, roleId string, from
 func (c *RoleClient) UpdateRoleAssigneesOnIdentityStore(ctx context.Context, isId string, roleId string, assignees ...string) (*types.Role, error) {
 	output, err := schema.UpdateRoleAssigneesOnIdentityStore(ctx, c.client, isId, roleId, assignees)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	switch r := output.UpdateRoleAssigneesOnIdentityStore.(type) {
@@ -526,9 +558,9 @@ func (c *RoleClient) UpdateRoleAssigneesOnIdentityStore(ctx context.Context, isI
 	case *schema.UpdateRoleAssigneesOnIdentityStoreUpdateRoleAssigneesOnIdentityStorePermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("updateRoleAssigneesOnIdentityStore", r.Message)
 	case *schema.UpdateRoleAssigneesOnIdentityStoreUpdateRoleAssigneesOnIdentityStoreNotFoundError:
-		return nil, types.NewErrNotFound(isId, r.Typename, r.Message)
+		return nil, types.NewErrResourceNotFound("updateRoleAssigneesOnIdentityStore", "IdentityStore", isId, r.Typename, r.Message)
 	default:
-		return nil, fmt.Errorf("unexpected type '%T'", r)
+		return nil, unexpectedResponse("UpdateRoleAssigneesOnIdentityStore", r)
 	}
 }
 
@@ -540,7 +572,7 @@ func (c *RoleClient) UpdateRoleAssigneesOnIdentityStore(ctx context.Context, isI
 func (c *RoleClient) UpdateRoleAssigneesOnDataObject(ctx context.Context, doId string, roleId string, assignees ...string) (*types.Role, error) {
 	output, err := schema.UpdateRoleAssigneesOnDataObject(ctx, c.client, doId, roleId, assignees)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	switch r := output.UpdateRoleAssigneesOnDataObject.(type) {
@@ -549,9 +581,9 @@ func (c *RoleClient) UpdateRoleAssigneesOnDataObject(ctx context.Context, doId s
 	case *schema.UpdateRoleAssigneesOnDataObjectUpdateRoleAssigneesOnDataObjectPermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("updateRoleAssigneesOnDataObject", r.Message)
 	case *schema.UpdateRoleAssigneesOnDataObjectUpdateRoleAssigneesOnDataObjectNotFoundError:
-		return nil, types.NewErrNotFound(doId, r.Typename, r.Message)
+		return nil, types.NewErrResourceNotFound("updateRoleAssigneesOnDataObject", "DataObject", doId, r.Typename, r.Message)
 	default:
-		return nil, fmt.Errorf("unexpected type '%T'", r)
+		return nil, unexpectedResponse("UpdateRoleAssigneesOnDataObject", r)
 	}
 }
 
@@ -563,7 +595,7 @@ func (c *RoleClient) UpdateRoleAssigneesOnDataObject(ctx context.Context, doId s
 func (c *RoleClient) UpdateRoleAssigneesOnDataSource(ctx context.Context, dataSourceId string, roleId string, assignees ...string) (*types.Role, error) {
 	output, err := schema.UpdateRoleAssigneesOnDataSource(ctx, c.client, dataSourceId, roleId, assignees)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	switch r := output.UpdateRoleAssigneesOnDataSource.(type) {
@@ -572,9 +604,9 @@ func (c *RoleClient) UpdateRoleAssigneesOnDataSource(ctx context.Context, dataSo
 	case *schema.UpdateRoleAssigneesOnDataSourceUpdateRoleAssigneesOnDataSourcePermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("updateRoleAssigneesOnDataSource", r.Message)
 	case *schema.UpdateRoleAssigneesOnDataSourceUpdateRoleAssigneesOnDataSourceNotFoundError:
-		return nil, types.NewErrNotFound(dataSourceId, r.Typename, r.Message)
+		return nil, types.NewErrResourceNotFound("updateRoleAssigneesOnDataSource", "DataSource", dataSourceId, r.Typename, r.Message)
 	default:
-		return nil, fmt.Errorf("unexpected type '%T'", r)
+		return nil, unexpectedResponse("UpdateRoleAssigneesOnDataSource", r)
 	}
 }
 
@@ -586,7 +618,7 @@ func (c *RoleClient) UpdateRoleAssigneesOnDataSource(ctx context.Context, dataSo
 func (c *RoleClient) UpdateRoleAssigneesOnAccessProvider(ctx context.Context, accessProviderId string, roleId string, assignees ...string) (*types.Role, error) {
 	output, err := schema.UpdateRoleAssigneesOnAccessProvider(ctx, c.client, accessProviderId, roleId, assignees)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	switch r := output.UpdateRoleAssigneesOnAccessProvider.(type) {
@@ -595,9 +627,9 @@ func (c *RoleClient) UpdateRoleAssigneesOnAccessProvider(ctx context.Context, ac
 	case *schema.UpdateRoleAssigneesOnAccessProviderUpdateRoleAssigneesOnAccessProviderPermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("updateRoleAssigneesOnAccessProvider", r.Message)
 	case *schema.UpdateRoleAssigneesOnAccessProviderUpdateRoleAssigneesOnAccessProviderNotFoundError:
-		return nil, types.NewErrNotFound(accessProviderId, r.Typename, r.Message)
+		return nil, types.NewErrResourceNotFound("updateRoleAssigneesOnAccessProvider", "AccessProvider", accessProviderId, r.Typename, r.Message)
 	default:
-		return nil, fmt.Errorf("unexpected type '%T'", r)
+		return nil, unexpectedResponse("UpdateRoleAssigneesOnAccessProvider", r)
 	}
 }
 
@@ -608,7 +640,7 @@ func (c *RoleClient) UpdateRoleAssigneesOnAccessProvider(ctx context.Context, ac
 func (c *RoleClient) SetGlobalRoleForUsers(ctx context.Context, roleId string, assignees ...string) error {
 	output, err := schema.SetGlobalRolesForUser(ctx, c.client, roleId, assignees)
 	if err != nil {
-		return types.NewErrClient(err)
+		return clientError(err)
 	}
 
 	switch r := output.SetGlobalRolesForUser.(type) {
@@ -621,7 +653,7 @@ func (c *RoleClient) SetGlobalRoleForUsers(ctx context.Context, roleId string, a
 	case *schema.SetGlobalRolesForUserSetGlobalRolesForUserPermissionDeniedError:
 		return types.NewErrPermissionDenied("setGlobalRolesForUser", r.Message)
 	default:
-		return fmt.Errorf("unexpected type '%T'", r)
+		return unexpectedResponse("SetGlobalRolesForUser", r)
 	}
 }
 
@@ -632,7 +664,10 @@ func roleAssignmentsEdgeFn(edge *types.RoleAssignmentPageEdgesEdge) (*string, *s
 		return cursor, nil, nil
 	}
 
-	listItem := (*edge.Node).(*types.RoleAssignmentPageEdgesEdgeNodeRoleAssignment)
+	listItem, err := internal.DecodeEdgeNode[*types.RoleAssignmentPageEdgesEdgeNodeRoleAssignment](*edge.Node)
+	if err != nil {
+		return cursor, nil, err
+	}
 
 	return cursor, &listItem.RoleAssignment, nil
 }
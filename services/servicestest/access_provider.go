@@ -0,0 +1,545 @@
+// Package servicestest provides fakes of the service clients, to test code depending on them without a Raito Cloud instance.
+package servicestest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"sync"
+
+	"github.com/raito-io/sdk-go/services"
+	"github.com/raito-io/sdk-go/types"
+)
+
+// ErrNotScripted is returned by a fake method for which no function is set.
+var ErrNotScripted = errors.New("fake method not scripted")
+
+// Call is a call recorded by a fake. Args holds the arguments of the call, except the context and the options.
+type Call struct {
+	Method string
+	Args   []any
+}
+
+// AccessProviderService is a fake implementation of services.AccessProviderService.
+// The behaviour of each method is scripted by setting the corresponding Func field. If it is not set, ErrNotScripted is returned.
+// Channel returning methods return a channel with a single ListItem carrying ErrNotScripted.
+// All calls are recorded and can be inspected with Calls. An AccessProviderService can be used concurrently.
+type AccessProviderService struct {
+	CreateAccessProviderFunc                    func(ctx context.Context, ap types.AccessProviderInput, ops ...func(options *services.CreateAccessProviderOptions)) (*types.AccessProvider, error)
+	CreateAccessProvidersFunc                   func(ctx context.Context, aps []types.AccessProviderInput, ops ...func(options *services.AccessProviderBatchOptions)) ([]types.AccessProviderResult, error)
+	GetAccessProvidersFunc                      func(ctx context.Context, ids []string, ops ...func(options *services.AccessProviderBatchOptions)) (map[string]types.AccessProviderResult, error)
+	DeleteAccessProvidersFunc                   func(ctx context.Context, ids []string, ops ...func(options *services.AccessProviderBatchOptions)) ([]types.AccessProviderDeleteResult, error)
+	UpdateAccessProviderFunc                    func(ctx context.Context, id string, ap types.AccessProviderInput, ops ...func(options *services.UpdateAccessProviderOptions)) (*types.AccessProvider, error)
+	PatchAccessProviderFunc                     func(ctx context.Context, id string, patch types.AccessProviderPatch, ops ...func(options *services.UpdateAccessProviderOptions)) (*types.AccessProvider, error)
+	SetAccessProviderWhoListFunc                func(ctx context.Context, id string, items []types.WhoItemInput, ops ...func(options *services.UpdateAccessProviderOptions)) error
+	DeleteAccessProviderFunc                    func(ctx context.Context, id string, ops ...func(options *services.UpdateAccessProviderOptions)) error
+	ActivateAccessProviderFunc                  func(ctx context.Context, id string) (*types.AccessProvider, error)
+	DeactivateAccessProviderFunc                func(ctx context.Context, id string) (*types.AccessProvider, error)
+	GetAccessProviderFunc                       func(ctx context.Context, id string) (*types.AccessProvider, error)
+	GetAccessProviderWithWhoWhatFunc            func(ctx context.Context, id string) (*types.AccessProviderWithWhoWhat, error)
+	CreateMaskingPolicyFunc                     func(ctx context.Context, ap types.AccessProviderInput) (*types.MaskingPolicy, error)
+	GetMaskingPolicyFunc                        func(ctx context.Context, id string) (*types.MaskingPolicy, error)
+	CreateRowFilterFunc                         func(ctx context.Context, ap types.AccessProviderInput) (*types.RowFilter, error)
+	GetRowFilterFunc                            func(ctx context.Context, id string) (*types.RowFilter, error)
+	GetAccessProviderSummaryFunc                func(ctx context.Context, id string) (*types.AccessProviderSummary, error)
+	AccessProviderExistsFunc                    func(ctx context.Context, id string) (bool, error)
+	GetAccessProviderSyncStatusFunc             func(ctx context.Context, id string) (*types.AccessProviderSyncStatus, error)
+	ListAccessProvidersFunc                     func(ctx context.Context, ops ...func(*services.AccessProviderListOptions)) <-chan types.ListItem[types.AccessProvider]
+	SearchAccessProvidersFunc                   func(ctx context.Context, query string, ops ...func(*services.AccessProviderListOptions)) <-chan types.ListItem[types.AccessProvider]
+	ListAccessProvidersCancelableFunc           func(ctx context.Context, ops ...func(*services.AccessProviderListOptions)) (<-chan types.ListItem[types.AccessProvider], func())
+	ListAccessProvidersAllFunc                  func(ctx context.Context, ops ...func(*services.AccessProviderListOptions)) ([]types.AccessProvider, error)
+	ForEachAccessProviderFunc                   func(ctx context.Context, fn func(types.AccessProvider) error, ops ...func(*services.AccessProviderListOptions)) error
+	CountAccessProvidersFunc                    func(ctx context.Context, filter *types.AccessProviderFilterInput) (int, error)
+	GetAccessProviderWhoListFunc                func(ctx context.Context, id string, ops ...func(*services.AccessProviderWhoListOptions)) <-chan types.ListItem[types.AccessProviderWhoListItem]
+	CountAccessProviderWhoItemsFunc             func(ctx context.Context, id string) (int, error)
+	GetAccessProviderWhoListGroupedFunc         func(ctx context.Context, id string, ops ...func(*services.AccessProviderWhoListOptions)) (*types.WhoListGroups, error)
+	GetAccessProviderWhoAccessProviderRefsFunc  func(ctx context.Context, id string) <-chan types.ListItem[types.AccessProviderWhoAccessProviderRef]
+	GetAccessProviderWhatDataObjectListFunc     func(ctx context.Context, id string, ops ...func(*services.AccessProviderWhatListOptions)) <-chan types.ListItem[types.AccessProviderWhatListItem]
+	GetAccessProviderWhatColumnListFunc         func(ctx context.Context, id string, ops ...func(*services.AccessProviderWhatListOptions)) <-chan types.ListItem[types.AccessProviderWhatColumnItem]
+	GetAccessProviderWhatLeafDataObjectsFunc    func(ctx context.Context, id string, ops ...func(*services.AccessProviderWhatLeafOptions)) <-chan types.ListItem[types.AccessProviderWhatLeafItem]
+	GetAccessProviderWhoWhatListFunc            func(ctx context.Context, id string) <-chan types.ListItem[types.AccessProviderWhoWhatItem]
+	AccessProviderHasWhatDataObjectFunc         func(ctx context.Context, apId string, dataObjectId string) (bool, error)
+	GetAccessProviderWhatAccessProviderListFunc func(ctx context.Context, id string, ops ...func(*services.AccessProviderWhatAccessProviderListOptions)) <-chan types.ListItem[types.AccessWhatAccessProviderItem]
+	GetAccessProviderAbacWhatScopeFunc          func(ctx context.Context, id string, ops ...func(*services.AccessProviderAbacWhatScopeListOptions)) <-chan types.ListItem[types.DataObject]
+	ExportAccessProvidersFunc                   func(ctx context.Context, w io.Writer, ops ...func(*services.AccessProviderListOptions)) error
+	ImportAccessProvidersFunc                   func(ctx context.Context, r io.Reader, ops ...func(options *services.AccessProviderImportOptions)) ([]types.AccessProviderImportResult, error)
+
+	mutex sync.Mutex
+	calls []Call
+}
+
+var _ services.AccessProviderService = (*AccessProviderService)(nil)
+
+// Calls returns the recorded calls. If methods are given, only calls of these methods are returned.
+func (s *AccessProviderService) Calls(methods ...string) []Call {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	calls := make([]Call, 0, len(s.calls))
+
+	for _, call := range s.calls {
+		if len(methods) == 0 || slices.Contains(methods, call.Method) {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+// ListItems returns a closed channel containing the given items, which can be returned by a scripted channel returning method.
+func ListItems[T any](items ...T) <-chan types.ListItem[T] {
+	channel := make(chan types.ListItem[T], len(items))
+
+	for i := range items {
+		channel <- types.NewListItemItem(&items[i])
+	}
+
+	close(channel)
+
+	return channel
+}
+
+// ErrorListItems returns a closed channel containing the given items, followed by a ListItem carrying err.
+func ErrorListItems[T any](err error, items ...T) <-chan types.ListItem[T] {
+	channel := make(chan types.ListItem[T], len(items)+1)
+
+	for i := range items {
+		channel <- types.NewListItemItem(&items[i])
+	}
+
+	channel <- types.NewListItemError[T](err)
+
+	close(channel)
+
+	return channel
+}
+
+func errorChannel[T any]() <-chan types.ListItem[T] {
+	return ErrorListItems[T](ErrNotScripted)
+}
+
+func (s *AccessProviderService) record(method string, args ...any) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.calls = append(s.calls, Call{Method: method, Args: args})
+}
+
+// CreateAccessProvider records the call and calls CreateAccessProviderFunc.
+func (s *AccessProviderService) CreateAccessProvider(ctx context.Context, ap types.AccessProviderInput, ops ...func(options *services.CreateAccessProviderOptions)) (*types.AccessProvider, error) {
+	s.record("CreateAccessProvider", ap)
+
+	if s.CreateAccessProviderFunc == nil {
+		return nil, ErrNotScripted
+	}
+
+	return s.CreateAccessProviderFunc(ctx, ap, ops...)
+}
+
+// CreateAccessProviders records the call and calls CreateAccessProvidersFunc.
+func (s *AccessProviderService) CreateAccessProviders(ctx context.Context, aps []types.AccessProviderInput, ops ...func(options *services.AccessProviderBatchOptions)) ([]types.AccessProviderResult, error) {
+	s.record("CreateAccessProviders", aps)
+
+	if s.CreateAccessProvidersFunc == nil {
+		return nil, ErrNotScripted
+	}
+
+	return s.CreateAccessProvidersFunc(ctx, aps, ops...)
+}
+
+// GetAccessProviders records the call and calls GetAccessProvidersFunc.
+func (s *AccessProviderService) GetAccessProviders(ctx context.Context, ids []string, ops ...func(options *services.AccessProviderBatchOptions)) (map[string]types.AccessProviderResult, error) {
+	s.record("GetAccessProviders", ids)
+
+	if s.GetAccessProvidersFunc == nil {
+		return nil, ErrNotScripted
+	}
+
+	return s.GetAccessProvidersFunc(ctx, ids, ops...)
+}
+
+// DeleteAccessProviders records the call and calls DeleteAccessProvidersFunc.
+func (s *AccessProviderService) DeleteAccessProviders(ctx context.Context, ids []string, ops ...func(options *services.AccessProviderBatchOptions)) ([]types.AccessProviderDeleteResult, error) {
+	s.record("DeleteAccessProviders", ids)
+
+	if s.DeleteAccessProvidersFunc == nil {
+		return nil, ErrNotScripted
+	}
+
+	return s.DeleteAccessProvidersFunc(ctx, ids, ops...)
+}
+
+// UpdateAccessProvider records the call and calls UpdateAccessProviderFunc.
+func (s *AccessProviderService) UpdateAccessProvider(ctx context.Context, id string, ap types.AccessProviderInput, ops ...func(options *services.UpdateAccessProviderOptions)) (*types.AccessProvider, error) {
+	s.record("UpdateAccessProvider", id, ap)
+
+	if s.UpdateAccessProviderFunc == nil {
+		return nil, ErrNotScripted
+	}
+
+	return s.UpdateAccessProviderFunc(ctx, id, ap, ops...)
+}
+
+// PatchAccessProvider records the call and calls PatchAccessProviderFunc.
+func (s *AccessProviderService) PatchAccessProvider(ctx context.Context, id string, patch types.AccessProviderPatch, ops ...func(options *services.UpdateAccessProviderOptions)) (*types.AccessProvider, error) {
+	s.record("PatchAccessProvider", id, patch)
+
+	if s.PatchAccessProviderFunc == nil {
+		return nil, ErrNotScripted
+	}
+
+	return s.PatchAccessProviderFunc(ctx, id, patch, ops...)
+}
+
+// SetAccessProviderWhoList records the call and calls SetAccessProviderWhoListFunc.
+func (s *AccessProviderService) SetAccessProviderWhoList(ctx context.Context, id string, items []types.WhoItemInput, ops ...func(options *services.UpdateAccessProviderOptions)) error {
+	s.record("SetAccessProviderWhoList", id, items)
+
+	if s.SetAccessProviderWhoListFunc == nil {
+		return ErrNotScripted
+	}
+
+	return s.SetAccessProviderWhoListFunc(ctx, id, items, ops...)
+}
+
+// DeleteAccessProvider records the call and calls DeleteAccessProviderFunc.
+func (s *AccessProviderService) DeleteAccessProvider(ctx context.Context, id string, ops ...func(options *services.UpdateAccessProviderOptions)) error {
+	s.record("DeleteAccessProvider", id)
+
+	if s.DeleteAccessProviderFunc == nil {
+		return ErrNotScripted
+	}
+
+	return s.DeleteAccessProviderFunc(ctx, id, ops...)
+}
+
+// ActivateAccessProvider records the call and calls ActivateAccessProviderFunc.
+func (s *AccessProviderService) ActivateAccessProvider(ctx context.Context, id string) (*types.AccessProvider, error) {
+	s.record("ActivateAccessProvider", id)
+
+	if s.ActivateAccessProviderFunc == nil {
+		return nil, ErrNotScripted
+	}
+
+	return s.ActivateAccessProviderFunc(ctx, id)
+}
+
+// DeactivateAccessProvider records the call and calls DeactivateAccessProviderFunc.
+func (s *AccessProviderService) DeactivateAccessProvider(ctx context.Context, id string) (*types.AccessProvider, error) {
+	s.record("DeactivateAccessProvider", id)
+
+	if s.DeactivateAccessProviderFunc == nil {
+		return nil, ErrNotScripted
+	}
+
+	return s.DeactivateAccessProviderFunc(ctx, id)
+}
+
+// GetAccessProvider records the call and calls GetAccessProviderFunc.
+func (s *AccessProviderService) GetAccessProvider(ctx context.Context, id string) (*types.AccessProvider, error) {
+	s.record("GetAccessProvider", id)
+
+	if s.GetAccessProviderFunc == nil {
+		return nil, ErrNotScripted
+	}
+
+	return s.GetAccessProviderFunc(ctx, id)
+}
+
+// GetAccessProviderWithWhoWhat records the call and calls GetAccessProviderWithWhoWhatFunc.
+func (s *AccessProviderService) GetAccessProviderWithWhoWhat(ctx context.Context, id string) (*types.AccessProviderWithWhoWhat, error) {
+	s.record("GetAccessProviderWithWhoWhat", id)
+
+	if s.GetAccessProviderWithWhoWhatFunc == nil {
+		return nil, ErrNotScripted
+	}
+
+	return s.GetAccessProviderWithWhoWhatFunc(ctx, id)
+}
+
+// CreateMaskingPolicy records the call and calls CreateMaskingPolicyFunc.
+func (s *AccessProviderService) CreateMaskingPolicy(ctx context.Context, ap types.AccessProviderInput) (*types.MaskingPolicy, error) {
+	s.record("CreateMaskingPolicy", ap)
+
+	if s.CreateMaskingPolicyFunc == nil {
+		return nil, ErrNotScripted
+	}
+
+	return s.CreateMaskingPolicyFunc(ctx, ap)
+}
+
+// GetMaskingPolicy records the call and calls GetMaskingPolicyFunc.
+func (s *AccessProviderService) GetMaskingPolicy(ctx context.Context, id string) (*types.MaskingPolicy, error) {
+	s.record("GetMaskingPolicy", id)
+
+	if s.GetMaskingPolicyFunc == nil {
+		return nil, ErrNotScripted
+	}
+
+	return s.GetMaskingPolicyFunc(ctx, id)
+}
+
+// CreateRowFilter records the call and calls CreateRowFilterFunc.
+func (s *AccessProviderService) CreateRowFilter(ctx context.Context, ap types.AccessProviderInput) (*types.RowFilter, error) {
+	s.record("CreateRowFilter", ap)
+
+	if s.CreateRowFilterFunc == nil {
+		return nil, ErrNotScripted
+	}
+
+	return s.CreateRowFilterFunc(ctx, ap)
+}
+
+// GetRowFilter records the call and calls GetRowFilterFunc.
+func (s *AccessProviderService) GetRowFilter(ctx context.Context, id string) (*types.RowFilter, error) {
+	s.record("GetRowFilter", id)
+
+	if s.GetRowFilterFunc == nil {
+		return nil, ErrNotScripted
+	}
+
+	return s.GetRowFilterFunc(ctx, id)
+}
+
+// GetAccessProviderSummary records the call and calls GetAccessProviderSummaryFunc.
+func (s *AccessProviderService) GetAccessProviderSummary(ctx context.Context, id string) (*types.AccessProviderSummary, error) {
+	s.record("GetAccessProviderSummary", id)
+
+	if s.GetAccessProviderSummaryFunc == nil {
+		return nil, ErrNotScripted
+	}
+
+	return s.GetAccessProviderSummaryFunc(ctx, id)
+}
+
+// AccessProviderExists records the call and calls AccessProviderExistsFunc.
+func (s *AccessProviderService) AccessProviderExists(ctx context.Context, id string) (bool, error) {
+	s.record("AccessProviderExists", id)
+
+	if s.AccessProviderExistsFunc == nil {
+		return false, ErrNotScripted
+	}
+
+	return s.AccessProviderExistsFunc(ctx, id)
+}
+
+// GetAccessProviderSyncStatus records the call and calls GetAccessProviderSyncStatusFunc.
+func (s *AccessProviderService) GetAccessProviderSyncStatus(ctx context.Context, id string) (*types.AccessProviderSyncStatus, error) {
+	s.record("GetAccessProviderSyncStatus", id)
+
+	if s.GetAccessProviderSyncStatusFunc == nil {
+		return nil, ErrNotScripted
+	}
+
+	return s.GetAccessProviderSyncStatusFunc(ctx, id)
+}
+
+// ListAccessProviders records the call and calls ListAccessProvidersFunc.
+func (s *AccessProviderService) ListAccessProviders(ctx context.Context, ops ...func(*services.AccessProviderListOptions)) <-chan types.ListItem[types.AccessProvider] {
+	s.record("ListAccessProviders")
+
+	if s.ListAccessProvidersFunc == nil {
+		return errorChannel[types.AccessProvider]()
+	}
+
+	return s.ListAccessProvidersFunc(ctx, ops...)
+}
+
+// SearchAccessProviders records the call and calls SearchAccessProvidersFunc.
+func (s *AccessProviderService) SearchAccessProviders(ctx context.Context, query string, ops ...func(*services.AccessProviderListOptions)) <-chan types.ListItem[types.AccessProvider] {
+	s.record("SearchAccessProviders", query)
+
+	if s.SearchAccessProvidersFunc == nil {
+		return errorChannel[types.AccessProvider]()
+	}
+
+	return s.SearchAccessProvidersFunc(ctx, query, ops...)
+}
+
+// ListAccessProvidersCancelable records the call and calls ListAccessProvidersCancelableFunc.
+func (s *AccessProviderService) ListAccessProvidersCancelable(ctx context.Context, ops ...func(*services.AccessProviderListOptions)) (<-chan types.ListItem[types.AccessProvider], func()) {
+	s.record("ListAccessProvidersCancelable")
+
+	if s.ListAccessProvidersCancelableFunc == nil {
+		return errorChannel[types.AccessProvider](), func() {}
+	}
+
+	return s.ListAccessProvidersCancelableFunc(ctx, ops...)
+}
+
+// ListAccessProvidersAll records the call and calls ListAccessProvidersAllFunc.
+func (s *AccessProviderService) ListAccessProvidersAll(ctx context.Context, ops ...func(*services.AccessProviderListOptions)) ([]types.AccessProvider, error) {
+	s.record("ListAccessProvidersAll")
+
+	if s.ListAccessProvidersAllFunc == nil {
+		return nil, ErrNotScripted
+	}
+
+	return s.ListAccessProvidersAllFunc(ctx, ops...)
+}
+
+// ForEachAccessProvider records the call and calls ForEachAccessProviderFunc.
+func (s *AccessProviderService) ForEachAccessProvider(ctx context.Context, fn func(types.AccessProvider) error, ops ...func(*services.AccessProviderListOptions)) error {
+	s.record("ForEachAccessProvider")
+
+	if s.ForEachAccessProviderFunc == nil {
+		return ErrNotScripted
+	}
+
+	return s.ForEachAccessProviderFunc(ctx, fn, ops...)
+}
+
+// CountAccessProviders records the call and calls CountAccessProvidersFunc.
+func (s *AccessProviderService) CountAccessProviders(ctx context.Context, filter *types.AccessProviderFilterInput) (int, error) {
+	s.record("CountAccessProviders", filter)
+
+	if s.CountAccessProvidersFunc == nil {
+		return 0, ErrNotScripted
+	}
+
+	return s.CountAccessProvidersFunc(ctx, filter)
+}
+
+// GetAccessProviderWhoList records the call and calls GetAccessProviderWhoListFunc.
+func (s *AccessProviderService) GetAccessProviderWhoList(ctx context.Context, id string, ops ...func(*services.AccessProviderWhoListOptions)) <-chan types.ListItem[types.AccessProviderWhoListItem] {
+	s.record("GetAccessProviderWhoList", id)
+
+	if s.GetAccessProviderWhoListFunc == nil {
+		return errorChannel[types.AccessProviderWhoListItem]()
+	}
+
+	return s.GetAccessProviderWhoListFunc(ctx, id, ops...)
+}
+
+// CountAccessProviderWhoItems records the call and calls CountAccessProviderWhoItemsFunc.
+func (s *AccessProviderService) CountAccessProviderWhoItems(ctx context.Context, id string) (int, error) {
+	s.record("CountAccessProviderWhoItems", id)
+
+	if s.CountAccessProviderWhoItemsFunc == nil {
+		return 0, ErrNotScripted
+	}
+
+	return s.CountAccessProviderWhoItemsFunc(ctx, id)
+}
+
+// GetAccessProviderWhoListGrouped records the call and calls GetAccessProviderWhoListGroupedFunc.
+func (s *AccessProviderService) GetAccessProviderWhoListGrouped(ctx context.Context, id string, ops ...func(*services.AccessProviderWhoListOptions)) (*types.WhoListGroups, error) {
+	s.record("GetAccessProviderWhoListGrouped", id)
+
+	if s.GetAccessProviderWhoListGroupedFunc == nil {
+		return nil, ErrNotScripted
+	}
+
+	return s.GetAccessProviderWhoListGroupedFunc(ctx, id, ops...)
+}
+
+// GetAccessProviderWhoAccessProviderRefs records the call and calls GetAccessProviderWhoAccessProviderRefsFunc.
+func (s *AccessProviderService) GetAccessProviderWhoAccessProviderRefs(ctx context.Context, id string) <-chan types.ListItem[types.AccessProviderWhoAccessProviderRef] {
+	s.record("GetAccessProviderWhoAccessProviderRefs", id)
+
+	if s.GetAccessProviderWhoAccessProviderRefsFunc == nil {
+		return errorChannel[types.AccessProviderWhoAccessProviderRef]()
+	}
+
+	return s.GetAccessProviderWhoAccessProviderRefsFunc(ctx, id)
+}
+
+// GetAccessProviderWhatDataObjectList records the call and calls GetAccessProviderWhatDataObjectListFunc.
+func (s *AccessProviderService) GetAccessProviderWhatDataObjectList(ctx context.Context, id string, ops ...func(*services.AccessProviderWhatListOptions)) <-chan types.ListItem[types.AccessProviderWhatListItem] {
+	s.record("GetAccessProviderWhatDataObjectList", id)
+
+	if s.GetAccessProviderWhatDataObjectListFunc == nil {
+		return errorChannel[types.AccessProviderWhatListItem]()
+	}
+
+	return s.GetAccessProviderWhatDataObjectListFunc(ctx, id, ops...)
+}
+
+// GetAccessProviderWhatColumnList records the call and calls GetAccessProviderWhatColumnListFunc.
+func (s *AccessProviderService) GetAccessProviderWhatColumnList(ctx context.Context, id string, ops ...func(*services.AccessProviderWhatListOptions)) <-chan types.ListItem[types.AccessProviderWhatColumnItem] {
+	s.record("GetAccessProviderWhatColumnList", id)
+
+	if s.GetAccessProviderWhatColumnListFunc == nil {
+		return errorChannel[types.AccessProviderWhatColumnItem]()
+	}
+
+	return s.GetAccessProviderWhatColumnListFunc(ctx, id, ops...)
+}
+
+// GetAccessProviderWhatLeafDataObjects records the call and calls GetAccessProviderWhatLeafDataObjectsFunc.
+func (s *AccessProviderService) GetAccessProviderWhatLeafDataObjects(ctx context.Context, id string, ops ...func(*services.AccessProviderWhatLeafOptions)) <-chan types.ListItem[types.AccessProviderWhatLeafItem] {
+	s.record("GetAccessProviderWhatLeafDataObjects", id)
+
+	if s.GetAccessProviderWhatLeafDataObjectsFunc == nil {
+		return errorChannel[types.AccessProviderWhatLeafItem]()
+	}
+
+	return s.GetAccessProviderWhatLeafDataObjectsFunc(ctx, id, ops...)
+}
+
+// GetAccessProviderWhoWhatList records the call and calls GetAccessProviderWhoWhatListFunc.
+func (s *AccessProviderService) GetAccessProviderWhoWhatList(ctx context.Context, id string) <-chan types.ListItem[types.AccessProviderWhoWhatItem] {
+	s.record("GetAccessProviderWhoWhatList", id)
+
+	if s.GetAccessProviderWhoWhatListFunc == nil {
+		return errorChannel[types.AccessProviderWhoWhatItem]()
+	}
+
+	return s.GetAccessProviderWhoWhatListFunc(ctx, id)
+}
+
+// AccessProviderHasWhatDataObject records the call and calls AccessProviderHasWhatDataObjectFunc.
+func (s *AccessProviderService) AccessProviderHasWhatDataObject(ctx context.Context, apId string, dataObjectId string) (bool, error) {
+	s.record("AccessProviderHasWhatDataObject", apId, dataObjectId)
+
+	if s.AccessProviderHasWhatDataObjectFunc == nil {
+		return false, ErrNotScripted
+	}
+
+	return s.AccessProviderHasWhatDataObjectFunc(ctx, apId, dataObjectId)
+}
+
+// GetAccessProviderWhatAccessProviderList records the call and calls GetAccessProviderWhatAccessProviderListFunc.
+func (s *AccessProviderService) GetAccessProviderWhatAccessProviderList(ctx context.Context, id string, ops ...func(*services.AccessProviderWhatAccessProviderListOptions)) <-chan types.ListItem[types.AccessWhatAccessProviderItem] {
+	s.record("GetAccessProviderWhatAccessProviderList", id)
+
+	if s.GetAccessProviderWhatAccessProviderListFunc == nil {
+		return errorChannel[types.AccessWhatAccessProviderItem]()
+	}
+
+	return s.GetAccessProviderWhatAccessProviderListFunc(ctx, id, ops...)
+}
+
+// GetAccessProviderAbacWhatScope records the call and calls GetAccessProviderAbacWhatScopeFunc.
+func (s *AccessProviderService) GetAccessProviderAbacWhatScope(ctx context.Context, id string, ops ...func(*services.AccessProviderAbacWhatScopeListOptions)) <-chan types.ListItem[types.DataObject] {
+	s.record("GetAccessProviderAbacWhatScope", id)
+
+	if s.GetAccessProviderAbacWhatScopeFunc == nil {
+		return errorChannel[types.DataObject]()
+	}
+
+	return s.GetAccessProviderAbacWhatScopeFunc(ctx, id, ops...)
+}
+
+// ExportAccessProviders records the call and calls ExportAccessProvidersFunc.
+func (s *AccessProviderService) ExportAccessProviders(ctx context.Context, w io.Writer, ops ...func(*services.AccessProviderListOptions)) error {
+	s.record("ExportAccessProviders", w)
+
+	if s.ExportAccessProvidersFunc == nil {
+		return ErrNotScripted
+	}
+
+	return s.ExportAccessProvidersFunc(ctx, w, ops...)
+}
+
+// ImportAccessProviders records the call and calls ImportAccessProvidersFunc.
+func (s *AccessProviderService) ImportAccessProviders(ctx context.Context, r io.Reader, ops ...func(options *services.AccessProviderImportOptions)) ([]types.AccessProviderImportResult, error) {
+	s.record("ImportAccessProviders", r)
+
+	if s.ImportAccessProvidersFunc == nil {
+		return nil, ErrNotScripted
+	}
+
+	return s.ImportAccessProvidersFunc(ctx, r, ops...)
+}
@@ -0,0 +1,62 @@
+package servicestest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/services"
+	"github.com/raito-io/sdk-go/types"
+	"github.com/raito-io/sdk-go/types/models"
+)
+
+// countActiveAccessProviders is an example of code depending on the AccessProviderService.
+func countActiveAccessProviders(ctx context.Context, service services.AccessProviderService) (int, error) {
+	count := 0
+
+	for item := range service.ListAccessProviders(ctx) {
+		if item.HasError() {
+			return 0, item.GetError()
+		}
+
+		if item.MustGetItem().State == models.AccessProviderStateActive {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func TestAccessProviderService(t *testing.T) {
+	t.Run("scripted", func(t *testing.T) {
+		fake := &AccessProviderService{
+			ListAccessProvidersFunc: func(ctx context.Context, ops ...func(*services.AccessProviderListOptions)) <-chan types.ListItem[types.AccessProvider] {
+				return ListItems(
+					types.AccessProvider{Id: "ap1", State: models.AccessProviderStateActive},
+					types.AccessProvider{Id: "ap2", State: models.AccessProviderStateInactive},
+				)
+			},
+		}
+
+		count, err := countActiveAccessProviders(context.Background(), fake)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.Equal(t, []Call{{Method: "ListAccessProviders"}}, fake.Calls())
+	})
+
+	t.Run("not scripted", func(t *testing.T) {
+		fake := &AccessProviderService{}
+
+		_, err := countActiveAccessProviders(context.Background(), fake)
+		assert.ErrorIs(t, err, ErrNotScripted)
+
+		_, err = fake.GetAccessProvider(context.Background(), "ap1")
+		assert.ErrorIs(t, err, ErrNotScripted)
+
+		assert.Equal(t, []Call{{Method: "GetAccessProvider", Args: []any{"ap1"}}}, fake.Calls("GetAccessProvider"))
+		assert.Len(t, fake.Calls(), 2)
+	})
+}
@@ -16,16 +16,32 @@ type UserClient struct {
 	client graphql.Client
 }
 
-func NewUserClient(client graphql.Client) UserClient {
+func NewUserClient(client graphql.Client, ops ...func(options *ClientOptions)) UserClient {
 	return UserClient{
-		client: client,
+		client: newGraphqlClient(client, ops...),
 	}
 }
 
+// UserService is implemented by UserClient.
+// It can be used to depend on an abstraction of the UserClient, e.g. to substitute it in tests.
+type UserService interface {
+	GetCurrentUser(ctx context.Context) (*types.User, error)
+	GetUser(ctx context.Context, id string) (*types.User, error)
+	GetUserByEmail(ctx context.Context, email string) (*types.User, error)
+	CreateUser(ctx context.Context, userInput types.UserInput) (*types.User, error)
+	UpdateUser(ctx context.Context, id string, userInput types.UserInput) (*types.User, error)
+	DeleteUser(ctx context.Context, id string) error
+	InviteAsRaitoUser(ctx context.Context, id string, ops ...func(*InviteAsRaitoUserOptions)) (*types.User, error)
+	RemoveAsRaitoUser(ctx context.Context, id string) (*types.User, error)
+	SetUserPassword(ctx context.Context, id string, password string) (*types.User, error)
+}
+
+var _ UserService = (*UserClient)(nil)
+
 func (c *UserClient) GetCurrentUser(ctx context.Context) (*types.User, error) {
 	result, err := schema.CurrentUser(ctx, c.client)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	return &result.CurrentUser.User, nil
@@ -36,14 +52,14 @@ func (c *UserClient) GetCurrentUser(ctx context.Context) (*types.User, error) {
 func (c *UserClient) GetUser(ctx context.Context, id string) (*types.User, error) {
 	result, err := schema.GetUser(ctx, c.client, id)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	switch r := result.User.(type) {
 	case *schema.GetUserUser:
 		return &r.User, nil
 	case *schema.GetUserUserNotFoundError:
-		return nil, types.NewErrNotFound(id, r.Typename, r.Message)
+		return nil, types.NewErrResourceNotFound("getUser", "User", id, r.Typename, r.Message)
 	case *schema.GetUserUserPermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("getUser", r.Message)
 	case *schema.GetUserUserInvalidEmailError:
@@ -60,11 +76,11 @@ func (c *UserClient) GetUser(ctx context.Context, id string) (*types.User, error
 func (c *UserClient) GetUserByEmail(ctx context.Context, email string) (*types.User, error) {
 	result, err := schema.GetUserByEmail(ctx, c.client, email)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	if result.UserByEmail == nil {
-		return nil, types.NewErrNotFound(email, ptr.String("user"), "No user found for the given email address.")
+		return nil, types.NewErrResourceNotFound("getUserByEmail", "User", email, ptr.String("user"), "No user found for the given email address.")
 	}
 
 	switch user := (*result.UserByEmail).(type) {
@@ -73,7 +89,7 @@ func (c *UserClient) GetUserByEmail(ctx context.Context, email string) (*types.U
 	case *schema.GetUserByEmailUserByEmailInvalidEmailError:
 		return nil, types.NewErrInvalidEmail(user.ErrEmail, user.Message)
 	case *schema.GetUserByEmailUserByEmailNotFoundError:
-		return nil, types.NewErrNotFound(email, user.Typename, user.Message)
+		return nil, types.NewErrResourceNotFound("getUserByEmail", "User", email, user.Typename, user.Message)
 	case *schema.GetUserByEmailUserByEmailPermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("getUserByEmail", user.Message)
 	default:
@@ -86,7 +102,7 @@ func (c *UserClient) GetUserByEmail(ctx context.Context, email string) (*types.U
 func (c *UserClient) CreateUser(ctx context.Context, userInput types.UserInput) (*types.User, error) {
 	result, err := schema.CreateUser(ctx, c.client, userInput)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	switch user := result.CreateUser.(type) {
@@ -97,7 +113,7 @@ func (c *UserClient) CreateUser(ctx context.Context, userInput types.UserInput)
 	case *schema.CreateUserCreateUserPermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("createUser", user.Message)
 	case *schema.CreateUserCreateUserNotFoundError:
-		return nil, types.NewErrNotFound("", user.Typename, user.Message)
+		return nil, types.NewErrResourceNotFound("createUser", "User", "", user.Typename, user.Message)
 	default:
 		return nil, types.NewErrClient(fmt.Errorf("unexpected result type: %T", user))
 	}
@@ -108,7 +124,7 @@ func (c *UserClient) CreateUser(ctx context.Context, userInput types.UserInput)
 func (c *UserClient) UpdateUser(ctx context.Context, id string, userInput types.UserInput) (*types.User, error) {
 	result, err := schema.UpdateUser(ctx, c.client, id, userInput)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	switch user := result.UpdateUser.(type) {
@@ -117,7 +133,7 @@ func (c *UserClient) UpdateUser(ctx context.Context, id string, userInput types.
 	case *schema.UpdateUserUpdateUserInvalidEmailError:
 		return nil, types.NewErrInvalidEmail(user.ErrEmail, user.Message)
 	case *schema.UpdateUserUpdateUserNotFoundError:
-		return nil, types.NewErrNotFound(id, user.Typename, user.Message)
+		return nil, types.NewErrResourceNotFound("updateUser", "User", id, user.Typename, user.Message)
 	case *schema.UpdateUserUpdateUserPermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("updateUser", user.Message)
 	default:
@@ -130,7 +146,7 @@ func (c *UserClient) UpdateUser(ctx context.Context, id string, userInput types.
 func (c *UserClient) DeleteUser(ctx context.Context, id string) error {
 	result, err := schema.DeleteUser(ctx, c.client, id)
 	if err != nil {
-		return types.NewErrClient(err)
+		return clientError(err)
 	}
 
 	switch response := result.DeleteUser.(type) {
@@ -143,7 +159,7 @@ func (c *UserClient) DeleteUser(ctx context.Context, id string) error {
 	case *schema.DeleteUserDeleteUserPermissionDeniedError:
 		return types.NewErrPermissionDenied("deleteUser", response.Message)
 	default:
-		return types.NewErrClient(fmt.Errorf("unexpected response type: %T", response))
+		return types.NewErrClient(unexpectedResponse("DeleteUser", response))
 	}
 }
 
@@ -169,7 +185,7 @@ func (c *UserClient) InviteAsRaitoUser(ctx context.Context, id string, ops ...fu
 
 	result, err := schema.InviteAsRaitoUser(ctx, c.client, id, &options.NoPassword)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	switch user := result.InviteAsRaitoUser.(type) {
@@ -178,11 +194,11 @@ func (c *UserClient) InviteAsRaitoUser(ctx context.Context, id string, ops ...fu
 	case *schema.InviteAsRaitoUserInviteAsRaitoUserPermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("InviteRaitoUser", user.Message)
 	case *schema.InviteAsRaitoUserInviteAsRaitoUserNotFoundError:
-		return nil, types.NewErrNotFound(id, user.Typename, user.Message)
+		return nil, types.NewErrResourceNotFound("InviteRaitoUser", "User", id, user.Typename, user.Message)
 	case *schema.InviteAsRaitoUserInviteAsRaitoUserInvalidEmailError:
 		return nil, types.NewErrInvalidEmail(user.ErrEmail, user.Message)
 	default:
-		return nil, types.NewErrClient(fmt.Errorf("unexpected response type: %T", user))
+		return nil, types.NewErrClient(unexpectedResponse("InviteAsRaitoUser", user))
 	}
 }
 
@@ -191,7 +207,7 @@ func (c *UserClient) InviteAsRaitoUser(ctx context.Context, id string, ops ...fu
 func (c *UserClient) RemoveAsRaitoUser(ctx context.Context, id string) (*types.User, error) {
 	result, err := schema.RemoveAsRaitoUser(ctx, c.client, id)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	switch user := result.RemoveAsRaitoUser.(type) {
@@ -202,9 +218,9 @@ func (c *UserClient) RemoveAsRaitoUser(ctx context.Context, id string) (*types.U
 	case *schema.RemoveAsRaitoUserRemoveAsRaitoUserInvalidEmailError:
 		return nil, types.NewErrInvalidEmail(user.ErrEmail, user.Message)
 	case *schema.RemoveAsRaitoUserRemoveAsRaitoUserNotFoundError:
-		return nil, types.NewErrNotFound(id, user.Typename, user.Message)
+		return nil, types.NewErrResourceNotFound("removeAsRaitoUser", "User", id, user.Typename, user.Message)
 	default:
-		return nil, types.NewErrClient(fmt.Errorf("unexpected response type: %T", user))
+		return nil, types.NewErrClient(unexpectedResponse("RemoveAsRaitoUser", user))
 	}
 }
 
@@ -214,7 +230,7 @@ func (c *UserClient) RemoveAsRaitoUser(ctx context.Context, id string) (*types.U
 func (c *UserClient) SetUserPassword(ctx context.Context, id string, password string) (*types.User, error) {
 	result, err := schema.SetUserPassword(ctx, c.client, id, password)
 	if err != nil {
-		return nil, types.NewErrClient(err)
+		return nil, clientError(err)
 	}
 
 	switch user := result.SetPassword.(type) {
@@ -223,10 +239,10 @@ func (c *UserClient) SetUserPassword(ctx context.Context, id string, password st
 	case *schema.SetUserPasswordSetPasswordPermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("setUserPassword", user.Message)
 	case *schema.SetUserPasswordSetPasswordNotFoundError:
-		return nil, types.NewErrNotFound(id, user.Typename, user.Message)
+		return nil, types.NewErrResourceNotFound("setUserPassword", "User", id, user.Typename, user.Message)
 	case *schema.SetUserPasswordSetPasswordInvalidEmailError:
 		return nil, types.NewErrInvalidEmail(user.ErrEmail, user.Message)
 	default:
-		return nil, types.NewErrClient(fmt.Errorf("unexpected response type: %T", user))
+		return nil, types.NewErrClient(unexpectedResponse("SetUserPassword", user))
 	}
 }
@@ -0,0 +1,23 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/types"
+)
+
+func TestUserClient_GetUser_NotFound(t *testing.T) {
+	client := NewUserClient(&mockGraphqlClient{responses: []string{`{"user": {"__typename": "NotFoundError", "message": "not found"}}`}})
+
+	_, err := client.GetUser(context.Background(), "user-1")
+
+	var notFoundErr *types.ErrNotFound
+	require.ErrorAs(t, err, &notFoundErr)
+	assert.Equal(t, "getUser", notFoundErr.Operation)
+	assert.Equal(t, "User", notFoundErr.Resource)
+	assert.Equal(t, "user-1", notFoundErr.Id)
+}
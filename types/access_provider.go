@@ -0,0 +1,303 @@
+package types
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/raito-io/sdk-go/types/models"
+)
+
+// AccessProviderSummary contains a reduced set of fields of an AccessProvider, as returned by GetAccessProviderSummary.
+type AccessProviderSummary struct {
+	Id         string                      `json:"id"`
+	Name       string                      `json:"name"`
+	State      models.AccessProviderState  `json:"state"`
+	Action     models.AccessProviderAction `json:"action"`
+	CreatedAt  time.Time                   `json:"createdAt"`
+	ModifiedAt time.Time                   `json:"modifiedAt"`
+}
+
+// AccessProviderWhoWhatItem is a single combination of a who item and a what data object item of an AccessProvider, as returned by GetAccessProviderWhoWhatList.
+type AccessProviderWhoWhatItem struct {
+	Who  *AccessProviderWhoListItem
+	What *AccessProviderWhatListItem
+}
+
+// AccessProviderSyncStatus is the synchronization status of an AccessProvider, as returned by GetAccessProviderSyncStatus.
+// DataSources contains the SyncStatus of the AccessProvider for each data source, keyed by the id of the data source.
+type AccessProviderSyncStatus struct {
+	Id          string
+	State       models.AccessProviderState
+	DataSources map[string]SyncStatus
+}
+
+// IsSynced returns true if the AccessProvider is synchronized to all of its data sources.
+func (s *AccessProviderSyncStatus) IsSynced() bool {
+	for _, status := range s.DataSources {
+		if status != SyncStatusSynced {
+			return false
+		}
+	}
+
+	return true
+}
+
+// AccessProviderWithWhoWhatLimit is the maximum number of who items and of what data objects that GetAccessProviderWithWhoWhat loads.
+const AccessProviderWithWhoWhatLimit = 100
+
+// AccessProviderWithWhoWhat is an AccessProvider together with its complete who and what data object lists, as returned by GetAccessProviderWithWhoWhat.
+type AccessProviderWithWhoWhat struct {
+	AccessProvider *AccessProvider
+	WhoList        []AccessProviderWhoListItem
+	WhatList       []AccessProviderWhatListItem
+}
+
+// AccessProviderResult is the result for a single AccessProvider of a batch operation.
+// Index refers to the position of the corresponding input in the batch.
+// Either AccessProvider or Err is set.
+type AccessProviderResult struct {
+	Index          int
+	AccessProvider *AccessProvider
+	Err            error
+}
+
+// AccessProviderDeleteResult is the result for a single AccessProvider of a batch delete.
+// Index refers to the position of the corresponding id in the batch.
+// Err is set if the AccessProvider could not be deleted.
+type AccessProviderDeleteResult struct {
+	Index int
+	Id    string
+	Err   error
+}
+
+// AccessProviderPatch contains the fields to update with PatchAccessProvider.
+// Fields that are nil are not sent and remain unchanged. To clear a list, set it to a pointer to an empty slice.
+type AccessProviderPatch struct {
+	Name                   *string                          `json:"name,omitempty"`
+	NamingHint             *string                          `json:"namingHint,omitempty"`
+	Action                 *models.AccessProviderAction     `json:"action,omitempty"`
+	Description            *string                          `json:"description,omitempty"`
+	Category               *string                          `json:"category,omitempty"`
+	Source                 *string                          `json:"source,omitempty"`
+	WhoType                *WhoAndWhatType                  `json:"whoType,omitempty"`
+	WhoAbacRule            *WhoAbacRuleInput                `json:"whoAbacRule,omitempty"`
+	WhoItems               *[]WhoItemInput                  `json:"whoItems,omitempty"`
+	WhatType               *WhoAndWhatType                  `json:"whatType,omitempty"`
+	WhatAbacRule           *WhatAbacRuleInput               `json:"whatAbacRule,omitempty"`
+	PolicyRule             *string                          `json:"policyRule,omitempty"`
+	FilterCriteria         *DataComparisonExpressionInput   `json:"filterCriteria,omitempty"`
+	DataSources            *[]AccessProviderDataSourceInput `json:"dataSources,omitempty"`
+	CommonWhatDataObjectId *string                          `json:"commonWhatDataObjectId,omitempty"`
+	WhatDataObjects        *[]AccessProviderWhatInputDO     `json:"whatDataObjects,omitempty"`
+	WhatAccessProviders    *[]AccessProviderWhatInputAP     `json:"whatAccessProviders,omitempty"`
+	Locks                  *[]AccessProviderLockDataInput   `json:"locks,omitempty"`
+	External               *bool                            `json:"external,omitempty"`
+}
+
+// Who item types that can be used in AccessProviderWhoListFilter.ItemTypes.
+const (
+	WhoItemTypeUser           = "User"
+	WhoItemTypeGroup          = "Group"
+	WhoItemTypeAccessProvider = "AccessProvider"
+)
+
+// AccessProviderWhoListFilter is used to filter the who items of an AccessProvider.
+// Search is applied by the Raito API. As the Raito API does not support filtering who items on type or identity store, ItemTypes and IdentityStoreId are applied while paging through the results.
+type AccessProviderWhoListFilter struct {
+	// Search only returns who items matching the search query.
+	Search *string
+	// ItemTypes only returns who items of the given types, e.g. WhoItemTypeUser. All types are returned if empty.
+	ItemTypes []string
+	// IdentityStoreId only returns who items of the identity store with the given id.
+	// As the who list only includes the identity store of groups, users and AccessProviders are not returned if it is set.
+	IdentityStoreId *string
+}
+
+// Matches returns true if the given who item passes the client-side part of the filter.
+// A nil filter matches all who items.
+func (f *AccessProviderWhoListFilter) Matches(item *AccessProviderWhoListItem) bool {
+	if f == nil {
+		return true
+	}
+
+	if len(f.ItemTypes) > 0 && (item.Item == nil || item.Item.GetTypename() == nil || !slices.Contains(f.ItemTypes, *item.Item.GetTypename())) {
+		return false
+	}
+
+	if f.IdentityStoreId != nil {
+		group, ok := item.Item.(*AccessProviderWhoListItemItemGroup)
+		if !ok || group == nil || group.IdentityStore.Id != *f.IdentityStoreId {
+			return false
+		}
+	}
+
+	return true
+}
+
+// WhoListGroups contains the who items of an AccessProvider partitioned by the type of the item, as returned by GetAccessProviderWhoListGrouped.
+type WhoListGroups struct {
+	Users           []AccessProviderWhoListItem
+	Groups          []AccessProviderWhoListItem
+	AccessProviders []AccessProviderWhoListItem
+}
+
+// Add adds the who item to the group of its type. Items of other types are ignored.
+func (g *WhoListGroups) Add(item AccessProviderWhoListItem) {
+	switch item.Item.(type) {
+	case *AccessProviderWhoListItemItemUser:
+		g.Users = append(g.Users, item)
+	case *AccessProviderWhoListItemItemGroup:
+		g.Groups = append(g.Groups, item)
+	case *AccessProviderWhoListItemItemAccessProvider:
+		g.AccessProviders = append(g.AccessProviders, item)
+	}
+}
+
+// AccessProviderWhoAccessProviderRef is a reference to an AccessProvider that is included in the who list of another AccessProvider.
+type AccessProviderWhoAccessProviderRef struct {
+	Id        string
+	Name      string
+	Type      AccessWhoItemType
+	ExpiresAt *time.Time
+}
+
+// WhoItemAccessProviderRef returns the referenced AccessProvider if the who item is an AccessProvider.
+// False is returned for all other who items, e.g. users and groups.
+func WhoItemAccessProviderRef(item *AccessProviderWhoListItem) (*AccessProviderWhoAccessProviderRef, bool) {
+	ap, ok := item.Item.(*AccessProviderWhoListItemItemAccessProvider)
+	if !ok || ap == nil {
+		return nil, false
+	}
+
+	return &AccessProviderWhoAccessProviderRef{
+		Id:        ap.Id,
+		Name:      ap.Name,
+		Type:      item.Type,
+		ExpiresAt: item.ExpiresAt,
+	}, true
+}
+
+// AccessProviderWhatLeafItem is a leaf data object to which an AccessProvider grants access through a data object in its what list, as returned by GetAccessProviderWhatLeafDataObjects.
+type AccessProviderWhatLeafItem struct {
+	// DataObject is the leaf data object.
+	DataObject *DataObject
+	// WhatDataObject is the data object in the what list containing DataObject. It equals DataObject if the data object in the what list is a leaf itself.
+	WhatDataObject *AccessProviderWhatListItemDataObject
+	// Depth is the number of levels between WhatDataObject and DataObject.
+	Depth             int
+	Permissions       []*string
+	GlobalPermissions []*string
+}
+
+// DataObjectTypeColumn is the type of data objects representing a column.
+const DataObjectTypeColumn = "column"
+
+// AccessProviderWhatColumnItem is a column in the what list of an AccessProvider, as returned by GetAccessProviderWhatColumnList.
+type AccessProviderWhatColumnItem struct {
+	// Column is the name of the column.
+	Column string
+	// ParentFullName is the full name of the data object containing the column, e.g. a table or view.
+	// The parent data object can be retrieved with GetDataObjectByFullName.
+	ParentFullName    string
+	DataObject        *AccessProviderWhatListItemDataObject
+	Permissions       []*string
+	GlobalPermissions []*string
+}
+
+// WhatItemColumn returns the column if the data object of the what item is a column.
+// False is returned for all other data objects, e.g. tables and schemas.
+func WhatItemColumn(item *AccessProviderWhatListItem) (*AccessProviderWhatColumnItem, bool) {
+	if item.DataObject == nil || item.DataObject.Type != DataObjectTypeColumn {
+		return nil, false
+	}
+
+	parentFullName := ""
+	if i := strings.LastIndex(item.DataObject.FullName, "."); i >= 0 {
+		parentFullName = item.DataObject.FullName[:i]
+	}
+
+	return &AccessProviderWhatColumnItem{
+		Column:            item.DataObject.Name,
+		ParentFullName:    parentFullName,
+		DataObject:        item.DataObject,
+		Permissions:       item.Permissions,
+		GlobalPermissions: item.GlobalPermissions,
+	}, true
+}
+
+// AccessProviderWhatPermissions are the permissions an AccessProvider grants on a single data object in its what list.
+// Permissions are the data source specific permissions, e.g. SELECT. GlobalPermissions are the Raito global permissions, e.g. read or write.
+type AccessProviderWhatPermissions struct {
+	Permissions       []string
+	GlobalPermissions []string
+}
+
+// WhatListPermissions returns the permissions granted per data object in the what list, keyed by the id of the data object.
+// The what list can be retrieved with GetAccessProviderWhatDataObjectList, which selects both the permissions and the global permissions of each item.
+// Items without a data object are ignored. If a data object appears multiple times, its permissions are merged.
+func WhatListPermissions(items []AccessProviderWhatListItem) map[string]AccessProviderWhatPermissions {
+	result := make(map[string]AccessProviderWhatPermissions, len(items))
+
+	for i := range items {
+		if items[i].DataObject == nil {
+			continue
+		}
+
+		permissions := result[items[i].DataObject.Id]
+		permissions.Permissions = appendPermissions(permissions.Permissions, items[i].Permissions)
+		permissions.GlobalPermissions = appendPermissions(permissions.GlobalPermissions, items[i].GlobalPermissions)
+
+		result[items[i].DataObject.Id] = permissions
+	}
+
+	return result
+}
+
+func appendPermissions(permissions []string, toAdd []*string) []string {
+	for _, permission := range toAdd {
+		if permission != nil && !slices.Contains(permissions, *permission) {
+			permissions = append(permissions, *permission)
+		}
+	}
+
+	return permissions
+}
+
+// IsAccessProviderLocked returns true if the AccessProvider has at least one lock, e.g. because it is managed externally.
+func IsAccessProviderLocked(ap *AccessProvider) bool {
+	return len(ap.Locks) > 0
+}
+
+// AccessProviderDeletedAt returns the time at which the AccessProvider was deleted, and false if the AccessProvider is not deleted.
+// As the Raito API does not expose a separate deletion time, this is the time of the last modification of the deleted AccessProvider.
+func AccessProviderDeletedAt(ap *AccessProvider) (time.Time, bool) {
+	if ap.State != models.AccessProviderStateDeleted {
+		return time.Time{}, false
+	}
+
+	return ap.ModifiedAt, true
+}
+
+// IsAccessProviderInternalizable returns false if Raito Cloud cannot take over the management of the AccessProvider, e.g. because it cannot be represented in Raito Cloud.
+// The Raito API does not expose the reason why an AccessProvider is not internalizable.
+func IsAccessProviderInternalizable(ap *AccessProvider) bool {
+	return !ap.NotInternalizable
+}
+
+// AccessProviderLockReasons returns the reason of each lock of the AccessProvider.
+// The reason is empty if no reason is provided for the lock.
+func AccessProviderLockReasons(ap *AccessProvider) map[AccessProviderLock]string {
+	reasons := make(map[AccessProviderLock]string, len(ap.Locks))
+
+	for i := range ap.Locks {
+		reason := ""
+		if ap.Locks[i].Details.Reason != nil {
+			reason = *ap.Locks[i].Details.Reason
+		}
+
+		reasons[ap.Locks[i].LockKey] = reason
+	}
+
+	return reasons
+}
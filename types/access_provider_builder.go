@@ -0,0 +1,217 @@
+package types
+
+import (
+	"time"
+
+	"github.com/raito-io/sdk-go/types/models"
+)
+
+// AccessProviderBuilder can be used to construct an AccessProviderInput without handling the optional pointer fields manually.
+// Name and Action are required and are validated when calling Build.
+type AccessProviderBuilder struct {
+	input AccessProviderInput
+}
+
+// NewAccessProviderBuilder creates a new, empty AccessProviderBuilder.
+func NewAccessProviderBuilder() *AccessProviderBuilder {
+	return &AccessProviderBuilder{}
+}
+
+// WithName sets the name of the AccessProvider.
+func (b *AccessProviderBuilder) WithName(name string) *AccessProviderBuilder {
+	b.input.Name = &name
+
+	return b
+}
+
+// WithNamingHint sets the naming hint of the AccessProvider.
+func (b *AccessProviderBuilder) WithNamingHint(namingHint string) *AccessProviderBuilder {
+	b.input.NamingHint = &namingHint
+
+	return b
+}
+
+// WithAction sets the action of the AccessProvider.
+func (b *AccessProviderBuilder) WithAction(action models.AccessProviderAction) *AccessProviderBuilder {
+	b.input.Action = &action
+
+	return b
+}
+
+// WithDescription sets the description of the AccessProvider.
+func (b *AccessProviderBuilder) WithDescription(description string) *AccessProviderBuilder {
+	b.input.Description = &description
+
+	return b
+}
+
+// WithCategory sets the grant category id of the AccessProvider.
+func (b *AccessProviderBuilder) WithCategory(category string) *AccessProviderBuilder {
+	b.input.Category = &category
+
+	return b
+}
+
+// WithSource sets the source of the AccessProvider.
+func (b *AccessProviderBuilder) WithSource(source string) *AccessProviderBuilder {
+	b.input.Source = &source
+
+	return b
+}
+
+// WithWhoType sets the who type of the AccessProvider.
+func (b *AccessProviderBuilder) WithWhoType(whoType WhoAndWhatType) *AccessProviderBuilder {
+	b.input.WhoType = &whoType
+
+	return b
+}
+
+// WithWhoAbacRule sets the who ABAC rule of the AccessProvider.
+func (b *AccessProviderBuilder) WithWhoAbacRule(rule WhoAbacRuleInput) *AccessProviderBuilder {
+	b.input.WhoAbacRule = &rule
+
+	return b
+}
+
+// WithWhoItem adds who items to the AccessProvider.
+func (b *AccessProviderBuilder) WithWhoItem(items ...WhoItemInput) *AccessProviderBuilder {
+	b.input.WhoItems = append(b.input.WhoItems, items...)
+
+	return b
+}
+
+// WithWhatType sets the what type of the AccessProvider.
+func (b *AccessProviderBuilder) WithWhatType(whatType WhoAndWhatType) *AccessProviderBuilder {
+	b.input.WhatType = &whatType
+
+	return b
+}
+
+// WithWhatAbacRule sets the what ABAC rule of the AccessProvider.
+func (b *AccessProviderBuilder) WithWhatAbacRule(rule WhatAbacRuleInput) *AccessProviderBuilder {
+	b.input.WhatAbacRule = &rule
+
+	return b
+}
+
+// WithPolicyRule sets the policy rule of the AccessProvider.
+func (b *AccessProviderBuilder) WithPolicyRule(policyRule string) *AccessProviderBuilder {
+	b.input.PolicyRule = &policyRule
+
+	return b
+}
+
+// WithFilterCriteria sets the filter criteria of the AccessProvider.
+func (b *AccessProviderBuilder) WithFilterCriteria(filterCriteria DataComparisonExpressionInput) *AccessProviderBuilder {
+	b.input.FilterCriteria = &filterCriteria
+
+	return b
+}
+
+// WithDataSource adds data sources to the AccessProvider.
+func (b *AccessProviderBuilder) WithDataSource(dataSources ...AccessProviderDataSourceInput) *AccessProviderBuilder {
+	b.input.DataSources = append(b.input.DataSources, dataSources...)
+
+	return b
+}
+
+// WithCommonWhatDataObjectId sets the common what data object id of the AccessProvider.
+func (b *AccessProviderBuilder) WithCommonWhatDataObjectId(dataObjectId string) *AccessProviderBuilder {
+	b.input.CommonWhatDataObjectId = &dataObjectId
+
+	return b
+}
+
+// WithWhatDataObject adds what data objects to the AccessProvider.
+func (b *AccessProviderBuilder) WithWhatDataObject(whatDataObjects ...AccessProviderWhatInputDO) *AccessProviderBuilder {
+	b.input.WhatDataObjects = append(b.input.WhatDataObjects, whatDataObjects...)
+
+	return b
+}
+
+// WithWhatAccessProvider adds what access providers to the AccessProvider.
+func (b *AccessProviderBuilder) WithWhatAccessProvider(whatAccessProviders ...AccessProviderWhatInputAP) *AccessProviderBuilder {
+	b.input.WhatAccessProviders = append(b.input.WhatAccessProviders, whatAccessProviders...)
+
+	return b
+}
+
+// WithLock adds locks to the AccessProvider.
+func (b *AccessProviderBuilder) WithLock(locks ...AccessProviderLockDataInput) *AccessProviderBuilder {
+	b.input.Locks = append(b.input.Locks, locks...)
+
+	return b
+}
+
+// WithLocked locks all properties of the AccessProvider if locked is true, so it can only be changed by its source, e.g. an import.
+// If locked is false, all locks are removed, including the locks added with WithLock.
+func (b *AccessProviderBuilder) WithLocked(locked bool) *AccessProviderBuilder {
+	b.input.Locks = []AccessProviderLockDataInput{}
+
+	if locked {
+		for _, lock := range accessProviderLocks {
+			b.input.Locks = append(b.input.Locks, AccessProviderLockDataInput{LockKey: lock})
+		}
+	}
+
+	return b
+}
+
+// WithExternal sets the external flag of the AccessProvider.
+func (b *AccessProviderBuilder) WithExternal(external bool) *AccessProviderBuilder {
+	b.input.External = &external
+
+	return b
+}
+
+// Build returns the constructed AccessProviderInput.
+// An ErrInvalidInput is returned if the input is not valid according to ValidateAccessProviderInput.
+func (b *AccessProviderBuilder) Build() (AccessProviderInput, error) {
+	err := ValidateAccessProviderInput(&b.input)
+	if err != nil {
+		return AccessProviderInput{}, err
+	}
+
+	return b.input, nil
+}
+
+// WhoItemBuilder can be used to construct a WhoItemInput for AccessProviderBuilder.WithWhoItem, e.g. to grant temporary access.
+type WhoItemBuilder struct {
+	input WhoItemInput
+}
+
+// NewUserWhoItem creates a WhoItemBuilder for the user with the given id.
+func NewUserWhoItem(userId string) *WhoItemBuilder {
+	return &WhoItemBuilder{input: WhoItemInput{User: &userId}}
+}
+
+// NewGroupWhoItem creates a WhoItemBuilder for the group with the given id.
+func NewGroupWhoItem(groupId string) *WhoItemBuilder {
+	return &WhoItemBuilder{input: WhoItemInput{Group: &groupId}}
+}
+
+// NewAccessProviderWhoItem creates a WhoItemBuilder for the AccessProvider with the given id, granting access to all its who items.
+func NewAccessProviderWhoItem(accessProviderId string) *WhoItemBuilder {
+	return &WhoItemBuilder{input: WhoItemInput{AccessProvider: &accessProviderId}}
+}
+
+// WithType sets the type of the who item, e.g. AccessWhoItemTypeWhopromise to promise access instead of granting it.
+func (b *WhoItemBuilder) WithType(whoItemType AccessWhoItemType) *WhoItemBuilder {
+	b.input.Type = &whoItemType
+
+	return b
+}
+
+// WithExpiresAt sets the time at which the access of the who item expires.
+// Access is granted as soon as the AccessProvider is created or updated, as the Raito API does not support a start time for who items.
+// The expiry time is returned in the ExpiresAt field of the items of GetAccessProviderWhoList.
+func (b *WhoItemBuilder) WithExpiresAt(expiresAt time.Time) *WhoItemBuilder {
+	b.input.ExpiresAt = &expiresAt
+
+	return b
+}
+
+// Build returns the constructed WhoItemInput.
+func (b *WhoItemBuilder) Build() WhoItemInput {
+	return b.input
+}
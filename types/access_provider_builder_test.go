@@ -0,0 +1,69 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/raito-io/sdk-go/types/models"
+)
+
+func TestAccessProviderBuilder(t *testing.T) {
+	user := "user-1"
+	dataObject := "do-1"
+
+	input, err := NewAccessProviderBuilder().
+		WithName("my grant").
+		WithAction(models.AccessProviderActionGrant).
+		WithDescription("description").
+		WithDataSource(AccessProviderDataSourceInput{DataSource: "ds-1"}).
+		WithWhoItem(WhoItemInput{User: &user}).
+		WithWhatDataObject(AccessProviderWhatInputDO{DataObjects: []*string{&dataObject}}).
+		Build()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "my grant", *input.Name)
+	assert.Equal(t, models.AccessProviderActionGrant, *input.Action)
+	assert.Equal(t, "description", *input.Description)
+	assert.Equal(t, []AccessProviderDataSourceInput{{DataSource: "ds-1"}}, input.DataSources)
+	assert.Equal(t, []WhoItemInput{{User: &user}}, input.WhoItems)
+	assert.Equal(t, []AccessProviderWhatInputDO{{DataObjects: []*string{&dataObject}}}, input.WhatDataObjects)
+	assert.Nil(t, input.Category)
+}
+
+func TestAccessProviderBuilder_MissingRequiredFields(t *testing.T) {
+	_, err := NewAccessProviderBuilder().WithName(" ").Build()
+
+	var invalidInputErr *ErrInvalidInput
+	assert.ErrorAs(t, err, &invalidInputErr)
+	assert.ErrorContains(t, err, "name is required")
+	assert.ErrorContains(t, err, "action is required")
+}
+
+func TestAccessProviderBuilder_WithLocked(t *testing.T) {
+	builder := NewAccessProviderBuilder().WithName("my grant").WithAction(models.AccessProviderActionGrant).WithLock(AccessProviderLockDataInput{LockKey: AccessProviderLockNamelock})
+
+	input, err := builder.WithLocked(true).Build()
+
+	assert.NoError(t, err)
+	assert.Len(t, input.Locks, 6)
+	assert.Contains(t, input.Locks, AccessProviderLockDataInput{LockKey: AccessProviderLockDeletelock})
+
+	input, err = builder.WithLocked(false).Build()
+
+	assert.NoError(t, err)
+	assert.Empty(t, input.Locks)
+	assert.NotNil(t, input.Locks)
+}
+
+func TestWhoItemBuilder(t *testing.T) {
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	item := NewUserWhoItem("user-1").WithExpiresAt(expiresAt).WithType(AccessWhoItemTypeWhogrant).Build()
+
+	assert.Equal(t, "user-1", *item.User)
+	assert.Nil(t, item.Group)
+	assert.Equal(t, expiresAt, *item.ExpiresAt)
+	assert.Equal(t, AccessWhoItemTypeWhogrant, *item.Type)
+}
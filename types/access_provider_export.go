@@ -0,0 +1,200 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// AccessProviderExport is a single AccessProvider together with its who and what lists, as written by ExportAccessProviders.
+type AccessProviderExport struct {
+	AccessProvider      *AccessProvider                `json:"accessProvider"`
+	WhoList             []AccessProviderWhoListItem    `json:"whoList"`
+	WhatDataObjects     []AccessProviderWhatListItem   `json:"whatDataObjects"`
+	WhatAccessProviders []AccessWhatAccessProviderItem `json:"whatAccessProviders"`
+}
+
+// AccessProviderImportResult is the result for a single line of an import with ImportAccessProviders.
+// Line is the line number in the import, starting at 1. Id is the id of the AccessProvider in the export, if it could be read.
+// Either AccessProvider or Err is set. Created is true if a new AccessProvider was created instead of updating an existing one.
+type AccessProviderImportResult struct {
+	Line           int
+	Id             string
+	AccessProvider *AccessProvider
+	Created        bool
+	Err            error
+}
+
+// AccessProviderImportIdMapping returns the mapping of the ids in an export to the ids in Raito Cloud of the successfully imported AccessProviders in results.
+// It can be passed to the next import with services.WithAccessProviderImportIdMapping, so created AccessProviders are updated instead of created again.
+func AccessProviderImportIdMapping(results []AccessProviderImportResult) map[string]string {
+	mapping := make(map[string]string, len(results))
+
+	for i := range results {
+		if results[i].Err == nil && results[i].AccessProvider != nil && results[i].Id != "" {
+			mapping[results[i].Id] = results[i].AccessProvider.Id
+		}
+	}
+
+	return mapping
+}
+
+// AccessProviderExportInput converts an AccessProviderExport to the AccessProviderInput that recreates the AccessProvider.
+// The who and what lists of the export are only used for static who and what types. For dynamic types, the ABAC rule of the AccessProvider is used instead.
+// An ErrInvalidInput is returned if the export cannot be converted, e.g. because of a who item type that cannot be set in an AccessProviderInput.
+func AccessProviderExportInput(export *AccessProviderExport) (*AccessProviderInput, error) {
+	ap := export.AccessProvider
+	if ap == nil {
+		return nil, NewErrInvalidInput("export does not contain an access provider")
+	}
+
+	input := &AccessProviderInput{
+		Name:        &ap.Name,
+		NamingHint:  ap.NamingHint,
+		Action:      &ap.Action,
+		Description: &ap.Description,
+		PolicyRule:  ap.PolicyRule,
+		External:    &ap.External,
+		WhoType:     &ap.WhoType,
+		WhatType:    &ap.WhatType,
+	}
+
+	if ap.Category != nil {
+		input.Category = &ap.Category.Id
+	}
+
+	for i := range ap.SyncData {
+		dataSource := AccessProviderDataSourceInput{DataSource: ap.SyncData[i].DataSource.Id}
+
+		if ap.SyncData[i].MaskType != nil {
+			dataSource.Type = &ap.SyncData[i].MaskType.ExternalId
+		} else if ap.SyncData[i].AccessProviderType != nil {
+			dataSource.Type = ap.SyncData[i].AccessProviderType.Type
+		}
+
+		input.DataSources = append(input.DataSources, dataSource)
+	}
+
+	for i := range ap.Locks {
+		input.Locks = append(input.Locks, AccessProviderLockDataInput{
+			LockKey: ap.Locks[i].LockKey,
+			Details: &AccessProviderLockDetailsInput{Reason: ap.Locks[i].Details.Reason},
+		})
+	}
+
+	var errs []error
+
+	if ap.WhoType == WhoAndWhatTypeDynamic && ap.WhoAbacRule != nil {
+		rule, err := abacRuleInput(ap.WhoAbacRule.RuleJson)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("whoAbacRule: %w", err))
+		}
+
+		input.WhoAbacRule = &WhoAbacRuleInput{
+			Rule:            rule,
+			Type:            ap.WhoAbacRule.Type,
+			PromiseDuration: ap.WhoAbacRule.PromiseDuration,
+		}
+	} else {
+		for i := range export.WhoList {
+			whoItem, err := whoItemInput(&export.WhoList[i])
+			if err != nil {
+				errs = append(errs, fmt.Errorf("whoList[%d]: %w", i, err))
+
+				continue
+			}
+
+			input.WhoItems = append(input.WhoItems, *whoItem)
+		}
+	}
+
+	if ap.WhatType == WhoAndWhatTypeDynamic && ap.WhatAbacRule != nil {
+		rule, err := abacRuleInput(ap.WhatAbacRule.RuleJson)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("whatAbacRule: %w", err))
+		}
+
+		input.WhatAbacRule = &WhatAbacRuleInput{
+			DoTypes:           ap.WhatAbacRule.DoTypes,
+			Permissions:       ap.WhatAbacRule.Permissions,
+			GlobalPermissions: ap.WhatAbacRule.GlobalPermissions,
+			Rule:              rule,
+		}
+	} else {
+		for i := range export.WhatDataObjects {
+			whatItem := &export.WhatDataObjects[i]
+			if whatItem.DataObject == nil {
+				errs = append(errs, fmt.Errorf("whatDataObjects[%d]: no data object", i))
+
+				continue
+			}
+
+			input.WhatDataObjects = append(input.WhatDataObjects, AccessProviderWhatInputDO{
+				DataObjects:       []*string{&whatItem.DataObject.Id},
+				Permissions:       whatItem.Permissions,
+				GlobalPermissions: whatItem.GlobalPermissions,
+			})
+		}
+	}
+
+	for i := range export.WhatAccessProviders {
+		if export.WhatAccessProviders[i].AccessProvider == nil {
+			errs = append(errs, fmt.Errorf("whatAccessProviders[%d]: no access provider", i))
+
+			continue
+		}
+
+		input.WhatAccessProviders = append(input.WhatAccessProviders, AccessProviderWhatInputAP{
+			AccessProvider: export.WhatAccessProviders[i].AccessProvider.Id,
+			ExpiresAt:      export.WhatAccessProviders[i].ExpiresAt,
+		})
+	}
+
+	if len(errs) > 0 {
+		return nil, NewErrInvalidInput(fmt.Sprintf("access provider %q cannot be imported: %s", ap.Id, errors.Join(errs...)))
+	}
+
+	return input, nil
+}
+
+func whoItemInput(item *AccessProviderWhoListItem) (*WhoItemInput, error) {
+	input := &WhoItemInput{
+		ExpiresAt:       item.ExpiresAt,
+		ExpiresAfter:    item.ExpiresAfter,
+		PromiseDuration: item.PromiseDuration,
+		Type:            &item.Type,
+	}
+
+	switch whoItem := item.Item.(type) {
+	case *AccessProviderWhoListItemItemUser:
+		input.User = &whoItem.Id
+	case *AccessProviderWhoListItemItemGroup:
+		input.Group = &whoItem.Id
+	case *AccessProviderWhoListItemItemAccessProvider:
+		input.AccessProvider = &whoItem.Id
+	default:
+		return nil, fmt.Errorf("unsupported who item type %T", item.Item)
+	}
+
+	return input, nil
+}
+
+// abacRuleInput decodes the JSON representation of an ABAC rule, as returned by the Raito API, to an AbacComparisonExpressionInput.
+// Unknown fields are rejected, so a rule is never imported partially.
+func abacRuleInput(ruleJson *string) (AbacComparisonExpressionInput, error) {
+	var rule AbacComparisonExpressionInput
+
+	if ruleJson == nil {
+		return rule, errors.New("no rule")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader([]byte(*ruleJson)))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&rule); err != nil {
+		return rule, fmt.Errorf("invalid rule: %w", err)
+	}
+
+	return rule, nil
+}
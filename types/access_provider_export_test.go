@@ -0,0 +1,95 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/types/models"
+)
+
+func TestAccessProviderExportInput(t *testing.T) {
+	t.Run("Static", func(t *testing.T) {
+		permission := "SELECT"
+
+		export := &AccessProviderExport{
+			AccessProvider: &AccessProvider{
+				Id:       "ap1",
+				Name:     "ap 1",
+				Action:   models.AccessProviderActionGrant,
+				WhoType:  WhoAndWhatTypeStatic,
+				WhatType: WhoAndWhatTypeStatic,
+				Category: &AccessProviderCategoryGrantCategory{GrantCategory: GrantCategory{Id: "cat1"}},
+			},
+			WhoList: []AccessProviderWhoListItem{
+				{Type: AccessWhoItemTypeWhogrant, Item: &AccessProviderWhoListItemItemGroup{Id: "g1"}},
+				{Type: AccessWhoItemTypeWhopromise, Item: &AccessProviderWhoListItemItemAccessProvider{Id: "ap2"}},
+			},
+			WhatDataObjects: []AccessProviderWhatListItem{
+				{DataObject: &AccessProviderWhatListItemDataObject{DataObject: DataObject{Id: "do1"}}, Permissions: []*string{&permission}},
+			},
+			WhatAccessProviders: []AccessWhatAccessProviderItem{
+				{AccessProvider: &AccessWhatAccessProviderItemAccessProvider{AccessProvider: AccessProvider{Id: "ap3"}}},
+			},
+		}
+
+		input, err := AccessProviderExportInput(export)
+
+		require.NoError(t, err)
+		assert.Equal(t, "ap 1", *input.Name)
+		assert.Equal(t, "cat1", *input.Category)
+		require.Len(t, input.WhoItems, 2)
+		assert.Equal(t, "g1", *input.WhoItems[0].Group)
+		assert.Equal(t, "ap2", *input.WhoItems[1].AccessProvider)
+		assert.Equal(t, AccessWhoItemTypeWhopromise, *input.WhoItems[1].Type)
+		require.Len(t, input.WhatDataObjects, 1)
+		assert.Equal(t, "do1", *input.WhatDataObjects[0].DataObjects[0])
+		assert.Equal(t, []AccessProviderWhatInputAP{{AccessProvider: "ap3"}}, input.WhatAccessProviders)
+		assert.NoError(t, ValidateAccessProviderInput(input))
+	})
+
+	t.Run("Dynamic", func(t *testing.T) {
+		rule := `{"literal": true}`
+
+		export := &AccessProviderExport{
+			AccessProvider: &AccessProvider{
+				Id:          "ap1",
+				Name:        "ap 1",
+				Action:      models.AccessProviderActionGrant,
+				WhoType:     WhoAndWhatTypeDynamic,
+				WhoAbacRule: &AccessProviderWhoAbacRule{WhoAbacRule: WhoAbacRule{Type: AccessWhoItemTypeWhogrant, RuleJson: &rule}},
+				WhatType:    WhoAndWhatTypeStatic,
+			},
+			WhoList: []AccessProviderWhoListItem{
+				{Type: AccessWhoItemTypeWhogrant, Item: &AccessProviderWhoListItemItemGroup{Id: "g1"}},
+			},
+		}
+
+		input, err := AccessProviderExportInput(export)
+
+		require.NoError(t, err)
+		assert.Empty(t, input.WhoItems)
+		require.NotNil(t, input.WhoAbacRule)
+		assert.True(t, *input.WhoAbacRule.Rule.Literal)
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		rule := `{"unknownOperator": true}`
+
+		export := &AccessProviderExport{
+			AccessProvider: &AccessProvider{
+				Id:          "ap1",
+				WhoType:     WhoAndWhatTypeDynamic,
+				WhoAbacRule: &AccessProviderWhoAbacRule{WhoAbacRule: WhoAbacRule{RuleJson: &rule}},
+			},
+			WhatDataObjects: []AccessProviderWhatListItem{{}},
+		}
+
+		_, err := AccessProviderExportInput(export)
+
+		assert.ErrorIs(t, err, &ErrInvalidInput{})
+		assert.ErrorContains(t, err, "whoAbacRule")
+		assert.ErrorContains(t, err, "whatDataObjects[0]")
+	})
+}
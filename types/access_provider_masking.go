@@ -0,0 +1,52 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/raito-io/sdk-go/types/models"
+)
+
+// MaskingPolicy is an AccessProvider with the Mask action, as returned by GetMaskingPolicy.
+// The who items of a masking policy are the users and groups for which the data is masked, the what data objects are the masked columns.
+type MaskingPolicy struct {
+	AccessProvider *AccessProvider
+	// MaskTypes contains the mask type of the masking policy in each data source, keyed by data source id.
+	// Data sources in which the default mask type is used are not included.
+	MaskTypes map[string]MaskType
+}
+
+// NewMaskingPolicy returns the MaskingPolicy of the AccessProvider.
+// An ErrInvalidInput is returned if the AccessProvider does not have the Mask action.
+func NewMaskingPolicy(ap *AccessProvider) (*MaskingPolicy, error) {
+	if ap.Action != models.AccessProviderActionMask {
+		return nil, NewErrInvalidInput(fmt.Sprintf("access provider %q is not a masking policy: action is %s", ap.Id, ap.Action))
+	}
+
+	maskTypes := make(map[string]MaskType, len(ap.SyncData))
+
+	for i := range ap.SyncData {
+		if ap.SyncData[i].MaskType != nil {
+			maskTypes[ap.SyncData[i].DataSource.Id] = ap.SyncData[i].MaskType.MaskType
+		}
+	}
+
+	return &MaskingPolicy{
+		AccessProvider: ap,
+		MaskTypes:      maskTypes,
+	}, nil
+}
+
+// ValidateMaskingPolicyInput checks the AccessProviderInput of a masking policy before it is sent to Raito Cloud.
+// In addition to the checks of ValidateAccessProviderInput, the action should be Mask and at least one data source is required.
+// The mask type of a data source can be set with the Type of the AccessProviderDataSourceInput.
+func ValidateMaskingPolicyInput(input *AccessProviderInput) error {
+	if input.Action != nil && *input.Action != models.AccessProviderActionMask {
+		return NewErrInvalidInput(fmt.Sprintf("action of a masking policy should be %s, got %s", models.AccessProviderActionMask, *input.Action))
+	}
+
+	if len(input.DataSources) == 0 {
+		return NewErrInvalidInput("a masking policy requires at least one data source")
+	}
+
+	return ValidateAccessProviderInput(input)
+}
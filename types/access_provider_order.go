@@ -0,0 +1,67 @@
+package types
+
+// The following helpers return an AccessProviderOrderByInput that can be passed to WithAccessProviderListOrder.
+
+// OrderByNameAsc orders AccessProviders by name, ascending.
+func OrderByNameAsc() AccessProviderOrderByInput {
+	return AccessProviderOrderByInput{Name: sortPtr(SortAsc)}
+}
+
+// OrderByNameDesc orders AccessProviders by name, descending.
+func OrderByNameDesc() AccessProviderOrderByInput {
+	return AccessProviderOrderByInput{Name: sortPtr(SortDesc)}
+}
+
+// OrderByCreatedAsc orders AccessProviders by creation time, oldest first.
+func OrderByCreatedAsc() AccessProviderOrderByInput {
+	return AccessProviderOrderByInput{CreatedAt: sortPtr(SortAsc)}
+}
+
+// OrderByCreatedDesc orders AccessProviders by creation time, most recent first.
+func OrderByCreatedDesc() AccessProviderOrderByInput {
+	return AccessProviderOrderByInput{CreatedAt: sortPtr(SortDesc)}
+}
+
+// OrderByModifiedAsc orders AccessProviders by modification time, least recently modified first.
+func OrderByModifiedAsc() AccessProviderOrderByInput {
+	return AccessProviderOrderByInput{ModifiedAt: sortPtr(SortAsc)}
+}
+
+// OrderByModifiedDesc orders AccessProviders by modification time, most recently modified first.
+func OrderByModifiedDesc() AccessProviderOrderByInput {
+	return AccessProviderOrderByInput{ModifiedAt: sortPtr(SortDesc)}
+}
+
+// OrderByActionAsc orders AccessProviders by action, ascending.
+func OrderByActionAsc() AccessProviderOrderByInput {
+	return AccessProviderOrderByInput{Action: sortPtr(SortAsc)}
+}
+
+// OrderByActionDesc orders AccessProviders by action, descending.
+func OrderByActionDesc() AccessProviderOrderByInput {
+	return AccessProviderOrderByInput{Action: sortPtr(SortDesc)}
+}
+
+// OrderByStateAsc orders AccessProviders by state, ascending.
+func OrderByStateAsc() AccessProviderOrderByInput {
+	return AccessProviderOrderByInput{State: sortPtr(SortAsc)}
+}
+
+// OrderByStateDesc orders AccessProviders by state, descending.
+func OrderByStateDesc() AccessProviderOrderByInput {
+	return AccessProviderOrderByInput{State: sortPtr(SortDesc)}
+}
+
+// OrderBySyncAsc orders AccessProviders by sync status, ascending.
+func OrderBySyncAsc() AccessProviderOrderByInput {
+	return AccessProviderOrderByInput{Sync: sortPtr(SortAsc)}
+}
+
+// OrderBySyncDesc orders AccessProviders by sync status, descending.
+func OrderBySyncDesc() AccessProviderOrderByInput {
+	return AccessProviderOrderByInput{Sync: sortPtr(SortDesc)}
+}
+
+func sortPtr(sort Sort) *Sort {
+	return &sort
+}
@@ -0,0 +1,49 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/raito-io/sdk-go/types/models"
+)
+
+// RowFilter is an AccessProvider with the Filtered action, as returned by GetRowFilter.
+// The who items of a row filter are the users and groups to which the filter applies, the what data objects are the filtered tables.
+type RowFilter struct {
+	AccessProvider *AccessProvider
+	// PolicyRule is the filter policy of the row filter, e.g. "{region} = 'EU'".
+	// It is empty if the filter is defined with filter criteria instead.
+	PolicyRule string
+}
+
+// NewRowFilter returns the RowFilter of the AccessProvider.
+// An ErrInvalidInput is returned if the AccessProvider does not have the Filtered action.
+func NewRowFilter(ap *AccessProvider) (*RowFilter, error) {
+	if ap.Action != models.AccessProviderActionFiltered {
+		return nil, NewErrInvalidInput(fmt.Sprintf("access provider %q is not a row filter: action is %s", ap.Id, ap.Action))
+	}
+
+	policyRule := ""
+	if ap.PolicyRule != nil {
+		policyRule = *ap.PolicyRule
+	}
+
+	return &RowFilter{
+		AccessProvider: ap,
+		PolicyRule:     policyRule,
+	}, nil
+}
+
+// ValidateRowFilterInput checks the AccessProviderInput of a row filter before it is sent to Raito Cloud.
+// In addition to the checks of ValidateAccessProviderInput, the action should be Filtered and a non-empty policy rule is required, unless filter criteria are specified.
+func ValidateRowFilterInput(input *AccessProviderInput) error {
+	if input.Action != nil && *input.Action != models.AccessProviderActionFiltered {
+		return NewErrInvalidInput(fmt.Sprintf("action of a row filter should be %s, got %s", models.AccessProviderActionFiltered, *input.Action))
+	}
+
+	if input.FilterCriteria == nil && (input.PolicyRule == nil || strings.TrimSpace(*input.PolicyRule) == "") {
+		return NewErrInvalidInput("a row filter requires a policy rule or filter criteria")
+	}
+
+	return ValidateAccessProviderInput(input)
+}
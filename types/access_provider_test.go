@@ -0,0 +1,161 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/types/models"
+)
+
+func TestAccessProviderPatch_MarshalJSON(t *testing.T) {
+	name := "new name"
+
+	patch := AccessProviderPatch{
+		Name:     &name,
+		WhoItems: &[]WhoItemInput{},
+	}
+
+	data, err := json.Marshal(patch)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name":"new name","whoItems":[]}`, string(data))
+}
+
+func TestAccessProvider_JSONRoundTrip(t *testing.T) {
+	namingHint := "hint"
+	ruleJson := `{"literal":true}`
+	actualName := "actual_name"
+	reason := "managed by terraform"
+	complete := true
+	promiseDuration := int64(3600)
+
+	original := AccessProvider{
+		Id:         "ap-1",
+		CreatedAt:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		ModifiedAt: time.Date(2024, 2, 3, 4, 5, 6, 0, time.UTC),
+		Name:       "ap",
+		NamingHint: &namingHint,
+		State:      models.AccessProviderStateActive,
+		Action:     models.AccessProviderActionMask,
+		Category: &AccessProviderCategoryGrantCategory{GrantCategory: GrantCategory{
+			Id:   "gc-1",
+			Name: "Grant",
+		}},
+		Description: "description",
+		WhatType:    WhoAndWhatTypeDynamic,
+		WhatAbacRule: &AccessProviderWhatAbacRule{WhatAbacRule: WhatAbacRule{
+			Permissions:       []string{"SELECT"},
+			GlobalPermissions: []string{},
+			DoTypes:           []string{"table"},
+			RuleJson:          &ruleJson,
+		}},
+		WhoType: WhoAndWhatTypeStatic,
+		WhoAbacRule: &AccessProviderWhoAbacRule{WhoAbacRule: WhoAbacRule{
+			PromiseDuration: &promiseDuration,
+			Type:            AccessWhoItemTypeWhogrant,
+			RuleJson:        &ruleJson,
+		}},
+		Complete: &complete,
+		Locks: []AccessProviderLocksAccessProviderLockData{{AccessProviderLocks: AccessProviderLocks{
+			LockKey: AccessProviderLockNamelock,
+			Details: AccessProviderLocksDetailsAccessProviderLockDetails{AccessProviderLockDetails: AccessProviderLockDetails{Reason: &reason}},
+		}}},
+		SyncData: []AccessProviderSyncData{{SyncData: SyncData{
+			DataSource: SyncDataDataSource{DataSource: DataSource{
+				Id:        "ds-1",
+				CreatedAt: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+			}},
+			ActualName: &actualName,
+		}}},
+	}
+
+	data, err := json.Marshal(original)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(data), `"state":"Active"`)
+	assert.Contains(t, string(data), `"action":"Mask"`)
+
+	var result AccessProvider
+	require.NoError(t, json.Unmarshal(data, &result))
+
+	assert.Equal(t, original, result)
+}
+
+func TestAccessProviderInput_JSONRoundTrip(t *testing.T) {
+	name := "ap"
+	action := models.AccessProviderActionGrant
+	user := "user-1"
+
+	original := AccessProviderInput{
+		Name:            &name,
+		Action:          &action,
+		WhoItems:        []WhoItemInput{{User: &user}},
+		DataSources:     []AccessProviderDataSourceInput{{DataSource: "ds-1"}},
+		WhatDataObjects: []AccessProviderWhatInputDO{},
+	}
+
+	data, err := json.Marshal(original)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(data), `"action":"Grant"`)
+	assert.NotContains(t, string(data), "namingHint")
+
+	var result AccessProviderInput
+	require.NoError(t, json.Unmarshal(data, &result))
+
+	assert.Equal(t, original, result)
+}
+
+func TestAccessProviderLockReasons(t *testing.T) {
+	reason := "managed by terraform"
+
+	ap := &AccessProvider{Locks: []AccessProviderLocksAccessProviderLockData{
+		{AccessProviderLocks: AccessProviderLocks{LockKey: AccessProviderLockWholock, Details: AccessProviderLocksDetailsAccessProviderLockDetails{AccessProviderLockDetails: AccessProviderLockDetails{Reason: &reason}}}},
+		{AccessProviderLocks: AccessProviderLocks{LockKey: AccessProviderLockDeletelock}},
+	}}
+
+	assert.True(t, IsAccessProviderLocked(ap))
+	assert.False(t, IsAccessProviderLocked(&AccessProvider{}))
+	assert.Equal(t, map[AccessProviderLock]string{
+		AccessProviderLockWholock:    "managed by terraform",
+		AccessProviderLockDeletelock: "",
+	}, AccessProviderLockReasons(ap))
+}
+
+func TestIsAccessProviderInternalizable(t *testing.T) {
+	assert.True(t, IsAccessProviderInternalizable(&AccessProvider{}))
+	assert.False(t, IsAccessProviderInternalizable(&AccessProvider{NotInternalizable: true}))
+}
+
+func TestAccessProviderDeletedAt(t *testing.T) {
+	modifiedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	deletedAt, deleted := AccessProviderDeletedAt(&AccessProvider{State: models.AccessProviderStateDeleted, ModifiedAt: modifiedAt})
+
+	assert.True(t, deleted)
+	assert.Equal(t, modifiedAt, deletedAt)
+
+	_, deleted = AccessProviderDeletedAt(&AccessProvider{State: models.AccessProviderStateActive, ModifiedAt: modifiedAt})
+
+	assert.False(t, deleted)
+}
+
+func TestWhatListPermissions(t *testing.T) {
+	selectPermission, insertPermission, read, write := "SELECT", "INSERT", "read", "write"
+
+	items := []AccessProviderWhatListItem{
+		{DataObject: &AccessProviderWhatListItemDataObject{DataObject: DataObject{Id: "do1"}}, Permissions: []*string{&selectPermission}, GlobalPermissions: []*string{&read}},
+		{DataObject: &AccessProviderWhatListItemDataObject{DataObject: DataObject{Id: "do2"}}, GlobalPermissions: []*string{&write}},
+		{DataObject: &AccessProviderWhatListItemDataObject{DataObject: DataObject{Id: "do1"}}, Permissions: []*string{&selectPermission, &insertPermission, nil}},
+		{Permissions: []*string{&selectPermission}},
+	}
+
+	assert.Equal(t, map[string]AccessProviderWhatPermissions{
+		"do1": {Permissions: []string{"SELECT", "INSERT"}, GlobalPermissions: []string{"read"}},
+		"do2": {GlobalPermissions: []string{"write"}},
+	}, WhatListPermissions(items))
+}
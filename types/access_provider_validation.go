@@ -0,0 +1,74 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/raito-io/sdk-go/types/models"
+)
+
+// accessProviderLocks contains all locks that can be set on an AccessProvider.
+var accessProviderLocks = []AccessProviderLock{
+	AccessProviderLockWholock,
+	AccessProviderLockInheritancelock,
+	AccessProviderLockWhatlock,
+	AccessProviderLockNamelock,
+	AccessProviderLockDeletelock,
+	AccessProviderLockOwnerlock,
+}
+
+// ValidateAccessProviderInput checks the AccessProviderInput for missing required fields and unsupported enum values before it is sent to Raito Cloud.
+// All problems found are aggregated in a single ErrInvalidInput. Nil is returned if the input is valid.
+// Note that the Raito API may still reject a valid input, e.g. if a referenced data source does not exist.
+func ValidateAccessProviderInput(input *AccessProviderInput) error {
+	var errs []error
+
+	if input.Name == nil || strings.TrimSpace(*input.Name) == "" {
+		errs = append(errs, errors.New("name is required"))
+	}
+
+	if input.Action == nil {
+		errs = append(errs, errors.New("action is required"))
+	} else if !input.Action.IsAAccessProviderAction() {
+		errs = append(errs, fmt.Errorf("action %d is not supported, expected one of %s", *input.Action, strings.Join(models.AccessProviderActionStrings(), ", ")))
+	}
+
+	for i := range input.Locks {
+		if !slices.Contains(accessProviderLocks, input.Locks[i].LockKey) {
+			errs = append(errs, fmt.Errorf("locks[%d]: lockKey %q is not supported", i, input.Locks[i].LockKey))
+		}
+	}
+
+	for i := range input.DataSources {
+		if input.DataSources[i].DataSource == "" {
+			errs = append(errs, fmt.Errorf("dataSources[%d]: dataSource is required", i))
+		}
+	}
+
+	for i := range input.WhoItems {
+		item := &input.WhoItems[i]
+		if item.User == nil && item.Group == nil && item.AccessProvider == nil && item.Recipient == nil {
+			errs = append(errs, fmt.Errorf("whoItems[%d]: one of user, group, accessProvider or recipient is required", i))
+		}
+	}
+
+	for i := range input.WhatDataObjects {
+		if len(input.WhatDataObjects[i].DataObjects) == 0 && len(input.WhatDataObjects[i].DataObjectByName) == 0 {
+			errs = append(errs, fmt.Errorf("whatDataObjects[%d]: dataObjects or dataObjectByName is required", i))
+		}
+	}
+
+	for i := range input.WhatAccessProviders {
+		if input.WhatAccessProviders[i].AccessProvider == "" {
+			errs = append(errs, fmt.Errorf("whatAccessProviders[%d]: accessProvider is required", i))
+		}
+	}
+
+	if len(errs) > 0 {
+		return NewErrInvalidInput(errors.Join(errs...).Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/raito-io/sdk-go/types/models"
+)
+
+func TestValidateAccessProviderInput(t *testing.T) {
+	name := "ap"
+	action := models.AccessProviderActionGrant
+	user := "user-id"
+
+	t.Run("Valid", func(t *testing.T) {
+		err := ValidateAccessProviderInput(&AccessProviderInput{
+			Name:        &name,
+			Action:      &action,
+			DataSources: []AccessProviderDataSourceInput{{DataSource: "ds-id"}},
+			WhoItems:    []WhoItemInput{{User: &user}},
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		err := ValidateAccessProviderInput(&AccessProviderInput{
+			DataSources:         []AccessProviderDataSourceInput{{DataSource: "ds-id"}, {}},
+			WhoItems:            []WhoItemInput{{}},
+			WhatDataObjects:     []AccessProviderWhatInputDO{{}},
+			WhatAccessProviders: []AccessProviderWhatInputAP{{}},
+		})
+
+		var invalidInputErr *ErrInvalidInput
+		assert.ErrorAs(t, err, &invalidInputErr)
+		assert.ErrorContains(t, err, "name is required")
+		assert.ErrorContains(t, err, "action is required")
+		assert.ErrorContains(t, err, "dataSources[1]: dataSource is required")
+		assert.ErrorContains(t, err, "whoItems[0]: one of user, group, accessProvider or recipient is required")
+		assert.ErrorContains(t, err, "whatDataObjects[0]: dataObjects or dataObjectByName is required")
+		assert.ErrorContains(t, err, "whatAccessProviders[0]: accessProvider is required")
+	})
+
+	t.Run("Unsupported enum values", func(t *testing.T) {
+		unknownAction := models.AccessProviderAction(42)
+
+		err := ValidateAccessProviderInput(&AccessProviderInput{
+			Name:   &name,
+			Action: &unknownAction,
+			Locks:  []AccessProviderLockDataInput{{LockKey: AccessProviderLockWholock}, {LockKey: "SomeLock"}},
+		})
+
+		assert.ErrorIs(t, err, &ErrInvalidInput{})
+		assert.ErrorContains(t, err, "action 42 is not supported")
+		assert.ErrorContains(t, err, `locks[1]: lockKey "SomeLock" is not supported`)
+		assert.NotContains(t, err.Error(), "locks[0]")
+	})
+}
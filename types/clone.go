@@ -0,0 +1,86 @@
+package types
+
+import (
+	"reflect"
+)
+
+// CloneAccessProviderInput returns a deep copy of the AccessProviderInput.
+// Modifying the nested slices, maps or pointer fields of the copy does not affect the original, so a template input can be safely reused.
+func CloneAccessProviderInput(input *AccessProviderInput) *AccessProviderInput {
+	return deepCopy(input)
+}
+
+// CloneAccessProvider returns a deep copy of the AccessProvider.
+// Modifying the nested slices, maps or pointer fields of the copy does not affect the original.
+func CloneAccessProvider(ap *AccessProvider) *AccessProvider {
+	return deepCopy(ap)
+}
+
+// deepCopy recursively copies all pointers, interfaces, slices and maps reachable through exported fields of v.
+// Unexported fields, such as the internals of time.Time, are copied by value.
+// As the generated types are trees, cyclic references are not supported.
+func deepCopy[T any](v T) T {
+	src := reflect.ValueOf(&v).Elem()
+	dst := reflect.New(src.Type()).Elem()
+
+	copyValue(dst, src)
+
+	return dst.Interface().(T)
+}
+
+func copyValue(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			return
+		}
+
+		value := reflect.New(src.Type().Elem())
+		copyValue(value.Elem(), src.Elem())
+		dst.Set(value)
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+
+		value := reflect.New(src.Elem().Type()).Elem()
+		copyValue(value, src.Elem())
+		dst.Set(value)
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+
+		value := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		for i := range src.Len() {
+			copyValue(value.Index(i), src.Index(i))
+		}
+
+		dst.Set(value)
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+
+		value := reflect.MakeMapWithSize(src.Type(), src.Len())
+
+		iter := src.MapRange()
+		for iter.Next() {
+			element := reflect.New(iter.Value().Type()).Elem()
+			copyValue(element, iter.Value())
+			value.SetMapIndex(iter.Key(), element)
+		}
+
+		dst.Set(value)
+	case reflect.Struct:
+		dst.Set(src)
+
+		for i := range src.NumField() {
+			if dst.Field(i).CanSet() {
+				copyValue(dst.Field(i), src.Field(i))
+			}
+		}
+	default:
+		dst.Set(src)
+	}
+}
@@ -0,0 +1,61 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/types/models"
+)
+
+func TestCloneAccessProviderInput(t *testing.T) {
+	name := "template"
+	user := "user-1"
+	dataObject := "do-1"
+
+	original := &AccessProviderInput{
+		Name:            &name,
+		WhoItems:        []WhoItemInput{{User: &user}},
+		DataSources:     []AccessProviderDataSourceInput{{DataSource: "ds-1"}},
+		WhatDataObjects: []AccessProviderWhatInputDO{{DataObjects: []*string{&dataObject}}},
+	}
+
+	clone := CloneAccessProviderInput(original)
+	require.Equal(t, original, clone)
+
+	*clone.Name = "variant"
+	*clone.WhoItems[0].User = "user-2"
+	clone.WhoItems = append(clone.WhoItems, WhoItemInput{User: &user})
+	clone.DataSources[0].DataSource = "ds-2"
+	*clone.WhatDataObjects[0].DataObjects[0] = "do-2"
+
+	assert.Equal(t, "template", *original.Name)
+	assert.Len(t, original.WhoItems, 1)
+	assert.Equal(t, "user-1", *original.WhoItems[0].User)
+	assert.Equal(t, "ds-1", original.DataSources[0].DataSource)
+	assert.Equal(t, "do-1", *original.WhatDataObjects[0].DataObjects[0])
+}
+
+func TestCloneAccessProvider(t *testing.T) {
+	namingHint := "hint"
+
+	original := &AccessProvider{
+		Id:         "ap-1",
+		CreatedAt:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Name:       "ap",
+		NamingHint: &namingHint,
+		Action:     models.AccessProviderActionGrant,
+		Locks:      []AccessProviderLocksAccessProviderLockData{{AccessProviderLocks: AccessProviderLocks{LockKey: AccessProviderLockNamelock}}},
+	}
+
+	clone := CloneAccessProvider(original)
+	require.Equal(t, original, clone)
+
+	*clone.NamingHint = "other"
+	clone.Locks[0].LockKey = AccessProviderLockDeletelock
+
+	assert.Equal(t, "hint", *original.NamingHint)
+	assert.Equal(t, AccessProviderLockNamelock, original.Locks[0].LockKey)
+}
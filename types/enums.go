@@ -0,0 +1,73 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/raito-io/sdk-go/types/models"
+)
+
+// ParseAccessProviderState returns the AccessProviderState with the given name, e.g. "Active". The name is case-insensitive.
+// An ErrInvalidInput is returned for unknown names.
+func ParseAccessProviderState(s string) (models.AccessProviderState, error) {
+	state, err := models.AccessProviderStateString(s)
+	if err != nil {
+		return 0, unknownEnumValueError("access provider state", s, models.AccessProviderStateStrings())
+	}
+
+	return state, nil
+}
+
+// ParseAccessProviderAction returns the AccessProviderAction with the given name, e.g. "Grant". The name is case-insensitive.
+// An ErrInvalidInput is returned for unknown names.
+func ParseAccessProviderAction(s string) (models.AccessProviderAction, error) {
+	action, err := models.AccessProviderActionString(s)
+	if err != nil {
+		return 0, unknownEnumValueError("access provider action", s, models.AccessProviderActionStrings())
+	}
+
+	return action, nil
+}
+
+// ParseAccessWhoItemType returns the AccessWhoItemType with the given name, e.g. "WhoGrant". The name is case-insensitive.
+// An ErrInvalidInput is returned for unknown names.
+func ParseAccessWhoItemType(s string) (AccessWhoItemType, error) {
+	return parseStringEnum("who item type", s, []AccessWhoItemType{AccessWhoItemTypeWhogrant, AccessWhoItemTypeWhopromise})
+}
+
+// ParseWhoAndWhatType returns the WhoAndWhatType with the given name, e.g. "Static". The name is case-insensitive.
+// An ErrInvalidInput is returned for unknown names.
+func ParseWhoAndWhatType(s string) (WhoAndWhatType, error) {
+	return parseStringEnum("who and what type", s, []WhoAndWhatType{WhoAndWhatTypeStatic, WhoAndWhatTypeDynamic, WhoAndWhatTypeUnknown})
+}
+
+// ParseAccessProviderLock returns the AccessProviderLock with the given name, e.g. "WhoLock". The name is case-insensitive.
+// An ErrInvalidInput is returned for unknown names.
+func ParseAccessProviderLock(s string) (AccessProviderLock, error) {
+	return parseStringEnum("access provider lock", s, []AccessProviderLock{
+		AccessProviderLockWholock,
+		AccessProviderLockInheritancelock,
+		AccessProviderLockWhatlock,
+		AccessProviderLockNamelock,
+		AccessProviderLockDeletelock,
+		AccessProviderLockOwnerlock,
+	})
+}
+
+func parseStringEnum[T ~string](enumName string, s string, values []T) (T, error) {
+	names := make([]string, 0, len(values))
+
+	for _, value := range values {
+		if strings.EqualFold(string(value), s) {
+			return value, nil
+		}
+
+		names = append(names, string(value))
+	}
+
+	return "", unknownEnumValueError(enumName, s, names)
+}
+
+func unknownEnumValueError(enumName string, s string, names []string) error {
+	return NewErrInvalidInput(fmt.Sprintf("unknown %s %q, expected one of %s", enumName, s, strings.Join(names, ", ")))
+}
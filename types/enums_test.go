@@ -0,0 +1,53 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/types/models"
+)
+
+func TestParseEnums(t *testing.T) {
+	t.Run("Known values", func(t *testing.T) {
+		state, err := ParseAccessProviderState("inactive")
+		require.NoError(t, err)
+		assert.Equal(t, models.AccessProviderStateInactive, state)
+
+		action, err := ParseAccessProviderAction(models.AccessProviderActionMask.String())
+		require.NoError(t, err)
+		assert.Equal(t, models.AccessProviderActionMask, action)
+
+		whoItemType, err := ParseAccessWhoItemType("whopromise")
+		require.NoError(t, err)
+		assert.Equal(t, AccessWhoItemTypeWhopromise, whoItemType)
+
+		whoAndWhatType, err := ParseWhoAndWhatType("Dynamic")
+		require.NoError(t, err)
+		assert.Equal(t, WhoAndWhatTypeDynamic, whoAndWhatType)
+
+		lock, err := ParseAccessProviderLock("WhatLock")
+		require.NoError(t, err)
+		assert.Equal(t, AccessProviderLockWhatlock, lock)
+	})
+
+	t.Run("Unknown values", func(t *testing.T) {
+		_, err := ParseAccessProviderState("Pending")
+		assert.ErrorIs(t, err, &ErrInvalidInput{})
+		assert.EqualError(t, err, `invalid input: unknown access provider state "Pending", expected one of Active, Inactive, Deleted`)
+
+		_, err = ParseAccessProviderAction("")
+		assert.ErrorIs(t, err, &ErrInvalidInput{})
+
+		_, err = ParseAccessWhoItemType("Grant")
+		assert.ErrorIs(t, err, &ErrInvalidInput{})
+		assert.EqualError(t, err, `invalid input: unknown who item type "Grant", expected one of WhoGrant, WhoPromise`)
+
+		_, err = ParseWhoAndWhatType("static-ish")
+		assert.ErrorIs(t, err, &ErrInvalidInput{})
+
+		_, err = ParseAccessProviderLock("ReadLock")
+		assert.ErrorIs(t, err, &ErrInvalidInput{})
+	})
+}
@@ -3,11 +3,17 @@ package types
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 )
 
 var ErrUnknownType = errors.New("unknown type")
 
+// ErrNotFound is returned if the object an operation concerns does not exist.
+// Operation and Resource are only set by operations that know which kind of object they requested, e.g. "getAccessProvider" and "AccessProvider".
 type ErrNotFound struct {
+	Operation string
+	Resource  string
 	Type      *string
 	Id        string
 	ServerMsg string
@@ -21,7 +27,22 @@ func NewErrNotFound(id string, t *string, msg string) *ErrNotFound {
 	}
 }
 
+// NewErrResourceNotFound returns an ErrNotFound for the resource with the given id, requested by operation.
+func NewErrResourceNotFound(operation string, resource string, id string, t *string, msg string) *ErrNotFound {
+	return &ErrNotFound{
+		Operation: operation,
+		Resource:  resource,
+		Type:      t,
+		Id:        id,
+		ServerMsg: msg,
+	}
+}
+
 func (e *ErrNotFound) Error() string {
+	if e.Resource != "" {
+		return fmt.Sprintf("not able to find %s with id %q for operation %s: %s", e.Resource, e.Id, e.Operation, e.ServerMsg)
+	}
+
 	t := "<unknown>"
 
 	if e.Type != nil {
@@ -31,6 +52,29 @@ func (e *ErrNotFound) Error() string {
 	return fmt.Sprintf("not able to find object %q with id %q: %s", t, e.Id, e.ServerMsg)
 }
 
+// Is reports whether target is an *ErrNotFound matching e.
+// The Id, Type, Operation and Resource of target are only compared if they are set, so errors.Is(err, &ErrNotFound{}) matches any ErrNotFound.
+func (e *ErrNotFound) Is(target error) bool {
+	t, ok := target.(*ErrNotFound)
+	if !ok {
+		return false
+	}
+
+	if t.Id != "" && t.Id != e.Id {
+		return false
+	}
+
+	if t.Operation != "" && t.Operation != e.Operation {
+		return false
+	}
+
+	if t.Resource != "" && t.Resource != e.Resource {
+		return false
+	}
+
+	return t.Type == nil || (e.Type != nil && *t.Type == *e.Type)
+}
+
 type ErrPermissionDenied struct {
 	Operation string
 	ServerMsg string
@@ -47,6 +91,17 @@ func (e *ErrPermissionDenied) Error() string {
 	return fmt.Sprintf("permission denied for %s: %s", e.Operation, e.ServerMsg)
 }
 
+// Is reports whether target is an *ErrPermissionDenied matching e.
+// The Operation of target is only compared if it is set, so errors.Is(err, &ErrPermissionDenied{}) matches any ErrPermissionDenied.
+func (e *ErrPermissionDenied) Is(target error) bool {
+	t, ok := target.(*ErrPermissionDenied)
+	if !ok {
+		return false
+	}
+
+	return t.Operation == "" || t.Operation == e.Operation
+}
+
 type ErrAlreadyExists struct {
 	Type      string
 	ServerMsg string
@@ -63,6 +118,17 @@ func (e *ErrAlreadyExists) Error() string {
 	return fmt.Sprintf("%q already exists: %s", e.Type, e.ServerMsg)
 }
 
+// Is reports whether target is an *ErrAlreadyExists matching e.
+// The Type of target is only compared if it is set, so errors.Is(err, &ErrAlreadyExists{}) matches any ErrAlreadyExists.
+func (e *ErrAlreadyExists) Is(target error) bool {
+	t, ok := target.(*ErrAlreadyExists)
+	if !ok {
+		return false
+	}
+
+	return t.Type == "" || t.Type == e.Type
+}
+
 type ErrInvalidInput struct {
 	ServerMsg string
 }
@@ -77,6 +143,13 @@ func (e *ErrInvalidInput) Error() string {
 	return fmt.Sprintf("invalid input: %s", e.ServerMsg)
 }
 
+// Is reports whether target is an *ErrInvalidInput, so errors.Is(err, &ErrInvalidInput{}) matches any ErrInvalidInput.
+func (e *ErrInvalidInput) Is(target error) bool {
+	_, ok := target.(*ErrInvalidInput)
+
+	return ok
+}
+
 type ErrInvalidEmail struct {
 	Email     string
 	ServerMsg string
@@ -93,6 +166,17 @@ func (e *ErrInvalidEmail) Error() string {
 	return fmt.Sprintf("invalid email address %q: %s", e.Email, e.ServerMsg)
 }
 
+// Is reports whether target is an *ErrInvalidEmail matching e.
+// The Email of target is only compared if it is set, so errors.Is(err, &ErrInvalidEmail{}) matches any ErrInvalidEmail.
+func (e *ErrInvalidEmail) Is(target error) bool {
+	t, ok := target.(*ErrInvalidEmail)
+	if !ok {
+		return false
+	}
+
+	return t.Email == "" || t.Email == e.Email
+}
+
 type ErrClient struct {
 	clientErr error
 }
@@ -106,3 +190,283 @@ func NewErrClient(clientErr error) *ErrClient {
 func (e *ErrClient) Error() string {
 	return fmt.Sprintf("client error: %s", e.clientErr)
 }
+
+func (e *ErrClient) Unwrap() error {
+	return e.clientErr
+}
+
+type ErrRateLimited struct {
+	RetryAfter time.Duration
+}
+
+func NewErrRateLimited(retryAfter time.Duration) *ErrRateLimited {
+	return &ErrRateLimited{
+		RetryAfter: retryAfter,
+	}
+}
+
+func (e *ErrRateLimited) Error() string {
+	return fmt.Sprintf("rate limited: retry after %s", e.RetryAfter)
+}
+
+// Is reports whether target is an *ErrRateLimited, so errors.Is(err, &ErrRateLimited{}) matches any ErrRateLimited.
+func (e *ErrRateLimited) Is(target error) bool {
+	_, ok := target.(*ErrRateLimited)
+
+	return ok
+}
+
+// ErrUnexpectedEdge is returned while paginating if the node of an edge has an unexpected type, e.g. because the Raito API is newer than the SDK.
+type ErrUnexpectedEdge struct {
+	Type string
+}
+
+func NewErrUnexpectedEdge(t string) *ErrUnexpectedEdge {
+	return &ErrUnexpectedEdge{
+		Type: t,
+	}
+}
+
+func (e *ErrUnexpectedEdge) Error() string {
+	return fmt.Sprintf("unexpected edge node of type %s", e.Type)
+}
+
+// Is reports whether target is an *ErrUnexpectedEdge, so errors.Is(err, &ErrUnexpectedEdge{}) matches any ErrUnexpectedEdge.
+func (e *ErrUnexpectedEdge) Is(target error) bool {
+	_, ok := target.(*ErrUnexpectedEdge)
+
+	return ok
+}
+
+// ErrUnexpectedResponse is returned if the Raito API responds to an operation with a type that the SDK does not handle, e.g. because the Raito API is newer than the SDK.
+// Operation is the name of the GraphQL operation and Type is the type of the response that was received.
+// It wraps ErrUnknownType, so errors.Is(err, ErrUnknownType) also matches.
+type ErrUnexpectedResponse struct {
+	Operation string
+	Type      string
+}
+
+func NewErrUnexpectedResponse(operation string, t string) *ErrUnexpectedResponse {
+	return &ErrUnexpectedResponse{
+		Operation: operation,
+		Type:      t,
+	}
+}
+
+func (e *ErrUnexpectedResponse) Error() string {
+	return fmt.Sprintf("unexpected response of type %s for operation %s", e.Type, e.Operation)
+}
+
+func (e *ErrUnexpectedResponse) Unwrap() error {
+	return ErrUnknownType
+}
+
+// Is reports whether target is an *ErrUnexpectedResponse matching e.
+// The Operation of target is only compared if it is set, so errors.Is(err, &ErrUnexpectedResponse{}) matches any ErrUnexpectedResponse.
+func (e *ErrUnexpectedResponse) Is(target error) bool {
+	t, ok := target.(*ErrUnexpectedResponse)
+	if !ok {
+		return false
+	}
+
+	return t.Operation == "" || t.Operation == e.Operation
+}
+
+type ErrResponseTooLarge struct {
+	Limit int64
+}
+
+func NewErrResponseTooLarge(limit int64) *ErrResponseTooLarge {
+	return &ErrResponseTooLarge{
+		Limit: limit,
+	}
+}
+
+func (e *ErrResponseTooLarge) Error() string {
+	return fmt.Sprintf("response body exceeds the limit of %d bytes", e.Limit)
+}
+
+// Is reports whether target is an *ErrResponseTooLarge, so errors.Is(err, &ErrResponseTooLarge{}) matches any ErrResponseTooLarge.
+func (e *ErrResponseTooLarge) Is(target error) bool {
+	_, ok := target.(*ErrResponseTooLarge)
+
+	return ok
+}
+
+// ErrTooManyItems is returned if a list contains more items than can be loaded at once, e.g. by GetAccessProviderWithWhoWhat.
+// The paginated methods should be used instead.
+type ErrTooManyItems struct {
+	List  string
+	Limit int
+}
+
+func NewErrTooManyItems(list string, limit int) *ErrTooManyItems {
+	return &ErrTooManyItems{
+		List:  list,
+		Limit: limit,
+	}
+}
+
+func (e *ErrTooManyItems) Error() string {
+	return fmt.Sprintf("%s contains more than %d items", e.List, e.Limit)
+}
+
+// Is reports whether target is an *ErrTooManyItems, so errors.Is(err, &ErrTooManyItems{}) matches any ErrTooManyItems.
+func (e *ErrTooManyItems) Is(target error) bool {
+	_, ok := target.(*ErrTooManyItems)
+
+	return ok
+}
+
+// ErrNotAttempted is the error of an input of a batch operation that was not attempted, because the context was done before its request could start.
+// It unwraps to the error of the context, e.g. context.Canceled.
+type ErrNotAttempted struct {
+	ctxErr error
+}
+
+func NewErrNotAttempted(ctxErr error) *ErrNotAttempted {
+	return &ErrNotAttempted{
+		ctxErr: ctxErr,
+	}
+}
+
+func (e *ErrNotAttempted) Error() string {
+	return fmt.Sprintf("not attempted: %s", e.ctxErr)
+}
+
+func (e *ErrNotAttempted) Unwrap() error {
+	return e.ctxErr
+}
+
+// Is reports whether target is an *ErrNotAttempted, so errors.Is(err, &ErrNotAttempted{}) matches any ErrNotAttempted.
+func (e *ErrNotAttempted) Is(target error) bool {
+	_, ok := target.(*ErrNotAttempted)
+
+	return ok
+}
+
+type ErrUnauthenticated struct {
+	authErr error
+}
+
+func NewErrUnauthenticated(authErr error) *ErrUnauthenticated {
+	return &ErrUnauthenticated{
+		authErr: authErr,
+	}
+}
+
+func (e *ErrUnauthenticated) Error() string {
+	return fmt.Sprintf("unauthenticated: %s", e.authErr)
+}
+
+func (e *ErrUnauthenticated) Unwrap() error {
+	return e.authErr
+}
+
+// Is reports whether target is an *ErrUnauthenticated, so errors.Is(err, &ErrUnauthenticated{}) matches any ErrUnauthenticated.
+func (e *ErrUnauthenticated) Is(target error) bool {
+	_, ok := target.(*ErrUnauthenticated)
+
+	return ok
+}
+
+type ErrLocked struct {
+	Id    string
+	Locks []AccessProviderLock
+}
+
+func NewErrLocked(id string, locks []AccessProviderLock) *ErrLocked {
+	return &ErrLocked{
+		Id:    id,
+		Locks: locks,
+	}
+}
+
+func (e *ErrLocked) Error() string {
+	return fmt.Sprintf("access provider %q is locked: %v", e.Id, e.Locks)
+}
+
+// Is reports whether target is an *ErrLocked matching e.
+// The Id of target is only compared if it is set, so errors.Is(err, &ErrLocked{}) matches any ErrLocked.
+func (e *ErrLocked) Is(target error) bool {
+	t, ok := target.(*ErrLocked)
+	if !ok {
+		return false
+	}
+
+	return t.Id == "" || t.Id == e.Id
+}
+
+// ErrCorrelated wraps an error of a request together with the correlation id that was sent as X-Request-ID header.
+// The correlation id can be shared with Raito support to find the request.
+type ErrCorrelated struct {
+	CorrelationId string
+	err           error
+}
+
+func NewErrCorrelated(correlationId string, err error) *ErrCorrelated {
+	return &ErrCorrelated{
+		CorrelationId: correlationId,
+		err:           err,
+	}
+}
+
+func (e *ErrCorrelated) Error() string {
+	return fmt.Sprintf("%s (request id %s)", e.err, e.CorrelationId)
+}
+
+func (e *ErrCorrelated) Unwrap() error {
+	return e.err
+}
+
+// CorrelationIdFromError returns the correlation id of the failed request, if err contains an ErrCorrelated.
+func CorrelationIdFromError(err error) (string, bool) {
+	var correlatedErr *ErrCorrelated
+	if errors.As(err, &correlatedErr) {
+		return correlatedErr.CorrelationId, true
+	}
+
+	return "", false
+}
+
+// GraphqlError is a single error returned by the Raito API in the errors array of a GraphQL response.
+type GraphqlError struct {
+	Message string
+	Path    []string
+}
+
+func (e GraphqlError) String() string {
+	if len(e.Path) == 0 {
+		return e.Message
+	}
+
+	return fmt.Sprintf("%s (at %s)", e.Message, strings.Join(e.Path, "."))
+}
+
+// ErrValidation is returned if the Raito API rejected a request, e.g. because of an invalid enum value or an unknown field.
+// In contrast to ErrClient, this indicates a problem with the input of the request rather than with the connection.
+type ErrValidation struct {
+	Errors []GraphqlError
+}
+
+func NewErrValidation(errs []GraphqlError) *ErrValidation {
+	return &ErrValidation{
+		Errors: errs,
+	}
+}
+
+func (e *ErrValidation) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		messages = append(messages, err.String())
+	}
+
+	return fmt.Sprintf("validation error: %s", strings.Join(messages, "; "))
+}
+
+// Is reports whether target is an *ErrValidation, so errors.Is(err, &ErrValidation{}) matches any ErrValidation.
+func (e *ErrValidation) Is(target error) bool {
+	_, ok := target.(*ErrValidation)
+
+	return ok
+}
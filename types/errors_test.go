@@ -0,0 +1,77 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrNotFound(t *testing.T) {
+	accessProviderType := "AccessProvider"
+	dataSourceType := "DataSource"
+
+	err := NewErrClient(fmt.Errorf("wrapped: %w", NewErrNotFound("ap-1", &accessProviderType, "not found")))
+
+	assert.ErrorIs(t, err, &ErrNotFound{})
+	assert.ErrorIs(t, err, &ErrNotFound{Id: "ap-1"})
+	assert.ErrorIs(t, err, &ErrNotFound{Id: "ap-1", Type: &accessProviderType})
+	assert.NotErrorIs(t, err, &ErrNotFound{Id: "ap-2"})
+	assert.NotErrorIs(t, err, &ErrNotFound{Type: &dataSourceType})
+	assert.NotErrorIs(t, err, &ErrPermissionDenied{})
+
+	var notFoundErr *ErrNotFound
+	assert.ErrorAs(t, err, &notFoundErr)
+	assert.Equal(t, "ap-1", notFoundErr.Id)
+	assert.Equal(t, &accessProviderType, notFoundErr.Type)
+}
+
+func TestErrResourceNotFound(t *testing.T) {
+	notFoundType := "NotFoundError"
+
+	err := fmt.Errorf("wrapped: %w", NewErrResourceNotFound("getAccessProvider", "AccessProvider", "ap-1", &notFoundType, "not found"))
+
+	assert.ErrorIs(t, err, &ErrNotFound{})
+	assert.ErrorIs(t, err, &ErrNotFound{Id: "ap-1", Resource: "AccessProvider"})
+	assert.ErrorIs(t, err, &ErrNotFound{Operation: "getAccessProvider"})
+	assert.NotErrorIs(t, err, &ErrNotFound{Resource: "DataSource"})
+	assert.NotErrorIs(t, err, &ErrNotFound{Operation: "deleteAccessProvider"})
+	assert.EqualError(t, err, `wrapped: not able to find AccessProvider with id "ap-1" for operation getAccessProvider: not found`)
+
+	var notFoundErr *ErrNotFound
+	assert.ErrorAs(t, err, &notFoundErr)
+	assert.Equal(t, "getAccessProvider", notFoundErr.Operation)
+	assert.Equal(t, "AccessProvider", notFoundErr.Resource)
+	assert.Equal(t, "ap-1", notFoundErr.Id)
+	assert.Equal(t, &notFoundType, notFoundErr.Type)
+}
+
+func TestErrPermissionDenied(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", NewErrPermissionDenied("getAccessProvider", "denied"))
+
+	assert.ErrorIs(t, err, &ErrPermissionDenied{})
+	assert.ErrorIs(t, err, &ErrPermissionDenied{Operation: "getAccessProvider"})
+	assert.NotErrorIs(t, err, &ErrPermissionDenied{Operation: "deleteAccessProvider"})
+	assert.NotErrorIs(t, err, &ErrNotFound{})
+
+	var permissionDeniedErr *ErrPermissionDenied
+	assert.ErrorAs(t, err, &permissionDeniedErr)
+	assert.Equal(t, "getAccessProvider", permissionDeniedErr.Operation)
+}
+
+func TestErrClient(t *testing.T) {
+	clientErr := errors.New("connection refused")
+
+	assert.ErrorIs(t, NewErrClient(clientErr), clientErr)
+}
+
+func TestErrUnexpectedResponse(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", NewErrUnexpectedResponse("GetAccessProvider", "*schema.GetAccessProviderAccessProviderInvalidInputError"))
+
+	assert.ErrorIs(t, err, &ErrUnexpectedResponse{})
+	assert.ErrorIs(t, err, &ErrUnexpectedResponse{Operation: "GetAccessProvider"})
+	assert.NotErrorIs(t, err, &ErrUnexpectedResponse{Operation: "UpdateAccessProvider"})
+	assert.ErrorIs(t, err, ErrUnknownType)
+	assert.EqualError(t, err, "wrapped: unexpected response of type *schema.GetAccessProviderAccessProviderInvalidInputError for operation GetAccessProvider")
+}
@@ -0,0 +1,277 @@
+// Package filter can be used to build filter inputs without handling the nested pointers manually.
+//
+//	input, err := filter.Search("prod-read").And(filter.State().Eq(models.AccessProviderStateActive)).Build()
+//
+// The Raito API combines all filter fields with AND, while the values of a single field are combined with OR.
+// Conditions can therefore only be combined with And. Combining conditions that can never match, e.g. two different
+// data sources, results in an error when calling Build.
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/raito-io/sdk-go/types"
+	"github.com/raito-io/sdk-go/types/models"
+)
+
+// AccessProviderCondition is a condition on AccessProviders that can be converted to a types.AccessProviderFilterInput.
+type AccessProviderCondition struct {
+	apply func(input *types.AccessProviderFilterInput) error
+}
+
+// And returns a condition that matches if the current condition and all other conditions match.
+func (c AccessProviderCondition) And(others ...AccessProviderCondition) AccessProviderCondition {
+	conditions := append([]AccessProviderCondition{c}, others...)
+
+	return AccessProviderCondition{apply: func(input *types.AccessProviderFilterInput) error {
+		var errs []error
+
+		for _, condition := range conditions {
+			if err := condition.apply(input); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		return errors.Join(errs...)
+	}}
+}
+
+// Build returns the types.AccessProviderFilterInput matching the condition, which can be used in WithAccessProviderListFilter.
+// An ErrInvalidInput is returned if the conditions cannot be combined.
+func (c AccessProviderCondition) Build() (*types.AccessProviderFilterInput, error) {
+	input := &types.AccessProviderFilterInput{}
+
+	if err := c.apply(input); err != nil {
+		return nil, types.NewErrInvalidInput(err.Error())
+	}
+
+	return input, nil
+}
+
+// ListField is a filter field that matches one of multiple values.
+type ListField[T comparable] struct {
+	name  string
+	field func(input *types.AccessProviderFilterInput) *[]T
+}
+
+// Eq returns a condition that matches if the field equals value.
+func (f ListField[T]) Eq(value T) AccessProviderCondition {
+	return f.In(value)
+}
+
+// In returns a condition that matches if the field equals one of values.
+// Combining multiple In conditions on the same field with And only matches the values present in all of them.
+func (f ListField[T]) In(values ...T) AccessProviderCondition {
+	return AccessProviderCondition{apply: func(input *types.AccessProviderFilterInput) error {
+		if len(values) == 0 {
+			return fmt.Errorf("%s: at least one value is required", f.name)
+		}
+
+		current := f.field(input)
+
+		if *current == nil {
+			*current = slices.Clone(values)
+
+			return nil
+		}
+
+		*current = slices.DeleteFunc(*current, func(v T) bool {
+			return !slices.Contains(values, v)
+		})
+
+		if len(*current) == 0 {
+			return fmt.Errorf("%s: combined conditions can never match", f.name)
+		}
+
+		return nil
+	}}
+}
+
+// ValueField is a filter field that matches a single value.
+type ValueField[T comparable] struct {
+	name  string
+	field func(input *types.AccessProviderFilterInput) **T
+}
+
+// Eq returns a condition that matches if the field equals value.
+// Combining Eq conditions with different values on the same field with And results in an error.
+func (f ValueField[T]) Eq(value T) AccessProviderCondition {
+	return AccessProviderCondition{apply: func(input *types.AccessProviderFilterInput) error {
+		current := f.field(input)
+
+		if *current != nil && **current != value {
+			return fmt.Errorf("%s: combined conditions can never match, %v and %v", f.name, **current, value)
+		}
+
+		*current = &value
+
+		return nil
+	}}
+}
+
+// Search returns a condition that matches AccessProviders of which the name contains term.
+// The Raito API does not support filtering on the exact name.
+func Search(term string) AccessProviderCondition {
+	return ValueField[string]{name: "search", field: func(input *types.AccessProviderFilterInput) **string { return &input.Search }}.Eq(term)
+}
+
+// Exclude returns a condition that matches all AccessProviders except the ones with the given ids.
+// Combining multiple Exclude conditions with And excludes all given ids.
+func Exclude(ids ...string) AccessProviderCondition {
+	return AccessProviderCondition{apply: func(input *types.AccessProviderFilterInput) error {
+		for _, id := range ids {
+			if !slices.Contains(input.Exclude, id) {
+				input.Exclude = append(input.Exclude, id)
+			}
+		}
+
+		return nil
+	}}
+}
+
+// State returns the state field of an AccessProvider.
+func State() ListField[models.AccessProviderState] {
+	return ListField[models.AccessProviderState]{name: "states", field: func(input *types.AccessProviderFilterInput) *[]models.AccessProviderState { return &input.States }}
+}
+
+// Action returns the action field of an AccessProvider.
+func Action() ListField[models.AccessProviderAction] {
+	return ListField[models.AccessProviderAction]{name: "actions", field: func(input *types.AccessProviderFilterInput) *[]models.AccessProviderAction { return &input.Actions }}
+}
+
+// Category returns the category id field of an AccessProvider.
+func Category() ListField[string] {
+	return ListField[string]{name: "categories", field: func(input *types.AccessProviderFilterInput) *[]string { return &input.Categories }}
+}
+
+// Owner returns the owner user id field of an AccessProvider.
+func Owner() ListField[string] {
+	return ListField[string]{name: "owners", field: func(input *types.AccessProviderFilterInput) *[]string { return &input.Owners }}
+}
+
+// DataSource returns the data source id field of an AccessProvider.
+func DataSource() ValueField[string] {
+	return ValueField[string]{name: "dataSource", field: func(input *types.AccessProviderFilterInput) **string { return &input.DataSource }}
+}
+
+// Source returns the source field of an AccessProvider.
+func Source() ValueField[string] {
+	return ValueField[string]{name: "source", field: func(input *types.AccessProviderFilterInput) **string { return &input.Source }}
+}
+
+// DataObjectInWhat returns the field matching AccessProviders with the given data object id in their what list.
+func DataObjectInWhat() ValueField[string] {
+	return ValueField[string]{name: "dataObjectInWhat", field: func(input *types.AccessProviderFilterInput) **string { return &input.DataObjectInWhat }}
+}
+
+// External returns the external field of an AccessProvider.
+func External() ValueField[bool] {
+	return ValueField[bool]{name: "external", field: func(input *types.AccessProviderFilterInput) **bool { return &input.External }}
+}
+
+// CanEditWho returns the field matching AccessProviders of which the who list can be edited.
+func CanEditWho() ValueField[bool] {
+	return ValueField[bool]{name: "canEditWho", field: func(input *types.AccessProviderFilterInput) **bool { return &input.CanEditWho }}
+}
+
+// CanEditWhat returns the field matching AccessProviders of which the what list can be edited.
+func CanEditWhat() ValueField[bool] {
+	return ValueField[bool]{name: "canEditWhat", field: func(input *types.AccessProviderFilterInput) **bool { return &input.CanEditWhat }}
+}
+
+// CanEditInheritance returns the field matching AccessProviders of which the inheritance can be edited.
+func CanEditInheritance() ValueField[bool] {
+	return ValueField[bool]{name: "canEditInheritance", field: func(input *types.AccessProviderFilterInput) **bool { return &input.CanEditInheritance }}
+}
+
+// Input returns a condition matching the given filter input, so it can be combined with other conditions.
+// Fields of the input without a builder, e.g. HasTags and CanLinkFrom, can only be combined with conditions having the same value for that field.
+// A nil input matches all AccessProviders.
+func Input(input *types.AccessProviderFilterInput) AccessProviderCondition {
+	if input == nil {
+		return all()
+	}
+
+	var conditions []AccessProviderCondition
+
+	appendValue := func(field ValueField[string], value *string) {
+		if value != nil {
+			conditions = append(conditions, field.Eq(*value))
+		}
+	}
+
+	appendBool := func(field ValueField[bool], value *bool) {
+		if value != nil {
+			conditions = append(conditions, field.Eq(*value))
+		}
+	}
+
+	if input.Search != nil {
+		conditions = append(conditions, Search(*input.Search))
+	}
+
+	appendValue(DataSource(), input.DataSource)
+	appendValue(Source(), input.Source)
+	appendValue(DataObjectInWhat(), input.DataObjectInWhat)
+	appendBool(External(), input.External)
+	appendBool(CanEditWho(), input.CanEditWho)
+	appendBool(CanEditWhat(), input.CanEditWhat)
+	appendBool(CanEditInheritance(), input.CanEditInheritance)
+
+	if len(input.States) > 0 {
+		conditions = append(conditions, State().In(input.States...))
+	}
+
+	if len(input.Actions) > 0 {
+		conditions = append(conditions, Action().In(input.Actions...))
+	}
+
+	if len(input.Categories) > 0 {
+		conditions = append(conditions, Category().In(input.Categories...))
+	}
+
+	if len(input.Owners) > 0 {
+		conditions = append(conditions, Owner().In(input.Owners...))
+	}
+
+	if len(input.Exclude) > 0 {
+		conditions = append(conditions, Exclude(input.Exclude...))
+	}
+
+	if len(input.HasTags) > 0 {
+		conditions = append(conditions, sameValue("hasTags", func(input *types.AccessProviderFilterInput) *[]types.TagFilter { return &input.HasTags }, input.HasTags))
+	}
+
+	if input.CanLinkFrom != nil {
+		conditions = append(conditions, sameValue("canLinkFrom", func(input *types.AccessProviderFilterInput) **types.CanLinkFilter { return &input.CanLinkFrom }, input.CanLinkFrom))
+	}
+
+	if input.CanLinkTo != nil {
+		conditions = append(conditions, sameValue("canLinkTo", func(input *types.AccessProviderFilterInput) **types.CanLinkFilter { return &input.CanLinkTo }, input.CanLinkTo))
+	}
+
+	return all().And(conditions...)
+}
+
+// all returns a condition matching all AccessProviders.
+func all() AccessProviderCondition {
+	return AccessProviderCondition{apply: func(*types.AccessProviderFilterInput) error { return nil }}
+}
+
+// sameValue returns a condition setting the field to value, which can only be combined with conditions setting an equal value.
+func sameValue[T any](name string, field func(input *types.AccessProviderFilterInput) *T, value T) AccessProviderCondition {
+	return AccessProviderCondition{apply: func(input *types.AccessProviderFilterInput) error {
+		current := field(input)
+
+		if !reflect.ValueOf(*current).IsZero() && !reflect.DeepEqual(*current, value) {
+			return fmt.Errorf("%s: conditions with different values cannot be combined", name)
+		}
+
+		*current = value
+
+		return nil
+	}}
+}
@@ -0,0 +1,69 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/types"
+	"github.com/raito-io/sdk-go/types/models"
+)
+
+func TestAccessProviderCondition_Build(t *testing.T) {
+	input, err := Search("prod-read").
+		And(State().Eq(models.AccessProviderStateActive)).
+		And(Action().In(models.AccessProviderActionGrant, models.AccessProviderActionMask), Action().Eq(models.AccessProviderActionGrant)).
+		And(DataSource().Eq("ds-1"), External().Eq(false), Exclude("ap-1"), Exclude("ap-2", "ap-1")).
+		Build()
+
+	require.NoError(t, err)
+
+	search := "prod-read"
+	dataSource := "ds-1"
+	external := false
+
+	assert.Equal(t, &types.AccessProviderFilterInput{
+		Search:     &search,
+		States:     []models.AccessProviderState{models.AccessProviderStateActive},
+		Actions:    []models.AccessProviderAction{models.AccessProviderActionGrant},
+		DataSource: &dataSource,
+		External:   &external,
+		Exclude:    []string{"ap-1", "ap-2"},
+	}, input)
+}
+
+func TestAccessProviderCondition_Build_NeverMatches(t *testing.T) {
+	_, err := DataSource().Eq("ds-1").
+		And(DataSource().Eq("ds-2")).
+		And(State().Eq(models.AccessProviderStateActive), State().Eq(models.AccessProviderStateInactive)).
+		Build()
+
+	assert.ErrorIs(t, err, &types.ErrInvalidInput{})
+	assert.ErrorContains(t, err, "dataSource: combined conditions can never match, ds-1 and ds-2")
+	assert.ErrorContains(t, err, "states: combined conditions can never match")
+}
+
+func TestInput(t *testing.T) {
+	search := "prod-read"
+	tag := "owner"
+
+	input, err := Input(&types.AccessProviderFilterInput{
+		Search:  &search,
+		States:  []models.AccessProviderState{models.AccessProviderStateActive, models.AccessProviderStateInactive},
+		HasTags: []types.TagFilter{{Key: &tag}},
+	}).And(Input(nil), State().Eq(models.AccessProviderStateInactive), Input(&types.AccessProviderFilterInput{HasTags: []types.TagFilter{{Key: &tag}}})).Build()
+
+	require.NoError(t, err)
+	assert.Equal(t, &types.AccessProviderFilterInput{
+		Search:  &search,
+		States:  []models.AccessProviderState{models.AccessProviderStateInactive},
+		HasTags: []types.TagFilter{{Key: &tag}},
+	}, input)
+
+	other := "department"
+
+	_, err = Input(&types.AccessProviderFilterInput{HasTags: []types.TagFilter{{Key: &tag}}}).And(Input(&types.AccessProviderFilterInput{HasTags: []types.TagFilter{{Key: &other}}})).Build()
+
+	assert.ErrorIs(t, err, &types.ErrInvalidInput{})
+}
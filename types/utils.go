@@ -1,30 +1,49 @@
 package types
 
+// ListItem is a single element received from a list channel.
+// A ListItem holds either an item or an error. If an error occurs while listing, a final ListItem carrying that error is
+// sent before the channel is closed. Consumers must check HasError on each received ListItem, as a closed channel does not
+// imply the listing completed successfully.
 type ListItem[T any] struct {
-	item *T
-	err  error
+	item   *T
+	cursor *string
+	err    error
 }
 
 func NewListItemItem[T any](item *T) ListItem[T] {
 	return ListItem[T]{item: item}
 }
 
+func NewListItemItemWithCursor[T any](item *T, cursor *string) ListItem[T] {
+	return ListItem[T]{item: item, cursor: cursor}
+}
+
 func NewListItemError[T any](err error) ListItem[T] {
 	return ListItem[T]{err: err}
 }
 
+// HasError returns true if the ListItem carries an error instead of an item.
 func (l *ListItem[T]) HasError() bool {
 	return l.err != nil
 }
 
+// GetError returns the error carried by the ListItem, if any.
 func (l *ListItem[T]) GetError() error {
 	return l.err
 }
 
+// GetItem returns the item carried by the ListItem, or nil if the ListItem carries an error.
 func (l *ListItem[T]) GetItem() *T {
 	return l.item
 }
 
+// GetCursor returns the pagination cursor of the item, or nil if it is unknown.
+// The cursor can be stored as a checkpoint to resume listing after this item, e.g. with WithAccessProviderListStartCursor.
+func (l *ListItem[T]) GetCursor() *string {
+	return l.cursor
+}
+
+// MustGetItem returns the item carried by the ListItem and panics if there is none.
 func (l *ListItem[T]) MustGetItem() T {
 	if l.item == nil {
 		panic("item was nil")